@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"embed"
 	"fmt"
+	"io/fs"
 	"maps"
+	"path"
 	"slices"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
@@ -13,9 +16,23 @@ import (
 //go:embed fonts/*.ttf
 var _fonts embed.FS
 
+//go:embed arenas/*.json
+var _arenas embed.FS
+
+//go:embed drills/*.json
+var _drills embed.FS
+
+//go:generate go run sounds/gen.go
+
+//go:embed sounds/*.wav
+var _sounds embed.FS
+
 // Assets contains all the assets of the game.
 type Assets struct {
-	fonts map[string][]byte
+	fonts  map[string][]byte
+	arenas [][]byte
+	drills [][]byte
+	sounds map[string][]byte
 }
 
 // Load loads all the assets of the game.
@@ -40,6 +57,53 @@ func Load() (*Assets, error) {
 		assets.fonts[key] = f
 	}
 
+	// Load arenas
+	paths, err := fs.Glob(_arenas, "arenas/*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list arena files: %w", err)
+	}
+
+	for _, path := range paths {
+		a, err := _arenas.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read arena file %q: %w", path, err)
+		}
+
+		assets.arenas = append(assets.arenas, a)
+	}
+
+	// Load drills
+	paths, err = fs.Glob(_drills, "drills/*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drill files: %w", err)
+	}
+
+	for _, path := range paths {
+		d, err := _drills.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read drill file %q: %w", path, err)
+		}
+
+		assets.drills = append(assets.drills, d)
+	}
+
+	// Load sounds
+	paths, err = fs.Glob(_sounds, "sounds/*.wav")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sound files: %w", err)
+	}
+
+	assets.sounds = make(map[string][]byte, len(paths))
+
+	for _, file := range paths {
+		s, err := _sounds.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sound file %q: %w", file, err)
+		}
+
+		assets.sounds[strings.TrimSuffix(path.Base(file), ".wav")] = s
+	}
+
 	return assets, nil
 }
 
@@ -62,3 +126,23 @@ func (a *Assets) NewTextFaceSource(key string) (*text.GoTextFaceSource, error) {
 func (a *Assets) AllFonts() []string {
 	return slices.Collect(maps.Keys(a.fonts))
 }
+
+// Arenas returns the JSON definitions of the built-in arenas.
+func (a *Assets) Arenas() [][]byte {
+	return a.arenas
+}
+
+// Drills returns the JSON definitions of the built-in training drills.
+func (a *Assets) Drills() [][]byte {
+	return a.drills
+}
+
+// Sound returns the WAV file of the sound with the given name, such as "paddle_hit".
+func (a *Assets) Sound(name string) ([]byte, error) {
+	data, ok := a.sounds[name]
+	if !ok {
+		return nil, fmt.Errorf("sound %q not found", name)
+	}
+
+	return data, nil
+}
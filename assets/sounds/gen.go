@@ -0,0 +1,164 @@
+//go:build ignore
+
+// This program synthesizes the sound effects and music tracks of the game as WAV files.
+// Run it with go generate ./assets from the root of the repository.
+package main
+
+import (
+	"encoding/binary"
+	"log"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+)
+
+// sampleRate is the sample rate of the generated files.
+const sampleRate = 22050
+
+// wave returns the value of a waveform, between -1 and 1, at phase t, in cycles.
+type wave func(t float64) float64
+
+func square(t float64) float64 {
+	if math.Mod(t, 1) < 0.5 {
+		return 1
+	}
+
+	return -1
+}
+
+func triangle(t float64) float64 {
+	return 4*math.Abs(math.Mod(t, 1)-0.5) - 1
+}
+
+func sine(t float64) float64 {
+	return math.Sin(2 * math.Pi * t)
+}
+
+func noise(_ float64) float64 {
+	return 2*rand.Float64() - 1 // nolint:gosec
+}
+
+// note is a tone starting at a given time, in seconds.
+type note struct {
+	start, duration float64
+	// from and to are the frequencies at the start and the end of the note, in Hz.
+	from, to float64
+	volume   float64
+	wave     wave
+}
+
+// render mixes the notes into samples of the given duration, in seconds.
+func render(duration float64, notes []note) []float64 {
+	samples := make([]float64, int(duration*sampleRate))
+
+	for _, n := range notes {
+		phase := 0.0
+		first := int(n.start * sampleRate)
+		count := int(n.duration * sampleRate)
+
+		for i := range count {
+			if first+i >= len(samples) {
+				break
+			}
+
+			progress := float64(i) / float64(count)
+			phase += (n.from + (n.to-n.from)*progress) / sampleRate
+
+			// short attack and linear release to avoid clicks
+			envelope := min(float64(i)/(0.005*sampleRate), 1) * (1 - progress)
+			samples[first+i] += n.wave(phase) * n.volume * envelope
+		}
+	}
+
+	return samples
+}
+
+// write saves the samples as a 16-bit mono WAV file in the sounds directory.
+func write(name string, samples []float64) {
+	data := make([]byte, 0, 44+2*len(samples))
+
+	le := binary.LittleEndian
+	data = append(data, "RIFF"...)
+	data = le.AppendUint32(data, uint32(36+2*len(samples)))
+	data = append(data, "WAVEfmt "...)
+	data = le.AppendUint32(data, 16)
+	data = le.AppendUint16(data, 1) // PCM
+	data = le.AppendUint16(data, 1) // mono
+	data = le.AppendUint32(data, sampleRate)
+	data = le.AppendUint32(data, 2*sampleRate)
+	data = le.AppendUint16(data, 2)
+	data = le.AppendUint16(data, 16)
+	data = append(data, "data"...)
+	data = le.AppendUint32(data, uint32(2*len(samples)))
+
+	for _, s := range samples {
+		data = le.AppendUint16(data, uint16(int16(math.Max(-1, math.Min(1, s))*math.MaxInt16)))
+	}
+
+	if err := os.WriteFile(filepath.Join("sounds", name), data, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// frequency returns the frequency of the note n semitones away from A4.
+func frequency(n int) float64 {
+	return 440 * math.Pow(2, float64(n)/12)
+}
+
+// track renders a loop of bars playing chords as arpeggios over a bass line.
+// chords are the semitones from A4 of the root of each bar.
+func track(name string, tempo float64, chords []int, lead wave) {
+	beat := 60 / tempo
+
+	var notes []note
+
+	for bar, root := range chords {
+		start := float64(bar) * 4 * beat
+
+		// bass on every beat, two octaves down
+		for b := range 4 {
+			f := frequency(root - 24)
+			notes = append(notes, note{start + float64(b)*beat, beat * 0.9, f, f, 0.35, triangle})
+		}
+
+		// arpeggio of the major chord on every eighth note
+		for e, interval := range []int{0, 4, 7, 12, 7, 4, 0, 4} {
+			f := frequency(root + interval)
+			notes = append(notes, note{start + float64(e)*beat/2, beat / 2, f, f, 0.12, lead})
+		}
+
+		// hi-hat on the off beats
+		for b := range 4 {
+			notes = append(notes, note{start + (float64(b)+0.5)*beat, 0.03, 1, 1, 0.08, noise})
+		}
+	}
+
+	write(name, render(float64(len(chords))*4*beat, notes))
+}
+
+func main() {
+	write("paddle_hit.wav", render(0.08, []note{{0, 0.08, 480, 480, 0.5, square}}))
+	write("wall_hit.wav", render(0.05, []note{{0, 0.05, 240, 240, 0.4, square}}))
+	write("goal.wav", render(0.4, []note{{0, 0.4, 400, 120, 0.5, square}}))
+	write("win.wav", render(0.6, []note{
+		{0, 0.15, frequency(3), frequency(3), 0.4, square},
+		{0.12, 0.15, frequency(7), frequency(7), 0.4, square},
+		{0.24, 0.15, frequency(10), frequency(10), 0.4, square},
+		{0.36, 0.24, frequency(15), frequency(15), 0.4, square},
+	}))
+	write("lose.wav", render(0.7, []note{
+		{0, 0.2, frequency(3), frequency(3), 0.4, square},
+		{0.18, 0.2, frequency(-2), frequency(-2), 0.4, square},
+		{0.36, 0.34, frequency(-5), frequency(-9), 0.4, square},
+	}))
+	write("menu_move.wav", render(0.04, []note{{0, 0.04, 880, 880, 0.3, sine}}))
+	write("menu_select.wav", render(0.1, []note{
+		{0, 0.05, 660, 660, 0.3, sine},
+		{0.05, 0.05, 990, 990, 0.3, sine},
+	}))
+
+	// a calm loop for the menus and a faster one for matches
+	track("menu_music.wav", 90, []int{3, -12, -4, -2}, triangle)
+	track("match_music.wav", 140, []int{-12, -7, -4, -2, -12, -7, -2, -5}, square)
+}
@@ -8,15 +8,18 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/gandarez/pong-multiplayer-go/assets"
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
 	"github.com/gandarez/pong-multiplayer-go/internal/game"
 )
 
 const title = "PONGO"
 
 func main() {
-	ebiten.SetWindowSize(int(game.ScreenWidth)*2, int(game.ScreenHeight)*2)
+	ebiten.SetWindowSize(display.Standard.Width()*2, display.Height*2)
 	ebiten.SetWindowTitle(title)
 	ebiten.SetRunnableOnUnfocused(true)
+	// the game steps its simulation in fixed ticks, so update once per rendered frame
+	ebiten.SetTPS(ebiten.SyncWithFPS)
 
 	// load all assets
 	assets, err := assets.Load()
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/server"
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	accounts := flag.String("accounts", "accounts.json", "file keeping the accounts, empty to keep them in memory")
+	matches := flag.String("matches", "matches.json", "file keeping the matches, empty to keep them in memory")
+	host := flag.String("host", network.BaseURL, "name of the game server the identities are signed for")
+	flag.Parse()
+
+	// the token game servers report results and queue players with, kept out of the command line
+	secret := os.Getenv("PONGO_RESULTS_SECRET")
+	if secret == "" {
+		slog.Warn("PONGO_RESULTS_SECRET isn't set, results and players to queue won't be accepted")
+	}
+
+	registry, err := account.NewRegistry(*accounts)
+	if err != nil {
+		slog.Error("failed to load accounts", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	history, err := server.NewHistory(*matches)
+	if err != nil {
+		slog.Error("failed to load matches", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(registry, history, *host, secret),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	slog.Info("account server listening", slog.String("addr", *addr), slog.String("host", *host))
+
+	if err := srv.ListenAndServe(); err != nil {
+		slog.Error("failed to run account server", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
@@ -2,32 +2,35 @@ package ai
 
 import "math/rand/v2"
 
-const cpuSpeed = 4
-
 // GuessBallPosition returns the new position of the enemy paddle based on the ball position.
-// It returns the new Y position of the enemy paddle.
-func GuessBallPosition(ballY, enemyY, enemyHeight, screenHeight, fieldBorderWidth float64) float64 {
+// Positions are measured along the axis the paddle moves on: Y for the left and right sides
+// and X for the top and bottom ones.
+// dt is the time elapsed in seconds since the last guess.
+// speed is the speed of the enemy paddle in units per second.
+// low and high are the lowest and highest positions of the paddle's edge, where it stops.
+// It returns the new position of the enemy paddle.
+func GuessBallPosition(dt, ballPos, enemyPos, enemyLength, speed, low, high float64) float64 {
 	delta := float64(rand.IntN(15)) // nolint:gosec
 
-	if enemyY < ballY-delta {
-		enemyY += cpuSpeed // Move down
+	if enemyPos < ballPos-delta {
+		enemyPos += speed * dt // Move down
 	}
 
-	if enemyY > ballY+delta {
-		enemyY -= cpuSpeed // Move up
+	if enemyPos > ballPos+delta {
+		enemyPos -= speed * dt // Move up
 	}
 
-	return keepInBounds(enemyY, enemyHeight, screenHeight, fieldBorderWidth)
+	return keepInBounds(enemyPos, enemyLength, low, high)
 }
 
-func keepInBounds(y, height, screenHeight, fieldBorderWidth float64) float64 {
-	if y < 0 {
-		return 0
+func keepInBounds(pos, length, low, high float64) float64 {
+	if pos < low {
+		return low
 	}
 
-	if y > screenHeight-height-fieldBorderWidth {
-		return screenHeight - height - fieldBorderWidth
+	if pos > high-length {
+		return high - length
 	}
 
-	return y
+	return pos
 }
@@ -0,0 +1,285 @@
+// Package audio plays the sound effects and the music of the game.
+package audio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+
+	ebitenaudio "github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+
+	"github.com/gandarez/pong-multiplayer-go/assets"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// sampleRate is the sample rate of the sounds, in Hz.
+const sampleRate = 22050
+
+// Sound is a sound effect.
+type Sound int
+
+const (
+	// PaddleHit is played when the ball bounces off a paddle.
+	PaddleHit Sound = iota
+	// WallHit is played when the ball bounces off a border, a wall or a bumper.
+	WallHit
+	// Goal is played when a goal is scored.
+	Goal
+	// Win is played when a match is won by a player of this device, or watched.
+	Win
+	// Lose is played when a match is lost by the players of this device.
+	Lose
+	// MenuMove is played when moving between the options of a menu or changing their value.
+	MenuMove
+	// MenuSelect is played when choosing an option of a menu.
+	MenuSelect
+)
+
+// soundFiles are the names of the sound effects in the assets.
+// nolint:gochecknoglobals
+var soundFiles = map[Sound]string{
+	PaddleHit:  "paddle_hit",
+	WallHit:    "wall_hit",
+	Goal:       "goal",
+	Win:        "win",
+	Lose:       "lose",
+	MenuMove:   "menu_move",
+	MenuSelect: "menu_select",
+}
+
+// Track is a music track, played in a loop.
+type Track int
+
+const (
+	// NoMusic stops the music.
+	NoMusic Track = iota
+	// MenuMusic is played in the menus.
+	MenuMusic
+	// MatchMusic is played during matches.
+	MatchMusic
+)
+
+// trackFiles are the names of the music tracks in the assets.
+// nolint:gochecknoglobals
+var trackFiles = map[Track]string{
+	MenuMusic:  "menu_music",
+	MatchMusic: "match_music",
+}
+
+// Mix holds the audio preferences of the user.
+type Mix struct {
+	// Effects turns the sound effects on.
+	Effects bool `json:"effects"`
+	// EffectsVolume is the volume of the sound effects, between 0 and 1.
+	EffectsVolume float64 `json:"effects_volume"`
+	// MusicVolume is the volume of the music, between 0 and 1.
+	MusicVolume float64 `json:"music_volume"`
+	// Muted silences the sound effects and the music.
+	Muted bool `json:"muted"`
+}
+
+// DefaultMix returns the default audio preferences.
+func DefaultMix() Mix {
+	return Mix{
+		Effects:       true,
+		EffectsVolume: 0.8,
+		MusicVolume:   0.5,
+	}
+}
+
+// Clamp returns the mix with the volumes brought between 0 and 1.
+func (m Mix) Clamp() Mix {
+	m.EffectsVolume = min(max(m.EffectsVolume, 0), 1)
+	m.MusicVolume = min(max(m.MusicVolume, 0), 1)
+
+	return m
+}
+
+// effectsVolume returns the volume the sound effects are played at.
+func (m Mix) effectsVolume() float64 {
+	if m.Muted || !m.Effects {
+		return 0
+	}
+
+	return m.EffectsVolume
+}
+
+// musicVolume returns the volume the music is played at.
+func (m Mix) musicVolume() float64 {
+	if m.Muted {
+		return 0
+	}
+
+	return m.MusicVolume
+}
+
+// Player plays the sound effects and the music. A nil Player plays nothing, so the game
+// keeps working without sound when the audio can't be initialized.
+type Player struct {
+	context *ebitenaudio.Context
+	// sounds are the decoded samples of each sound effect.
+	sounds map[Sound][]byte
+	tracks map[Track]*ebitenaudio.Player
+	track  Track
+	mix    Mix
+	// localSides are the sides defended by the players of this device in the match being played,
+	// none when watching it.
+	localSides []geometry.Side
+}
+
+// New creates a new Player decoding the sounds from the assets.
+// It must only be called once, as there can only be one audio context.
+func New(a *assets.Assets, mix Mix) (*Player, error) {
+	p := &Player{
+		context: ebitenaudio.NewContext(sampleRate),
+		sounds:  make(map[Sound][]byte, len(soundFiles)),
+		tracks:  make(map[Track]*ebitenaudio.Player, len(trackFiles)),
+		mix:     mix,
+	}
+
+	for sound, name := range soundFiles {
+		stream, err := decode(a, name)
+		if err != nil {
+			return nil, err
+		}
+
+		samples, err := io.ReadAll(stream)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode sound %q: %w", name, err)
+		}
+
+		p.sounds[sound] = samples
+	}
+
+	for track, name := range trackFiles {
+		stream, err := decode(a, name)
+		if err != nil {
+			return nil, err
+		}
+
+		player, err := p.context.NewPlayer(ebitenaudio.NewInfiniteLoop(stream, stream.Length()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create player for track %q: %w", name, err)
+		}
+
+		p.tracks[track] = player
+	}
+
+	return p, nil
+}
+
+// decode decodes the WAV file of the sound with the given name.
+func decode(a *assets.Assets, name string) (*wav.Stream, error) {
+	data, err := a.Sound(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sound: %w", err)
+	}
+
+	stream, err := wav.DecodeWithSampleRate(sampleRate, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode sound %q: %w", name, err)
+	}
+
+	return stream, nil
+}
+
+// Play plays the sound effect, unless the effects are off or muted.
+func (p *Player) Play(sound Sound) {
+	if p == nil {
+		return
+	}
+
+	volume := p.mix.effectsVolume()
+	if volume == 0 {
+		return
+	}
+
+	player := p.context.NewPlayerFromBytes(p.sounds[sound])
+	player.SetVolume(volume)
+	player.Play()
+}
+
+// PlayMusic plays the track in a loop from its start, stopping the one being played.
+// Nothing changes when the track is already being played.
+func (p *Player) PlayMusic(track Track) {
+	if p == nil || track == p.track {
+		return
+	}
+
+	if current, ok := p.tracks[p.track]; ok {
+		current.Pause()
+	}
+
+	p.track = track
+
+	next, ok := p.tracks[track]
+	if !ok {
+		return
+	}
+
+	if err := next.Rewind(); err != nil {
+		slog.Error("failed to rewind music track", slog.Any("error", err))
+	}
+
+	next.SetVolume(p.mix.musicVolume())
+	next.Play()
+}
+
+// Mix returns the audio preferences in use.
+func (p *Player) Mix() Mix {
+	if p == nil {
+		return DefaultMix()
+	}
+
+	return p.mix
+}
+
+// SetMix changes the audio preferences, including the volume of the music being played.
+func (p *Player) SetMix(mix Mix) {
+	if p == nil {
+		return
+	}
+
+	p.mix = mix
+
+	if current, ok := p.tracks[p.track]; ok {
+		current.SetVolume(mix.musicVolume())
+	}
+}
+
+// SetLocalSides sets the sides defended by the players of this device in the match about to be played,
+// telling a win from a loss at its end. There are none when watching a match.
+func (p *Player) SetLocalSides(sides ...geometry.Side) {
+	if p == nil {
+		return
+	}
+
+	p.localSides = sides
+}
+
+// Subscribe plays the sound effects of the events published on the bus.
+func (p *Player) Subscribe(bus *event.Bus) {
+	event.On(bus, func(event.PaddleHit) { p.Play(PaddleHit) })
+	event.On(bus, func(event.WallHit) { p.Play(WallHit) })
+	event.On(bus, func(event.Goal) { p.Play(Goal) })
+	event.On(bus, p.matchOver)
+}
+
+// matchOver plays the win sound when a player of this device won the match or when it was watched,
+// the lose sound otherwise.
+func (p *Player) matchOver(over event.MatchOver) {
+	if p == nil {
+		return
+	}
+
+	if len(p.localSides) == 0 || slices.Contains(p.localSides, over.Winner) {
+		p.Play(Win)
+		return
+	}
+
+	p.Play(Lose)
+}
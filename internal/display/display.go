@@ -0,0 +1,224 @@
+// Package display scales the screen the game is drawn on to the window, whatever the size
+// and the aspect ratio of the window.
+package display
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Height is the height of the screen the game is drawn on. Its width follows the aspect ratio,
+// so layouts anchored to the top, the bottom or the center of the screen fit every window.
+const Height = 480
+
+// Scaling is how the screen is scaled to the window.
+type Scaling int
+
+const (
+	// Fit scales the screen to fill as much of the window as it can.
+	Fit Scaling = iota
+	// Integer scales the screen by whole multiples only, keeping the pixels sharp.
+	Integer
+)
+
+// Scalings returns every scaling, in the order they're offered.
+func Scalings() []Scaling {
+	return []Scaling{Fit, Integer}
+}
+
+// String returns the name of the scaling.
+func (s Scaling) String() string {
+	switch s {
+	case Integer:
+		return "Integer"
+	default:
+		return "Fit"
+	}
+}
+
+// Aspect is the aspect ratio of the screen.
+type Aspect int
+
+const (
+	// Standard is the 4:3 aspect ratio of the classic field.
+	Standard Aspect = iota
+	// Wide is the 16:10 aspect ratio.
+	Wide
+	// Widescreen is the 16:9 aspect ratio.
+	Widescreen
+)
+
+// Aspects returns every aspect ratio, in the order they're offered.
+func Aspects() []Aspect {
+	return []Aspect{Standard, Wide, Widescreen}
+}
+
+// String returns the name of the aspect ratio.
+func (a Aspect) String() string {
+	switch a {
+	case Wide:
+		return "16:10"
+	case Widescreen:
+		return "16:9"
+	default:
+		return "4:3"
+	}
+}
+
+// Width returns the width of a screen of the aspect ratio.
+func (a Aspect) Width() int {
+	ratio := 4.0 / 3
+
+	switch a {
+	case Wide:
+		ratio = 16.0 / 10
+	case Widescreen:
+		ratio = 16.0 / 9
+	}
+
+	return int(math.Round(Height * ratio))
+}
+
+// Settings are how the game is shown.
+type Settings struct {
+	// Fullscreen shows the game on the whole monitor instead of in a window.
+	Fullscreen bool    `json:"fullscreen"`
+	Scaling    Scaling `json:"scaling"`
+	Aspect     Aspect  `json:"aspect"`
+	// Theme is the name of the palette the game is drawn with.
+	Theme string `json:"theme,omitempty"`
+	// PlayerColors draws each paddle in the color of its player instead of the text color.
+	PlayerColors bool `json:"player_colors"`
+}
+
+// Valid returns the settings, replacing the unknown scaling, aspect ratio and theme by the default ones.
+func (s Settings) Valid() Settings {
+	if s.Scaling < Fit || s.Scaling > Integer {
+		s.Scaling = Fit
+	}
+
+	if s.Aspect < Standard || s.Aspect > Widescreen {
+		s.Aspect = Standard
+	}
+
+	if _, ok := theme.Find(s.Theme); !ok {
+		s.Theme = theme.Classic
+	}
+
+	return s
+}
+
+// Screen is the screen the game is drawn on, scaled to the window and centered in it.
+type Screen struct {
+	settings Settings
+	canvas   *ebiten.Image
+	// scale and offset place the screen in the window, in device pixels.
+	scale  float64
+	offset geometry.Vector
+}
+
+// New creates a new Screen and applies the settings.
+func New(settings Settings) *Screen {
+	s := &Screen{scale: 1}
+	s.Apply(settings)
+
+	return s
+}
+
+// Apply applies the settings, entering or leaving fullscreen and switching the theme.
+func (s *Screen) Apply(settings Settings) {
+	s.settings = settings.Valid()
+
+	theme.Set(s.settings.Theme)
+
+	if ebiten.IsFullscreen() != s.settings.Fullscreen {
+		ebiten.SetFullscreen(s.settings.Fullscreen)
+	}
+}
+
+// Settings returns the settings applied.
+func (s *Screen) Settings() Settings {
+	return s.settings
+}
+
+// Size returns the size of the screen.
+func (s *Screen) Size() (int, int) {
+	return s.settings.Aspect.Width(), Height
+}
+
+// Width returns the width of the screen.
+func (s *Screen) Width() float64 {
+	return float64(s.settings.Aspect.Width())
+}
+
+// Layout returns the size of the window in device pixels, which the game is presented on,
+// and places the screen in it.
+func (s *Screen) Layout(outsideWidth, outsideHeight int) (int, int) {
+	factor := ebiten.Monitor().DeviceScaleFactor()
+	width := int(math.Ceil(float64(outsideWidth) * factor))
+	height := int(math.Ceil(float64(outsideHeight) * factor))
+
+	screenWidth, screenHeight := s.Size()
+
+	s.scale = min(float64(width)/float64(screenWidth), float64(height)/float64(screenHeight))
+	if s.settings.Scaling == Integer && s.scale >= 1 {
+		s.scale = math.Floor(s.scale)
+	}
+
+	s.offset = geometry.Vector{
+		X: math.Floor((float64(width) - float64(screenWidth)*s.scale) / 2),
+		Y: math.Floor((float64(height) - float64(screenHeight)*s.scale) / 2),
+	}
+
+	return max(width, 1), max(height, 1)
+}
+
+// Canvas returns the screen to draw the game on, filled with the background of the theme.
+func (s *Screen) Canvas() *ebiten.Image {
+	width, height := s.Size()
+
+	if s.canvas == nil || s.canvas.Bounds().Dx() != width || s.canvas.Bounds().Dy() != height {
+		if s.canvas != nil {
+			s.canvas.Deallocate()
+		}
+
+		s.canvas = ebiten.NewImage(width, height)
+	}
+
+	s.canvas.Fill(theme.Active().Background)
+
+	return s.canvas
+}
+
+// Present draws the canvas on the window, scaled and centered, the rest of the window in the background color.
+func (s *Screen) Present(window *ebiten.Image) {
+	if s.canvas == nil {
+		return
+	}
+
+	window.Fill(theme.Active().Background)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(s.scale, s.scale)
+	op.GeoM.Translate(s.offset.X, s.offset.Y)
+
+	// integer scaling keeps every pixel square, fit scaling blends them to avoid uneven ones
+	if s.settings.Scaling == Fit && s.scale != math.Trunc(s.scale) {
+		op.Filter = ebiten.FilterLinear
+	}
+
+	window.DrawImage(s.canvas, op)
+}
+
+// ToScreen returns the position on the screen of a position in the window, such as the one
+// of the mouse cursor or a touch.
+func (s *Screen) ToScreen(x, y int) geometry.Vector {
+	return geometry.Vector{
+		X: (float64(x) - s.offset.X) / s.scale,
+		Y: (float64(y) - s.offset.Y) / s.scale,
+	}
+}
@@ -0,0 +1,259 @@
+// Package fx adds feedback to paddle hits and goals: gamepad rumble, screen shake,
+// hit flashes, particle bursts and goal slow motion.
+package fx
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	// shakeDuration is how long the screen shakes after a goal, in seconds.
+	shakeDuration = 0.3
+	// shakeStrength is the largest offset of the shaking screen, in screen pixels.
+	shakeStrength = 6.0
+	// flashDuration is how long a flash takes to fade out, in seconds.
+	flashDuration = 0.15
+	// goalFlashAlpha and hitFlashAlpha are the initial opacity of the flashes.
+	goalFlashAlpha = 0.35
+	hitFlashAlpha  = 0.12
+	// slowMotionDuration is how long the game is slowed down after a goal, in seconds.
+	slowMotionDuration = 0.6
+	// slowMotionScale is the speed of the game while slowed down.
+	slowMotionScale = 0.3
+	// particleCount is the number of particles of a burst.
+	particleCount = 12
+	// particleLife is how long a particle lives, in seconds.
+	particleLife = 0.4
+	// particleSize is the width of a particle, in field units.
+	particleSize = 3.0
+	// rumbleDuration is how long a gamepad vibrates after a paddle hit.
+	rumbleDuration = 80 * time.Millisecond
+)
+
+// Settings turn each effect on or off.
+type Settings struct {
+	Rumble     bool `json:"rumble"`
+	Shake      bool `json:"shake"`
+	Flash      bool `json:"flash"`
+	Particles  bool `json:"particles"`
+	SlowMotion bool `json:"slow_motion"`
+	// ReducedMotion turns the camera effects off, whatever their own setting:
+	// screen shake, flashes and slow motion.
+	ReducedMotion bool `json:"reduced_motion"`
+}
+
+// DefaultSettings returns the default settings, with every effect on.
+func DefaultSettings() Settings {
+	return Settings{
+		Rumble:     true,
+		Shake:      true,
+		Flash:      true,
+		Particles:  true,
+		SlowMotion: true,
+	}
+}
+
+type (
+	// Effects plays the effects of the events published on a bus.
+	Effects struct {
+		settings Settings
+		// rumble returns the gamepads of the local players defending a side, nil disables rumble.
+		rumble func(side geometry.Side) []ebiten.GamepadID
+		// shake, flash and slowMotion are the remaining time of each effect, in seconds.
+		shake      float64
+		flash      float64
+		flashAlpha float64
+		slowMotion float64
+		particles  []particle
+	}
+
+	// particle is a spark thrown by a hit or a goal.
+	particle struct {
+		position geometry.Vector
+		velocity geometry.Vector
+		life     float64
+	}
+)
+
+// New creates new effects with the given settings.
+func New(settings Settings) *Effects {
+	return &Effects{settings: settings}
+}
+
+// Settings returns the settings in use.
+func (e *Effects) Settings() Settings {
+	return e.settings
+}
+
+// SetSettings changes the settings, stopping the effects turned off.
+func (e *Effects) SetSettings(settings Settings) {
+	e.settings = settings
+	e.Reset()
+}
+
+// SetRumble sets how to find the gamepads to vibrate when a side hits the ball.
+// rumble can be nil when no gamepad should vibrate.
+func (e *Effects) SetRumble(rumble func(side geometry.Side) []ebiten.GamepadID) {
+	e.rumble = rumble
+}
+
+// Reset stops every effect, e.g. when a new match starts.
+func (e *Effects) Reset() {
+	e.shake, e.flash, e.slowMotion = 0, 0, 0
+	e.particles = nil
+}
+
+// Subscribe plays the effects of the paddle hits and goals published on the bus.
+func (e *Effects) Subscribe(bus *event.Bus) {
+	event.On(bus, e.paddleHit)
+	event.On(bus, e.goal)
+}
+
+// paddleHit vibrates the gamepads of the players who hit the ball, flashes the screen
+// and throws sparks off the paddle, faster as the ball gets faster.
+func (e *Effects) paddleHit(hit event.PaddleHit) {
+	if e.settings.Rumble && e.rumble != nil {
+		for _, id := range e.rumble(hit.Side) {
+			ebiten.VibrateGamepad(id, &ebiten.VibrateGamepadOptions{
+				Duration:        rumbleDuration,
+				StrongMagnitude: 0.3,
+				WeakMagnitude:   0.6,
+			})
+		}
+	}
+
+	if e.camera(e.settings.Flash) {
+		e.startFlash(hitFlashAlpha)
+	}
+
+	if e.settings.Particles {
+		e.burst(hit.Position, hit.Speed/4)
+	}
+}
+
+// goal shakes the screen, flashes it, slows the game down and throws sparks out of the goal.
+func (e *Effects) goal(goal event.Goal) {
+	if e.camera(e.settings.Shake) {
+		e.shake = shakeDuration
+	}
+
+	if e.camera(e.settings.Flash) {
+		e.startFlash(goalFlashAlpha)
+	}
+
+	if e.camera(e.settings.SlowMotion) {
+		e.slowMotion = slowMotionDuration
+	}
+
+	if e.settings.Particles && goal.Side != geometry.Undefined {
+		e.burst(goal.Position, 120)
+	}
+}
+
+// camera returns whether a camera effect turned on by enabled can be played.
+func (e *Effects) camera(enabled bool) bool {
+	return enabled && !e.settings.ReducedMotion
+}
+
+// startFlash flashes the screen, unless a brighter flash is fading out.
+func (e *Effects) startFlash(alpha float64) {
+	if e.flash > 0 && e.flashAlpha*e.flash/flashDuration > alpha {
+		return
+	}
+
+	e.flash, e.flashAlpha = flashDuration, alpha
+}
+
+// burst throws particles in every direction from position at about the given speed.
+func (e *Effects) burst(position geometry.Vector, speed float64) {
+	for range particleCount {
+		angle := 2 * math.Pi * rand.Float64() // nolint:gosec
+		s := speed * (0.5 + rand.Float64())   // nolint:gosec
+
+		e.particles = append(e.particles, particle{
+			position: position,
+			velocity: geometry.Vector{X: s * math.Cos(angle), Y: s * math.Sin(angle)},
+			life:     particleLife,
+		})
+	}
+}
+
+// Update advances the effects by dt seconds of real time, a negative dt counting as none.
+func (e *Effects) Update(dt float64) {
+	dt = max(dt, 0)
+
+	e.shake = max(e.shake-dt, 0)
+	e.flash = max(e.flash-dt, 0)
+	e.slowMotion = max(e.slowMotion-dt, 0)
+
+	alive := e.particles[:0]
+
+	for _, p := range e.particles {
+		p.life -= dt
+		if p.life <= 0 {
+			continue
+		}
+
+		p.position = p.position.Add(p.velocity.Scale(dt))
+		alive = append(alive, p)
+	}
+
+	e.particles = alive
+}
+
+// TimeScale returns the speed the game runs at, below 1 during slow motion.
+func (e *Effects) TimeScale() float64 {
+	if e.slowMotion > 0 {
+		return slowMotionScale
+	}
+
+	return 1
+}
+
+// Offset returns how far the screen is moved by the shake, in screen pixels.
+func (e *Effects) Offset() geometry.Vector {
+	if e.shake <= 0 {
+		return geometry.Vector{}
+	}
+
+	strength := shakeStrength * e.shake / shakeDuration
+
+	return geometry.Vector{
+		X: strength * (2*rand.Float64() - 1), // nolint:gosec
+		Y: strength * (2*rand.Float64() - 1), // nolint:gosec
+	}
+}
+
+// Draw draws the particles and the flash on the field, in field units.
+func (e *Effects) Draw(world *ebiten.Image) {
+	clr := theme.Active().Text
+
+	for _, p := range e.particles {
+		vector.DrawFilledRect(
+			world,
+			float32(p.position.X-particleSize/2), float32(p.position.Y-particleSize/2),
+			particleSize, particleSize,
+			theme.Fade(clr, p.life/particleLife),
+			false,
+		)
+	}
+
+	if e.flash > 0 {
+		bounds := world.Bounds()
+		vector.DrawFilledRect(
+			world,
+			0, 0, float32(bounds.Dx()), float32(bounds.Dy()),
+			theme.Fade(clr, e.flashAlpha*e.flash/flashDuration),
+			false,
+		)
+	}
+}
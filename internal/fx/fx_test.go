@@ -0,0 +1,68 @@
+package fx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/timestep"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// TestEffects_Update drives the effects with the deltas of the clock the game updates them with,
+// at 60 frames per second, and checks every effect of a goal fades out.
+func TestEffects_Update(t *testing.T) {
+	e := New(DefaultSettings())
+	bus := event.NewBus()
+	e.Subscribe(bus)
+
+	var clock timestep.Clock
+
+	now := time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC)
+	e.Update(clock.Delta(now))
+
+	bus.Publish(event.Goal{Side: geometry.Left, Scorer: geometry.Right, Position: geometry.Vector{X: 10, Y: 240}})
+
+	if e.TimeScale() != slowMotionScale || e.Offset() == (geometry.Vector{}) || len(e.particles) != particleCount {
+		t.Fatalf("goal didn't start the effects: %+v", e)
+	}
+
+	frame := time.Second / 60
+	previous := e.shake
+
+	for elapsed := time.Duration(0); elapsed < time.Second; elapsed += frame {
+		now = now.Add(frame)
+		e.Update(clock.Delta(now))
+
+		if e.shake > previous {
+			t.Fatalf("shake grew from %v to %v", previous, e.shake)
+		}
+
+		previous = e.shake
+	}
+
+	if e.TimeScale() != 1 {
+		t.Errorf("time scale = %v after a second, want 1", e.TimeScale())
+	}
+
+	if e.shake != 0 || e.flash != 0 || e.Offset() != (geometry.Vector{}) {
+		t.Errorf("shake and flash = %v and %v after a second, want none", e.shake, e.flash)
+	}
+
+	if len(e.particles) != 0 {
+		t.Errorf("%d particles alive after a second, want none", len(e.particles))
+	}
+}
+
+func TestEffects_Update_NegativeDelta(t *testing.T) {
+	e := New(DefaultSettings())
+	bus := event.NewBus()
+	e.Subscribe(bus)
+
+	bus.Publish(event.Goal{Side: geometry.Left, Scorer: geometry.Right})
+	e.Update(-1)
+
+	if e.shake != shakeDuration || e.slowMotion != slowMotionDuration {
+		t.Errorf("shake and slow motion = %v and %v, want them unchanged", e.shake, e.slowMotion)
+	}
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"math/rand/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/powerup"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	// curveSpeed is the angular velocity, in degrees per second, of a ball that collected a curve.
+	curveSpeed = 90
+	// shieldWidth is the width of the wall protecting a shielded goal.
+	shieldWidth = 5
+)
+
+// arcade applies the power-ups of a local arcade match.
+type arcade struct {
+	arena  arena.Arena
+	format rules.Format
+	field  *powerup.Field
+	// bouncerHeights keeps the original height of each paddle, before any effect.
+	bouncerHeights map[player.Player]float64
+}
+
+// remoteArcade holds the power-ups of a network arcade match as received from the server.
+type remoteArcade struct {
+	powerUps []powerup.PowerUp
+	shields  map[geometry.Side]bool
+}
+
+// newArcade creates a new arcade spawning power-ups in the middle of the arena.
+func newArcade(field arena.Arena, format rules.Format) *arcade {
+	return &arcade{
+		arena:  field,
+		format: format,
+		field: powerup.NewField(
+			geometry.Rect{
+				X:      field.Width / 4,
+				Y:      field.BorderWidth + 20,
+				Width:  field.Width / 2,
+				Height: field.Height - 2*field.BorderWidth - 40,
+			},
+			append(append([]geometry.Rect{}, field.Walls...), field.Bumpers...),
+		),
+		bouncerHeights: make(map[player.Player]float64),
+	}
+}
+
+// update advances the power-ups by dt seconds and resizes the paddles according to
+// the active effects. It returns the time the balls must be moved by.
+func (a *arcade) update(dt float64, players []player.Player) float64 {
+	a.field.Update(dt)
+
+	for _, p := range players {
+		height, ok := a.bouncerHeights[p]
+		if !ok {
+			height = p.BouncerHeight()
+			a.bouncerHeights[p] = height
+		}
+
+		p.SetBouncerHeight(height * a.field.PaddleScale(p.Side()))
+	}
+
+	return dt * a.field.TimeScale()
+}
+
+// shields returns the walls protecting the goals of the shielded sides.
+func (a *arcade) shields() []ball.Obstacle {
+	var obstacles []ball.Obstacle
+
+	for _, side := range a.format.Sides() {
+		if a.field.Shielded(side) {
+			obstacles = append(obstacles, ball.NewWall(shieldBounds(side, a.arena)))
+		}
+	}
+
+	return obstacles
+}
+
+// collect collects the power-up touched by the ball on behalf of the last player who hit it.
+// It returns the balls spawned by the power-up, if any.
+func (a *arcade) collect(b ball.Ball) []ball.Ball {
+	p, ok := a.field.Collect(b.Bounds())
+	if !ok {
+		return nil
+	}
+
+	side := b.LastHit()
+
+	switch p.Kind {
+	case powerup.ExtraBall:
+		return []ball.Ball{b.Spawn(side.Opposite())}
+	case powerup.Curve:
+		curve := float64(curveSpeed)
+		if rand.IntN(2) == 0 { // nolint:gosec
+			curve = -curve
+		}
+
+		b.SetCurve(curve)
+	default:
+		a.field.Activate(p.Kind, side)
+	}
+
+	return nil
+}
+
+// shielded returns true if the server reported a shield protecting the goal on side.
+func (r remoteArcade) shielded(side geometry.Side) bool {
+	return r.shields[side]
+}
+
+// shieldBounds returns the bounds of the shield protecting the goal on side.
+func shieldBounds(side geometry.Side, field arena.Arena) geometry.Rect {
+	switch side {
+	case geometry.Right:
+		return geometry.Rect{X: field.Width - shieldWidth, Width: shieldWidth, Height: field.Height}
+	case geometry.Top:
+		return geometry.Rect{Width: field.Width, Height: shieldWidth}
+	case geometry.Bottom:
+		return geometry.Rect{Y: field.Height - shieldWidth, Width: field.Width, Height: shieldWidth}
+	default:
+		return geometry.Rect{Width: shieldWidth, Height: field.Height}
+	}
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	"github.com/gandarez/pong-multiplayer-go/assets"
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
+)
+
+// loadArenas returns the classic arena followed by the built-in arenas and the
+// arenas found in the user config directory. Arenas that fail to load are skipped.
+func loadArenas(assets *assets.Assets) []arena.Arena {
+	arenas := []arena.Arena{arena.Classic()}
+
+	for _, data := range assets.Arenas() {
+		a, err := arena.Parse(data)
+		if err != nil {
+			slog.Error("failed to parse built-in arena", slog.Any("error", err))
+			continue
+		}
+
+		arenas = append(arenas, a)
+	}
+
+	dir, err := userArenasDir()
+	if err != nil {
+		slog.Warn("failed to find user arenas directory", slog.Any("error", err))
+		return arenas
+	}
+
+	custom, err := arena.LoadDir(dir)
+	if err != nil {
+		slog.Error("failed to load user arenas", slog.Any("error", err))
+	}
+
+	return append(arenas, custom...)
+}
+
+// userArenasDir returns the directory where users can drop their own arena files.
+func userArenasDir() (string, error) {
+	dir, err := storage.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "arenas"), nil
+}
@@ -5,7 +5,7 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 
-	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
@@ -14,17 +14,18 @@ import (
 // width is the ball's width.
 func drawBall(screen *ebiten.Image, position geometry.Vector, width float64, trail []geometry.Vector) {
 	trailLength := len(trail)
+	trailColor := theme.Active().Trail
 	// draw the trail
 	for i, pos := range trail {
 		alpha := uint8(float64(i) * (255.0 / float64(trailLength))) // decrease opacity for older positions
 
 		scaledWidth := width * (1.0 - (float64(i)/float64(trailLength))*(float64(i)/float64(trailLength)))
 
-		drawBallAt(screen, pos, scaledWidth, color.RGBA{100, 100, 100, alpha})
+		drawBallAt(screen, pos, scaledWidth, color.RGBA{trailColor.R, trailColor.G, trailColor.B, alpha})
 	}
 
 	// Draw the current ball position
-	drawBallAt(screen, position, width, ui.DefaultColor)
+	drawBallAt(screen, position, width, theme.Active().Text)
 }
 
 // drawBallAt draws a ball at a specific position.
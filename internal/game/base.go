@@ -1,24 +1,59 @@
 package game
 
 import (
+	"image/color"
 	"log/slog"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
-	"github.com/gandarez/pong-multiplayer-go/internal/menu"
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
 	"github.com/gandarez/pong-multiplayer-go/internal/stat"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/match"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/powerup"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/timestep"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
-const ballTrailSize = 20
+// ballTrailSize is the number of ball positions kept in the trail, a third of a second.
+const ballTrailSize = timestep.TickRate / 3
 
 // baseState contains common logic for playing states.
 type baseState struct {
-	game              *Game
-	level             level.Level
+	game  *Game
+	level level.Level
+	rules rules.Rules
+	arena arena.Arena
+	// match keeps the scores and the clock of local matches and tells when they're over.
+	match *match.Match
+	// world is where the field, players and balls are drawn in field units before
+	// being scaled to fit the screen.
+	world *ebiten.Image
+	// players are all the players on the field and lineup holds the slot of each one.
+	players []player.Player
+	lineup  []slot
+	// scores are the scores of each side defending a goal.
+	scores map[geometry.Side]*score
+	// namePositions are where the names of each side are drawn in network matches.
+	namePositions map[geometry.Side]geometry.Vector
+	// balls are the balls in play, there's always at least one.
+	balls []ball.Ball
+	// arcade holds the power-ups of local arcade matches, nil otherwise.
+	arcade *arcade
+	// remoteArcade holds the power-ups received from the server in network matches.
+	remoteArcade remoteArcade
+	// remoteClock is the clock received from the server in timed network matches, nil otherwise.
+	remoteClock *network.ClockState
+	// networked is true when positions come from the server and can't be interpolated.
+	networked         bool
 	pauseMenu         *pauseMenu
 	metric            *stat.Metric
 	gamePaused        bool
@@ -26,23 +61,52 @@ type baseState struct {
 	pingCurrentPlayer int
 	pingOpponent      int
 	ballTrail         []geometry.Vector
+	timestep          *timestep.Timestep
+	// syncedAt is when the last state was received from the server in network matches.
+	syncedAt time.Time
+	// ratings are the skill ratings received from the server in network matches, by player name.
+	ratings map[string]playerRating
 }
 
-// newBasePlayingState creates a new baseState.
-func newBasePlayingState(game *Game, lvl level.Level) *baseState {
-	pauseMenu := newPauseMenu(game.font, ScreenWidth)
+// playerRating is the skill rating of a player and how much the result of the match changed it.
+type playerRating struct {
+	value, change float64
+}
+
+// newBasePlayingState creates a new baseState to play in the given arena.
+func newBasePlayingState(game *Game, lvl level.Level, field arena.Arena) *baseState {
+	pauseMenu := newPauseMenu(game.font)
 
-	metric, err := stat.New(game.font, ScreenWidth)
+	metric, err := stat.New(game.font, int(game.screenWidth()))
 	if err != nil {
 		slog.Error("failed to create metric", slog.Any("error", err))
 	}
 
+	matchRules := game.menu.MatchRules()
+
+	var arcade *arcade
+	if matchRules.Arcade {
+		arcade = newArcade(field, matchRules.Format)
+	}
+
+	// apply the settings changed in the menu, no gamepad vibrates until the state says which ones
+	game.effects.SetSettings(game.settings.Effects)
+	game.effects.SetRumble(nil)
+
 	return &baseState{
-		game:      game,
-		level:     lvl,
-		pauseMenu: pauseMenu,
-		metric:    metric,
-		ballTrail: make([]geometry.Vector, 0, ballTrailSize),
+		game:       game,
+		level:      lvl,
+		rules:      matchRules,
+		arena:      field,
+		match:      match.New(matchRules, game.events),
+		world:      ebiten.NewImage(int(field.Width), int(field.Height)),
+		scores:     newScores(game.font, matchRules.Format, game.screenWidth()),
+		arcade:     arcade,
+		pauseMenu:  pauseMenu,
+		metric:     metric,
+		showMetric: game.settings.ShowMetrics,
+		ballTrail:  make([]geometry.Vector, 0, ballTrailSize),
+		timestep:   timestep.New(timestep.TickRate),
 	}
 }
 
@@ -50,11 +114,22 @@ func newBasePlayingState(game *Game, lvl level.Level) *baseState {
 func (s *baseState) update() {
 	// handle pause menu
 	if s.pauseMenu.isShown {
-		s.pauseMenu.update()
+		s.pauseMenu.update(s.game.controls.Pointer())
+
+		// pressing pause again resumes the match
+		if s.game.controls.JustPressed(input.Pause) {
+			s.pauseMenu.resume()
+		}
 
 		if s.pauseMenu.ShouldExit {
+			// quitting matches leaves them out of the stats, and tournament matches and league fixtures
+			// to be played again
+			s.game.tracker.Stop()
+			s.game.tournamentTracker.Stop()
+			s.game.leagueTracker.Stop()
+
 			// force reset the menu
-			s.game.menu = menu.New(s.game.font, ScreenWidth, ScreenHeight)
+			s.game.resetMenu()
 			s.game.changeState(newMainMenuState(s.game))
 
 			return
@@ -63,18 +138,22 @@ func (s *baseState) update() {
 		if s.pauseMenu.ShouldResume {
 			s.gamePaused = false
 			s.pauseMenu.ShouldResume = false
+			// don't simulate the time spent in the pause menu
+			s.timestep.Reset()
 		}
 
 		return
 	}
 
-	// if TAB is pressed, then show/hide metrics
-	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+	// show/hide metrics
+	if s.game.controls.JustPressed(input.ToggleMetrics) {
 		s.showMetric = !s.showMetric
+		s.game.settings.ShowMetrics = s.showMetric
+		s.game.saveSettings()
 	}
 
 	// check for pause input
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) && s.game.currentState.canPause() {
+	if (s.game.controls.JustPressed(input.Pause) || s.pauseTapped()) && s.game.currentState.canPause() {
 		s.gamePaused = true
 		s.pauseMenu.show()
 
@@ -82,14 +161,85 @@ func (s *baseState) update() {
 	}
 }
 
-// draw handles common drawing logic, including pause menu.
-func (s *baseState) draw(screen *ebiten.Image) {
+// addPlayer adds a player to the field in the given slot.
+func (s *baseState) addPlayer(p player.Player, sl slot) {
+	s.players = append(s.players, p)
+	s.lineup = append(s.lineup, sl)
+}
+
+// drawWorld draws the field, the power-ups, the players and the balls in field units
+// and scales them to fit the screen.
+func (s *baseState) drawWorld(screen *ebiten.Image) {
+	s.world.Clear()
+
 	// draw the field
-	drawField(screen)
+	drawField(s.world, s.arena, s.rules.Format)
+
+	// draw power-ups and shields of arcade matches
+	s.drawArcade(s.world)
+
+	// draw players and balls
+	for i, p := range s.players {
+		bounds := p.Bounds()
+		drawPlayer(s.interpolate(p.PreviousPosition(), p.Position()), bounds.Width, bounds.Height, s.paddleColor(i), s.world)
+	}
+
+	s.drawBalls(s.world)
+
+	// draw the particles and flashes of the hits and goals
+	s.game.effects.Draw(s.world)
+
+	op := s.worldOptions()
+	shake := s.game.effects.Offset()
+	op.GeoM.Translate(shake.X, shake.Y)
+
+	screen.DrawImage(s.world, op)
+}
+
+// paddleColor returns the color of the paddle of the player at the place i of the lineup,
+// the color of the player when the settings ask for it.
+func (s *baseState) paddleColor(i int) color.RGBA {
+	palette := theme.Active()
+	if !s.game.display.Settings().PlayerColors {
+		return palette.Text
+	}
+
+	return palette.Player(i)
+}
+
+// worldOptions returns the options to draw the world scaled to fit the screen and centered.
+// The world is in field units, so a field of any aspect ratio fits a screen of any other.
+func (s *baseState) worldOptions() *ebiten.DrawImageOptions {
+	width := s.game.screenWidth()
+	scale := min(width/s.arena.Width, display.Height/s.arena.Height)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate((width-s.arena.Width*scale)/2, (display.Height-s.arena.Height*scale)/2)
+
+	return op
+}
+
+// drawScores draws the score of each side.
+func (s *baseState) drawScores(screen *ebiten.Image) {
+	for _, sc := range s.scores {
+		sc.draw(screen)
+	}
+}
+
+// drawOverlay draws the elements on top of the world, including pause menu.
+func (s *baseState) drawOverlay(screen *ebiten.Image) {
+	// draw the remaining time of timed matches
+	s.tryDrawMatchClock(screen)
 
 	// draw metric if enabled
 	s.tryDrawMetric(screen)
 
+	// draw the pause button for the touch screen and the mouse
+	if button := s.pauseButton(); button != nil && !s.gamePaused {
+		button.Draw(screen)
+	}
+
 	// draw pause menu if open
 	if s.gamePaused && s.pauseMenu.isShown {
 		s.pauseMenu.draw(screen)
@@ -97,7 +247,8 @@ func (s *baseState) draw(screen *ebiten.Image) {
 }
 
 func (s *baseState) tryDrawMetric(screen *ebiten.Image) {
-	if !s.showMetric {
+	// there's no ball to measure between the serves of a drill
+	if !s.showMetric || len(s.balls) == 0 {
 		return
 	}
 
@@ -109,12 +260,151 @@ func (s *baseState) tryDrawMetric(screen *ebiten.Image) {
 
 	s.metric.Draw(
 		screen,
-		s.game.currentState.getBall().Bounces(),
-		s.game.currentState.getBall().Angle(),
+		s.balls[0].Bounces(),
+		s.balls[0].Angle(),
 		s.level,
 	)
 }
 
+// updateBalls moves every ball by dt seconds against the players' paddles and gives a point
+// to the side scoring each goal. Balls that scored are removed and, once the last ball scores,
+// a new ball is served and the players are reset.
+func (s *baseState) updateBalls(dt float64) {
+	if s.arcade != nil {
+		dt = s.arcade.update(dt, s.players)
+	}
+
+	obstacles := make([]ball.Obstacle, 0, len(s.players)+2)
+	for _, p := range s.players {
+		obstacles = append(obstacles, ball.NewPaddle(p.Bounds(), p.Side()))
+	}
+
+	if s.arcade != nil {
+		obstacles = append(obstacles, s.arcade.shields()...)
+	}
+
+	s.updateBallTrail(s.balls[0])
+
+	var (
+		conceded []geometry.Side
+		spawned  []ball.Ball
+		kept     = make([]ball.Ball, 0, len(s.balls))
+	)
+
+	for _, b := range s.balls {
+		b.Update(dt, obstacles...)
+
+		if s.arcade != nil {
+			spawned = append(spawned, s.arcade.collect(b)...)
+		}
+
+		if goal, side := b.CheckGoal(); goal {
+			conceded = append(conceded, side)
+			s.match.Goal(side, b.LastHit(), b.Bounds().Center())
+
+			continue
+		}
+
+		kept = append(kept, b)
+	}
+
+	kept = append(kept, spawned...)
+
+	for side, sc := range s.scores {
+		sc.value = int8(s.match.Score(side))
+	}
+
+	if len(kept) == 0 {
+		last := s.balls[len(s.balls)-1]
+		kept = append(kept, last.Reset(conceded[len(conceded)-1]))
+
+		for _, p := range s.players {
+			p.Reset()
+		}
+	}
+
+	s.balls = kept
+}
+
+// drawBalls draws all balls, the first one with its trail.
+func (s *baseState) drawBalls(screen *ebiten.Image) {
+	for i, b := range s.balls {
+		var trail []geometry.Vector
+		if i == 0 {
+			trail = s.ballTrail
+		}
+
+		drawBall(screen, s.interpolate(b.PreviousPosition(), b.Position()), b.Width(), trail)
+	}
+}
+
+// drawArcade draws the power-ups waiting to be collected and the active shields.
+func (s *baseState) drawArcade(screen *ebiten.Image) {
+	var (
+		items    []powerup.PowerUp
+		shielded func(geometry.Side) bool
+	)
+
+	switch {
+	case s.arcade != nil:
+		items, shielded = s.arcade.field.PowerUps(), s.arcade.field.Shielded
+	case s.networked:
+		items, shielded = s.remoteArcade.powerUps, s.remoteArcade.shielded
+	default:
+		return
+	}
+
+	drawPowerUps(screen, s.game.font, items)
+
+	for _, side := range s.rules.Format.Sides() {
+		if shielded(side) {
+			drawShield(screen, side, s.arena)
+		}
+	}
+}
+
+// tryDrawMatchClock draws the remaining time if the match is timed. Network matches are timed
+// by the server, so their clock is only drawn when the server sends one.
+func (s *baseState) tryDrawMatchClock(screen *ebiten.Image) {
+	remaining, suddenDeath := s.match.Remaining(), s.match.SuddenDeath()
+
+	switch {
+	case s.networked && s.remoteClock == nil:
+		return
+	case s.networked:
+		remaining = time.Duration(s.remoteClock.Remaining * float64(time.Second))
+		suddenDeath = s.remoteClock.SuddenDeath
+	case s.rules.TimeLimit <= 0:
+		return
+	}
+
+	if err := drawMatchClock(screen, s.game.font, remaining, suddenDeath); err != nil {
+		slog.Error("failed to draw match clock", slog.Any("error", err))
+	}
+}
+
+// checkMatchOver shows the winner screen once a side has won the match.
+// It returns true when the match is over.
+func (s *baseState) checkMatchOver() bool {
+	if !s.match.Over() {
+		return false
+	}
+
+	s.game.changeState(newWinnerState(s.game, s, s.match.Winner(), s.game.currentState))
+
+	return true
+}
+
+// interpolate returns the position to render between the previous and the current
+// simulation tick, so the movement looks smooth at any frame rate.
+func (s *baseState) interpolate(previous, current geometry.Vector) geometry.Vector {
+	if s.networked {
+		return current
+	}
+
+	return previous.Lerp(current, s.timestep.Alpha())
+}
+
 // updateBallTrail adds the current ball position to the trail slice
 // maintaining only the last ballTrailSize positions.
 func (s *baseState) updateBallTrail(ball ball.Ball) {
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
+	"github.com/gandarez/pong-multiplayer-go/internal/font"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const suddenDeathStr = "SUDDEN DEATH"
+
+// drawMatchClock draws the remaining time of a timed match at the bottom of the field.
+// When suddenDeath is true, it's drawn instead of the time.
+func drawMatchClock(screen *ebiten.Image, font *font.Font, remaining time.Duration, suddenDeath bool) error {
+	textFace, err := font.Face("ui", 16)
+	if err != nil {
+		return fmt.Errorf("failed to get text face to draw match clock: %w", err)
+	}
+
+	remaining = max(remaining, 0).Round(time.Second)
+
+	value := fmt.Sprintf("%02d:%02d", int(remaining.Minutes()), int(remaining.Seconds())%60)
+	clr := theme.Active().Text
+
+	if suddenDeath {
+		value = suddenDeathStr
+		clr = theme.Active().Highlight
+	}
+
+	width, _ := text.Measure(value, textFace, 1)
+
+	t := ui.Text{
+		Value:    value,
+		FontFace: textFace,
+		Position: geometry.Vector{
+			X: (float64(screen.Bounds().Dx()) - width) / 2,
+			Y: display.Height - fieldBorderWidth - 30,
+		},
+		Color: clr,
+	}
+	t.Draw(screen)
+
+	return nil
+}
@@ -1,22 +1,45 @@
 package game
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 
-	"github.com/gandarez/pong-multiplayer-go/internal/menu"
+	"github.com/gandarez/pong-multiplayer-go/internal/identity"
 	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
 	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
+	"github.com/gandarez/pong-multiplayer-go/pkg/rating"
 )
 
+// queueRefresh is how often the status of the player in the queue of the server is fetched.
+const queueRefresh = 2 * time.Second
+
 // ConnectingState represents the state when the game is connecting to the server.
 type ConnectingState struct {
 	game            *Game
 	networkReadyCh  chan network.ReadyMessage
 	connectionError error
+	// rejection tells why the server refused the player, e.g. a name reserved by another account.
+	rejection string
+	// rating is the skill rating of the player, fetched in the background, zero until then
+	// or when playing as a guest.
+	rating   rating.Rating
+	ratingMu sync.Mutex
+	// queue is the status of the player in the queue pairing players by rating, fetched in the
+	// background every queueRefresh, zero until then or when the server doesn't queue them.
+	queue   network.QueueStatus
+	queueMu sync.Mutex
+	// queueFetched is when the status in the queue was last fetched, zero until then.
+	queueFetched time.Time
 }
 
 // NewConnectingState creates a new ConnectingState.
@@ -28,9 +51,9 @@ func NewConnectingState(game *Game) *ConnectingState {
 }
 
 func (s *ConnectingState) update() error {
-	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+	if s.game.backPressed() {
 		s.game.networkClient.Close()
-		s.game.menu = menu.New(s.game.font, ScreenWidth, ScreenHeight)
+		s.game.resetMenu()
 		s.game.changeState(newMainMenuState(s.game))
 
 		return nil
@@ -46,11 +69,23 @@ func (s *ConnectingState) update() error {
 		return fmt.Errorf("failed to connect to server: %w", s.connectionError)
 	}
 
-	// wait until the network ready channel is initialized
-	for ready := range s.networkReadyCh {
+	s.fetchQueueStatus()
+
+	// check for the ready message without blocking, so the screen keeps showing the rating
+	// and the ratings of the opponents the queue of the server looks for while waiting
+	select {
+	case ready, ok := <-s.networkReadyCh:
+		if !ok {
+			return nil
+		}
+
 		if ready.Ready {
 			s.game.changeState(newMultiplayerState(s.game, ready))
+		} else if ready.Error != "" {
+			slog.Warn("server refused the player", slog.String("reason", ready.Error))
+			s.rejection = ready.Error
 		}
+	default:
 	}
 
 	return nil
@@ -58,8 +93,111 @@ func (s *ConnectingState) update() error {
 
 // draw draws the connecting state.
 func (s *ConnectingState) draw(screen *ebiten.Image) {
-	ui.DrawSplash(screen, s.game.font, ScreenWidth)
-	ui.DrawWaitingConnection(screen, s.game.font, ScreenWidth)
+	ui.DrawSplash(screen, s.game.font, s.game.screenWidth())
+	s.game.drawBackButton(screen)
+
+	face, err := s.game.font.Face("ui", 20)
+	if err != nil {
+		slog.Error("failed to create text face", slog.Any("error", err))
+		return
+	}
+
+	if s.rejection == "" {
+		ui.DrawWaitingConnection(screen, s.game.font, s.game.screenWidth())
+		s.drawRating(screen, face)
+		s.drawQueue(screen, face)
+
+		return
+	}
+
+	drawCenteredText(screen, s.rejection, face, 250, theme.Active().Highlight)
+	drawCenteredText(screen, "Change your name in the settings", face, 280, theme.Active().Text)
+}
+
+// drawRating draws the rating of the player once it's fetched.
+func (s *ConnectingState) drawRating(screen *ebiten.Image, face text.Face) {
+	s.ratingMu.Lock()
+	r := s.rating
+	s.ratingMu.Unlock()
+
+	if r.IsZero() {
+		return
+	}
+
+	drawCenteredText(screen, fmt.Sprintf("Your rating: %.f +/- %.f", r.Rating, 2*r.Deviation), face, 290, theme.Active().Text)
+}
+
+// drawQueue draws the ratings of the opponents the server looks for, a range widening the longer
+// the player waits, then the opponent found.
+func (s *ConnectingState) drawQueue(screen *ebiten.Image, face text.Face) {
+	s.queueMu.Lock()
+	status := s.queue
+	s.queueMu.Unlock()
+
+	var looking string
+
+	switch {
+	case status.Opponent != "":
+		looking = "Opponent found: " + status.Opponent
+	case !status.Queued:
+		return
+	case status.Window == 0:
+		looking = "Looking for any opponent"
+	default:
+		looking = fmt.Sprintf("Looking for opponents rated %.f to %.f", status.Rating-status.Window, status.Rating+status.Window)
+	}
+
+	drawCenteredText(screen, looking, face, 320, theme.Active().Text)
+}
+
+// fetchQueueStatus fetches the status of the player in the queue of the server in the background,
+// every queueRefresh while they wait.
+func (s *ConnectingState) fetchQueueStatus() {
+	if s.rejection != "" || time.Since(s.queueFetched) < queueRefresh {
+		return
+	}
+
+	s.queueFetched = time.Now()
+
+	name := s.game.menu.PlayerName()
+
+	go func() {
+		status, err := network.FetchQueueStatus(s.game.ctx, name)
+
+		switch {
+		case errors.Is(err, network.ErrNotQueued):
+			// the game server didn't queue the player yet, or pairs players without the queue
+		case err != nil:
+			slog.Error("failed to fetch queue status", slog.Any("error", err))
+			return
+		}
+
+		s.queueMu.Lock()
+		s.queue = status
+		s.queueMu.Unlock()
+	}()
+}
+
+// fetchRating fetches the rating of the player in the background, a new account having the
+// rating of a new player.
+func (s *ConnectingState) fetchRating(name string) {
+	go func() {
+		r := rating.Default()
+
+		profile, err := network.FetchProfile(s.game.ctx, name)
+
+		switch {
+		case err == nil:
+			r = profile.Rating
+		case !errors.Is(err, network.ErrNoAccount):
+			slog.Error("failed to fetch rating", slog.Any("error", err))
+			return
+		}
+
+		s.ratingMu.Lock()
+		s.rating = r
+		s.ratingMu.Unlock()
+	}()
 }
 
 // connectToServer connects to the game server.
@@ -70,17 +208,29 @@ func (s *ConnectingState) connectToServer() {
 		return
 	}
 
+	name := s.game.menu.PlayerName()
+	id := s.identity(name)
+	handicap := s.game.menu.Handicap(0)
+
 	if err := s.game.networkClient.SendPlayerInfo(network.GameInfo{
-		PlayerName:   s.game.menu.PlayerName(),
-		Level:        int(s.game.menu.Level()),
-		ScreenWidth:  ScreenWidth,
-		ScreenHeight: ScreenHeight,
-		MaxScore:     maxScore,
+		PlayerName:  name,
+		Level:       int(s.game.menu.Level()),
+		FieldWidth:  arena.DefaultWidth,
+		FieldHeight: arena.DefaultHeight,
+		MaxScore:    s.game.menu.Rules().ScoreLimit,
+		Rules:       s.game.menu.Rules(),
+		Identity:    id,
+		Handicap:    handicap,
 	}); err != nil {
 		s.connectionError = fmt.Errorf("failed to send player info: %w", err)
 		return
 	}
 
+	// guests aren't rated
+	if id != nil {
+		s.fetchRating(name)
+	}
+
 	go func() {
 		if err := s.game.networkClient.ReceiveReadyMessage(s.networkReadyCh); err != nil {
 			slog.Error("error receiving ready message", slog.Any("error", err))
@@ -89,6 +239,29 @@ func (s *ConnectingState) connectToServer() {
 	}()
 }
 
+// identity returns the identity proving the player owns their name, nil to join as a guest
+// when the online account is turned off, or the identity of this device can't be loaded
+// or signed for a challenge of the server.
+func (s *ConnectingState) identity(name string) *account.Identity {
+	if !s.game.settings.OnlineAccount {
+		return nil
+	}
+
+	key, err := identity.Load()
+	if err != nil {
+		slog.Error("failed to load identity, joining as a guest", slog.Any("error", err))
+		return nil
+	}
+
+	nonce, err := network.FetchChallenge(s.game.ctx)
+	if err != nil {
+		slog.Error("failed to fetch challenge, joining as a guest", slog.Any("error", err))
+		return nil
+	}
+
+	return key.Identity(name, network.BaseURL, nonce)
+}
+
 func (*ConnectingState) getBall() ball.Ball {
 	panic("not implemented")
 }
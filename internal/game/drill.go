@@ -0,0 +1,39 @@
+package game
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	"github.com/gandarez/pong-multiplayer-go/assets"
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/pkg/drill"
+)
+
+// loadDrills returns the built-in training drills followed by the drills found in the user
+// config directory. Drills that fail to load are skipped.
+func loadDrills(assets *assets.Assets) []drill.Drill {
+	var drills []drill.Drill
+
+	for _, data := range assets.Drills() {
+		d, err := drill.Parse(data)
+		if err != nil {
+			slog.Error("failed to parse built-in drill", slog.Any("error", err))
+			continue
+		}
+
+		drills = append(drills, d)
+	}
+
+	dir, err := storage.Dir()
+	if err != nil {
+		slog.Warn("failed to find user drills directory", slog.Any("error", err))
+		return drills
+	}
+
+	custom, err := drill.LoadDir(filepath.Join(dir, "drills"))
+	if err != nil {
+		slog.Error("failed to load user drills", slog.Any("error", err))
+	}
+
+	return append(drills, custom...)
+}
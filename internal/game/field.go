@@ -2,28 +2,76 @@ package game
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 
-	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
-// drawField draws the game field, common to all game modes.
-func drawField(screen *ebiten.Image) {
-	// draw field limits (top and bottom borders)
-	for x := 0.; x <= ScreenWidth; x++ {
-		for y := 0.; y <= fieldBorderWidth; y++ {
-			screen.Set(int(x), int(y), ui.DefaultColor)
-			screen.Set(int(x), int(y+ScreenHeight-fieldBorderWidth), ui.DefaultColor)
+const (
+	dashSize    = 7
+	dashSpacing = 17
+)
+
+// drawField draws the arena field, common to all game modes.
+// Four-way fields have goals on every side, so only their corners are closed.
+func drawField(screen *ebiten.Image, field arena.Arena, format rules.Format) {
+	if format == rules.FourWay {
+		for _, corner := range field.Corners() {
+			drawRect(screen, corner)
 		}
+	} else {
+		// draw field limits (top and bottom borders)
+		drawRect(screen, geometry.Rect{Width: field.Width, Height: field.BorderWidth})
+		drawRect(screen, geometry.Rect{Y: field.Height - field.BorderWidth, Width: field.Width, Height: field.BorderWidth})
+
+		// draw delimiter line (dashed)
+		drawDelimiter(screen, field)
 	}
 
-	// draw delimiter line (dashed)
-	for squareCount, y := 0, 15.; squareCount < 30; squareCount++ {
-		for w := 0.; w < 7.; w++ {
-			for h := 0.; h < 7.; h++ {
-				screen.Set(int(ScreenWidth/2-5+w), int(h+y), ui.DefaultColor)
-			}
+	// draw the sides outside the goal openings
+	if top, bottom := field.Goal(); field.GoalSize != 0 {
+		for _, x := range []float64{0, field.Width - field.BorderWidth} {
+			drawRect(screen, geometry.Rect{X: x, Width: field.BorderWidth, Height: top})
+			drawRect(screen, geometry.Rect{X: x, Y: bottom, Width: field.BorderWidth, Height: field.Height - bottom})
 		}
+	}
+
+	// draw walls filled and bumpers outlined
+	for _, wall := range field.Walls {
+		drawRect(screen, wall)
+	}
 
-		y += 17.
+	for _, bumper := range field.Bumpers {
+		vector.StrokeRect(
+			screen,
+			float32(bumper.X), float32(bumper.Y),
+			float32(bumper.Width), float32(bumper.Height),
+			2, theme.Active().Highlight, false,
+		)
 	}
 }
+
+// drawDelimiter draws the dashed line splitting the field in halves.
+func drawDelimiter(screen *ebiten.Image, field arena.Arena) {
+	for y := field.BorderWidth + 5; y < field.Height-field.BorderWidth; y += dashSpacing {
+		drawRect(screen, geometry.Rect{
+			X:      field.Width/2 - 5,
+			Y:      y,
+			Width:  dashSize,
+			Height: min(dashSize, field.Height-field.BorderWidth-y),
+		})
+	}
+}
+
+// drawRect draws a filled rectangle with the default color.
+func drawRect(screen *ebiten.Image, r geometry.Rect) {
+	vector.DrawFilledRect(
+		screen,
+		float32(r.X), float32(r.Y),
+		float32(r.Width), float32(r.Height),
+		theme.Active().Text, false,
+	)
+}
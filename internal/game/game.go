@@ -3,24 +3,35 @@ package game
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/gandarez/pong-multiplayer-go/assets"
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
 	"github.com/gandarez/pong-multiplayer-go/internal/font"
+	"github.com/gandarez/pong-multiplayer-go/internal/fx"
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
+	"github.com/gandarez/pong-multiplayer-go/internal/league"
 	"github.com/gandarez/pong-multiplayer-go/internal/menu"
 	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/profile"
+	"github.com/gandarez/pong-multiplayer-go/internal/settings"
+	"github.com/gandarez/pong-multiplayer-go/internal/summary"
+	"github.com/gandarez/pong-multiplayer-go/internal/tournament"
+	"github.com/gandarez/pong-multiplayer-go/internal/training"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/timestep"
 )
 
 const (
-	// maxScore is the maximum score to win the game.
-	maxScore = 10
-	// ScreenWidth is the width of the screen.
-	ScreenWidth = 640
-	// ScreenHeight is the height of the screen.
-	ScreenHeight = 480
 	// fieldBorderWidth is the width of the field border.
 	fieldBorderWidth = 10
+	// windowSaveDelay is how long the window size must stay the same before it's saved.
+	windowSaveDelay = time.Second
 )
 
 // Game represents the main game object.
@@ -33,31 +44,139 @@ type Game struct {
 	currentState state
 
 	// shared resources
-	assets        *assets.Assets
+	display *display.Screen
+	assets  *assets.Assets
+	audio   *audio.Player
+	effects *fx.Effects
+	// clock measures the real time elapsed between updates.
+	clock    timestep.Clock
+	controls *input.Controls
+	settings *settings.Settings
+	profiles *profile.Store
+	// tracker adds the matches played on this device to the profile of the player.
+	tracker *profile.Tracker
+	// recorder sums up the match being played for the winner screen.
+	recorder *summary.Recorder
+	// tournamentTracker records the results of the tournament matches.
+	tournamentTracker *tournament.Tracker
+	// leagueTracker records the scores of the league fixtures.
+	leagueTracker *league.Tracker
 	networkClient *network.Client
+	// events receives what happens in matches, such as hits and goals.
+	events *event.Bus
+	// resizedAt is when the window was last resized, zero once its size is saved.
+	resizedAt time.Time
 }
 
 // New creates a new game instance.
 func New(ctx context.Context, cancel context.CancelFunc, assets *assets.Assets) (*Game, error) {
 	font := font.New(assets)
-	gameMenu := menu.New(font, ScreenWidth, ScreenHeight)
+	userSettings, err := settings.Load()
+	if err != nil {
+		slog.Error("failed to load settings, using the default ones", slog.Any("error", err))
+	}
+
+	if w := userSettings.Window; w.Width > 0 && w.Height > 0 {
+		ebiten.SetWindowSize(w.Width, w.Height)
+	}
+
+	screen := display.New(userSettings.Display)
+
+	controls := input.NewControls(input.NewGamepads())
+	controls.SetBindings(userSettings.Bindings())
+	controls.Pointer().SetScreen(screen.ToScreen)
+
+	player, err := audio.New(assets, userSettings.Audio)
+	if err != nil {
+		slog.Error("failed to initialize audio, playing without sound", slog.Any("error", err))
+	}
+
+	effects := fx.New(userSettings.Effects)
+
+	profiles, err := profile.Load()
+	if err != nil {
+		slog.Error("failed to load profiles, starting without stats", slog.Any("error", err))
+	}
+
+	tracker := profile.NewTracker(profiles)
+	recorder := summary.NewRecorder()
+
+	running, err := tournament.Load()
+	if err != nil {
+		slog.Error("failed to load tournament", slog.Any("error", err))
+	}
+
+	tournamentTracker := tournament.NewTracker()
+
+	played, err := league.Load()
+	if err != nil {
+		slog.Error("failed to load league", slog.Any("error", err))
+	}
+
+	leagueTracker := league.NewTracker()
+
+	history, err := training.Load()
+	if err != nil {
+		slog.Error("failed to load training history", slog.Any("error", err))
+	}
+
+	events := event.NewBus()
+	player.Subscribe(events)
+	effects.Subscribe(events)
+	tracker.Subscribe(events)
+	recorder.Subscribe(events)
+	tournamentTracker.Subscribe(events)
+	leagueTracker.Subscribe(events)
+
+	gameMenu := menu.New(font, player, controls, userSettings, screen)
+	gameMenu.SetArenas(loadArenas(assets))
+	gameMenu.SetProfiles(profiles)
+	gameMenu.SetTournament(running)
+	gameMenu.SetLeague(played)
+	gameMenu.SetTraining(history)
+	gameMenu.SetDrills(loadDrills(assets))
 
 	game := &Game{
-		cancel: cancel,
-		ctx:    ctx,
-		font:   font,
-		menu:   gameMenu,
-		assets: assets,
+		cancel:            cancel,
+		ctx:               ctx,
+		font:              font,
+		menu:              gameMenu,
+		display:           screen,
+		assets:            assets,
+		audio:             player,
+		effects:           effects,
+		controls:          controls,
+		settings:          userSettings,
+		profiles:          profiles,
+		tracker:           tracker,
+		recorder:          recorder,
+		events:            events,
+		tournamentTracker: tournamentTracker,
+		leagueTracker:     leagueTracker,
 	}
 
 	// set the initial state to MainMenuState
-	game.currentState = newMainMenuState(game)
+	game.changeState(newMainMenuState(game))
 
 	return game, nil
 }
 
 // Update delegates the update logic to the current game state.
 func (g *Game) Update() error {
+	// detect gamepads being connected or disconnected
+	g.controls.Update()
+
+	// remember the window size once the user is done resizing it
+	g.trackWindowSize()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyF11) {
+		g.toggleFullscreen()
+	}
+
+	// fade the effects out in real time, even while the game is slowed down; ebiten.TPS
+	// can't be used as the ticks follow the frame rate
+	g.effects.Update(g.clock.Delta(time.Now()))
+
 	if err := g.currentState.update(); err != nil {
 		return fmt.Errorf("failed to update game state: %w", err)
 	}
@@ -65,19 +184,91 @@ func (g *Game) Update() error {
 	return nil
 }
 
-// Draw delegates the drawing logic to the current game state.
-func (g *Game) Draw(screen *ebiten.Image) {
-	g.currentState.draw(screen)
+// Draw delegates the drawing logic to the current game state, which draws on the screen
+// then scaled to the window.
+func (g *Game) Draw(window *ebiten.Image) {
+	g.currentState.draw(g.display.Canvas())
+	g.display.Present(window)
+}
+
+// Layout returns the size of the window in device pixels, the screen being scaled to it when drawn.
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return g.display.Layout(outsideWidth, outsideHeight)
+}
+
+// screenWidth returns the width of the screen, which follows its aspect ratio. Its height
+// is always display.Height.
+func (g *Game) screenWidth() float64 {
+	return g.display.Width()
 }
 
-// Layout returns the game's logical screen dimensions.
-func (*Game) Layout(_, _ int) (int, int) {
-	return ScreenWidth, ScreenHeight
+// toggleFullscreen enters or leaves fullscreen and saves the choice.
+func (g *Game) toggleFullscreen() {
+	g.settings.Display.Fullscreen = !ebiten.IsFullscreen()
+	g.display.Apply(g.settings.Display)
+	g.saveSettings()
+}
+
+// resetMenu recreates the menu from its main state, keeping the match settings.
+func (g *Game) resetMenu() {
+	previous := g.menu
+
+	g.menu = menu.New(g.font, g.audio, g.controls, g.settings, g.display)
+	g.menu.SetRules(previous.Rules())
+	g.menu.SetArenas(previous.Arenas())
+	g.menu.SetArena(previous.Arena())
+	g.menu.SetProfiles(g.profiles)
+	g.menu.SetTournament(previous.Tournament())
+	g.menu.SetLeague(previous.League())
+	g.menu.SetTraining(previous.Training())
+	g.menu.SetDrills(previous.Drills())
+}
+
+// saveSettings saves the settings of the user, logging any failure.
+func (g *Game) saveSettings() {
+	if err := g.settings.Save(); err != nil {
+		slog.Error("failed to save settings", slog.Any("error", err))
+	}
+}
+
+// trackWindowSize saves the size of the window when it hasn't changed for windowSaveDelay.
+func (g *Game) trackWindowSize() {
+	width, height := ebiten.WindowSize()
+	if width == 0 || height == 0 {
+		// not running in a window, e.g. in the browser
+		return
+	}
+
+	if size := (settings.Window{Width: width, Height: height}); size != g.settings.Window {
+		g.settings.Window = size
+		g.resizedAt = time.Now()
+
+		return
+	}
+
+	if !g.resizedAt.IsZero() && time.Since(g.resizedAt) > windowSaveDelay {
+		g.resizedAt = time.Time{}
+		g.saveSettings()
+	}
 }
 
 // changeState allows switching between different game states.
 func (g *Game) changeState(state state) {
 	g.currentState = state
+	g.audio.PlayMusic(music(state))
+}
+
+// music returns the track played in the state: the match music while playing or watching
+// a match, none on the winner screen and the menu music otherwise.
+func music(state state) audio.Track {
+	switch state.(type) {
+	case *onePlayerState, *twoPlayersState, *multiplayerState, *spectatorState:
+		return audio.MatchMusic
+	case *winnerState:
+		return audio.NoMusic
+	default:
+		return audio.MenuMusic
+	}
 }
 
 // exit gracefully exits the game.
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// localInput returns the input of the local player with the given index from its bindings
+// and its gamepad, when several players share this device. The up and down actions move the
+// paddle left and right when defending the top or bottom side.
+func (g *Game) localInput(index int, side geometry.Side) player.Input {
+	backward := g.controls.Pressed(input.PlayerUp(index))
+	forward := g.controls.Pressed(input.PlayerDown(index))
+	axis := g.gamepadAxis(index, side)
+
+	if side.Horizontal() {
+		return player.Input{Left: backward, Right: forward, Axis: axis}
+	}
+
+	return player.Input{Up: backward, Down: forward, Axis: axis}
+}
+
+// soloInput returns the input of the only player on this device from its bindings and gamepad.
+func (g *Game) soloInput(side geometry.Side) player.Input {
+	return player.Input{
+		Up:    g.controls.Pressed(input.MoveUp),
+		Down:  g.controls.Pressed(input.MoveDown),
+		Left:  g.controls.Pressed(input.MoveLeft),
+		Right: g.controls.Pressed(input.MoveRight),
+		Axis:  g.singleGamepadAxis(side),
+	}
+}
+
+// gamepadAxis returns the analog control of the gamepad assigned to the local player
+// with the given index, or 0 if the player has no gamepad.
+func (g *Game) gamepadAxis(player int, side geometry.Side) float64 {
+	id, ok := g.controls.Gamepads().Assigned(player)
+	if !ok {
+		return 0
+	}
+
+	return input.Axis(id, side.Horizontal())
+}
+
+// singleGamepadAxis returns the analog control of the gamepad used when a single player
+// is playing on this device, or 0 if no gamepad is connected.
+func (g *Game) singleGamepadAxis(side geometry.Side) float64 {
+	id, ok := g.controls.Gamepads().First()
+	if !ok {
+		return 0
+	}
+
+	return input.Axis(id, side.Horizontal())
+}
+
+// soloGamepads returns the gamepad of the only player on this device when it defends side.
+func (s *baseState) soloGamepads(side geometry.Side) []ebiten.GamepadID {
+	if len(s.lineup) == 0 || s.lineup[0].side != side {
+		return nil
+	}
+
+	if id, ok := s.game.controls.Gamepads().First(); ok {
+		return []ebiten.GamepadID{id}
+	}
+
+	return nil
+}
+
+// localGamepads returns the gamepads assigned to the local players defending side.
+func (s *baseState) localGamepads(side geometry.Side) []ebiten.GamepadID {
+	var ids []ebiten.GamepadID
+
+	for i, sl := range s.lineup {
+		if sl.side != side {
+			continue
+		}
+
+		if id, ok := s.game.controls.Gamepads().Assigned(i); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// slot is the place of a player on the field: the side it defends and, in doubles
+// matches, the half of the goal it covers.
+type slot struct {
+	side geometry.Side
+	lane int
+}
+
+// lineup returns the slots of the players of a match in the given format.
+// The first slot is always the left one, followed by the right one, so the first two
+// local players keep their controls in every format.
+func lineup(format rules.Format) []slot {
+	var slots []slot
+
+	for lane := range format.Lanes() {
+		for _, side := range format.Sides() {
+			slots = append(slots, slot{side: side, lane: lane})
+		}
+	}
+
+	return slots
+}
+
+// playerArea returns the part of the field where the paddle of the player in sl moves.
+func playerArea(field arena.Arena, format rules.Format, sl slot) geometry.Rect {
+	if format == rules.FourWay {
+		if sl.side.Horizontal() {
+			return geometry.Rect{X: arena.CornerSize, Width: field.Width - 2*arena.CornerSize, Height: field.Height}
+		}
+
+		return geometry.Rect{Y: arena.CornerSize, Width: field.Width, Height: field.Height - 2*arena.CornerSize}
+	}
+
+	area := geometry.Rect{Y: field.BorderWidth, Width: field.Width, Height: field.Height - 2*field.BorderWidth}
+
+	lanes := float64(format.Lanes())
+	area.Height /= lanes
+	area.Y += float64(sl.lane) * area.Height
+
+	return area
+}
+
+// alongAxis returns the coordinate of v along the axis the paddles of side move on.
+func alongAxis(side geometry.Side, v geometry.Vector) float64 {
+	if side.Horizontal() {
+		return v.X
+	}
+
+	return v.Y
+}
+
+// areaLimits returns where the area starts and ends along the axis the paddles of side move on.
+func areaLimits(side geometry.Side, area geometry.Rect) (float64, float64) {
+	if side.Horizontal() {
+		return area.X, area.MaxX()
+	}
+
+	return area.Y, area.MaxY()
+}
+
+// teamName returns the names of the players defending side, joined by an ampersand.
+func teamName(players []player.Player, side geometry.Side) string {
+	var names []string
+
+	for _, p := range players {
+		if p.Side() == side {
+			names = append(names, p.Name())
+		}
+	}
+
+	return strings.Join(names, " & ")
+}
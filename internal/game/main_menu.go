@@ -36,6 +36,8 @@ func (s *mainMenuState) update() error {
 			s.game.changeState(NewConnectingState(s.game))
 		case menu.Spectator:
 			s.game.changeState(newSpectatorState(s.game))
+		case menu.Training:
+			s.game.changeState(newTrainingState(s.game))
 		}
 	}
 
@@ -44,7 +46,7 @@ func (s *mainMenuState) update() error {
 
 // draw draws the main menu.
 func (s *mainMenuState) draw(screen *ebiten.Image) {
-	ui.DrawSplash(screen, s.game.font, ScreenWidth)
+	ui.DrawSplash(screen, s.game.font, s.game.screenWidth())
 	s.game.menu.Draw(screen)
 }
 
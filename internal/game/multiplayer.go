@@ -3,42 +3,64 @@ package game
 import (
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 
-	"github.com/gandarez/pong-multiplayer-go/internal/font"
 	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/profile"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
 // multiplayerState represents the multiplayer game state.
 type multiplayerState struct {
-	ball                           ball.Ball
-	player1                        player.Player
-	player2                        player.Player
-	score1                         *score
-	score2                         *score
-	networkGameCh                  chan network.GameState
-	p1NamePosition, p2NamePosition geometry.Vector
+	// side and lane are the slot of the current player.
+	side          geometry.Side
+	lane          int
+	networkGameCh chan network.GameState
 	*baseState
 }
 
 // newMultiplayerState creates a new multiplayerState.
 func newMultiplayerState(game *Game, ready network.ReadyMessage) *multiplayerState {
-	base := newBasePlayingState(game, game.menu.Level())
+	base := newBasePlayingState(game, game.menu.Level(), arena.Classic())
+	base.balls = []ball.Ball{ball.NewNetwork()}
+	base.networked = true
+
+	// initialize players with names from the ready message
+	states := ready.Players
+	if len(states) == 0 {
+		states = []network.PlayerState{
+			{Name: ready.Name, Side: ready.Side, Lane: ready.Lane, Handicap: ready.Handicap},
+			{Name: ready.OpponentName, Side: ready.OpponentSide, Handicap: ready.OpponentHandicap},
+		}
+	}
+
+	base.setFormat(formatOf(states))
+
+	for _, ps := range states {
+		sl := slot{side: ps.Side, lane: ps.Lane}
+		area := playerArea(base.arena, base.rules.Format, sl)
+		base.addPlayer(player.NewNetwork(ps.Name, ps.Side, area, ps.Handicap.Paddle()), sl)
+	}
 
-	// initialize players with names from gameState
-	player1 := player.NewNetwork(ready.Name, ready.Side, ScreenWidth, ScreenHeight)
-	player2 := player.NewNetwork(ready.OpponentName, ready.OpponentSide, ScreenWidth, ScreenHeight)
-	ball := ball.NewNetwork()
-	score1 := newScore1(base.game.font) // left
-	score2 := newScore2(base.game.font) // right
+	// calculate player name positions
+	base.updateNamePositions()
 
-	// calculate player name position
-	p1NamePosition, p2NamePosition := calculatePlayerNamePosition(*game.font, player1.Name(), player2.Name(), player1.Side())
+	// the clock of the match runs on the server, points are timed on this device
+	start := time.Now()
+	clock := func() time.Duration { return time.Since(start) }
+
+	game.tracker.Start(profile.Online, base.level, clock, profile.Player{Name: game.menu.PlayerName(), Side: ready.Side})
+	game.recorder.Start(clock)
+	game.audio.SetLocalSides(ready.Side)
 
 	networkGameCh := make(chan network.GameState)
 
@@ -49,15 +71,10 @@ func newMultiplayerState(game *Game, ready network.ReadyMessage) *multiplayerSta
 	}()
 
 	return &multiplayerState{
-		baseState:      base,
-		ball:           ball,
-		player1:        player1,
-		player2:        player2,
-		score1:         score1,
-		score2:         score2,
-		networkGameCh:  networkGameCh,
-		p1NamePosition: p1NamePosition,
-		p2NamePosition: p2NamePosition,
+		baseState:     base,
+		side:          ready.Side,
+		lane:          ready.Lane,
+		networkGameCh: networkGameCh,
 	}
 }
 
@@ -69,15 +86,11 @@ func (s *multiplayerState) update() error {
 		return nil
 	}
 
-	up := ebiten.IsKeyPressed(ebiten.KeyUp)
-	down := ebiten.IsKeyPressed(ebiten.KeyDown)
+	input := s.input()
 
-	if up || down {
+	if input != (network.PlayerInput{}) {
 		// send input to server
-		if err := s.game.networkClient.SendPlayerInput(network.PlayerInput{
-			Up:   up,
-			Down: down,
-		}); err != nil {
+		if err := s.game.networkClient.SendPlayerInput(input); err != nil {
 			slog.Error("failed to send player input", slog.Any("error", err))
 		}
 	}
@@ -85,108 +98,279 @@ func (s *multiplayerState) update() error {
 	// receive game state from server and update local game state
 	gameState := <-s.networkGameCh
 
-	// update ball and players positions
-	s.updateBallTrail(s.ball)
-	s.ball.SetPosition(gameState.Ball.Position)
-	s.ball.SetAngle(gameState.Ball.Angle)
-	s.ball.SetBounces(gameState.Ball.Bounces)
+	// update balls, power-ups, clock and players positions
+	s.syncBalls(gameState)
+	s.syncArcade(gameState)
+	s.syncClock(gameState)
 
 	// update player positions and scores
-	s.updatePlayerPositions(gameState)
-	s.updateScores(gameState)
+	s.syncPlayers(playerStates(gameState))
 
 	// update ping
 	s.pingCurrentPlayer = gameState.CurrentPlayer.Ping
 	s.pingOpponent = gameState.OpponentPlayer.Ping
 
 	// check for winner
-	if gameState.CurrentPlayer.Winner || gameState.OpponentPlayer.Winner {
-		winner := s.player1
-		if gameState.OpponentPlayer.Winner {
-			winner = s.player2
-		}
-
+	if side, ok := winnerSide(gameState); ok {
 		s.game.networkClient.Close()
+		s.game.events.Publish(event.MatchOver{Winner: side})
 
-		s.game.changeState(newWinnerState(s.game, winner.Name(), s))
+		s.game.changeState(newWinnerState(s.game, s.baseState, side, s))
 	}
 
 	return nil
 }
 
-func (s *multiplayerState) updatePlayerPositions(gameState network.GameState) {
-	if s.player1.Side() == gameState.CurrentPlayer.Side {
-		s.player1.SetPosition(gameState.CurrentPlayer.PositionY)
-		s.player2.SetPosition(gameState.OpponentPlayer.PositionY)
-	} else {
-		s.player1.SetPosition(gameState.OpponentPlayer.PositionY)
-		s.player2.SetPosition(gameState.CurrentPlayer.PositionY)
+// input returns the input of the current player from the keyboard, a gamepad, the touch
+// screen or the mouse. Touch and mouse controls are sent as an analog control.
+func (s *multiplayerState) input() network.PlayerInput {
+	in := s.game.soloInput(s.side)
+
+	if i := slices.Index(s.lineup, slot{side: s.side, lane: s.lane}); i >= 0 {
+		s.followPointer(&in, s.players[i])
+
+		if in.Follow {
+			in.Axis = followAxis(s.players[i], in.Target)
+		}
+	}
+
+	return network.PlayerInput{
+		Up:    in.Up,
+		Down:  in.Down,
+		Left:  in.Left,
+		Right: in.Right,
+		Axis:  in.Axis,
 	}
 }
 
-func (s *multiplayerState) updateScores(gameState network.GameState) {
-	if s.player1.Side() == geometry.Left {
-		s.score1.value = gameState.CurrentPlayer.Score
-		s.score2.value = gameState.OpponentPlayer.Score
-	} else {
-		s.score1.value = gameState.OpponentPlayer.Score
-		s.score2.value = gameState.CurrentPlayer.Score
+// playerStates returns the states of all the players of a match.
+func playerStates(gameState network.GameState) []network.PlayerState {
+	if len(gameState.Players) > 0 {
+		return gameState.Players
 	}
+
+	return []network.PlayerState{gameState.CurrentPlayer, gameState.OpponentPlayer}
 }
 
-func calculatePlayerNamePosition(font font.Font, p1name, p2name string, p1Side geometry.Side) (geometry.Vector, geometry.Vector) {
-	playerNameTextFace, _ := font.Face("ui", 20)
-	scoreTextFace, _ := font.Face("score", 44)
+// winnerSide returns the side that won the match, if any.
+func winnerSide(gameState network.GameState) (geometry.Side, bool) {
+	for _, ps := range playerStates(gameState) {
+		if ps.Winner {
+			return ps.Side, true
+		}
+	}
+
+	return geometry.Undefined, false
+}
 
-	p1NameWidth, _ := text.Measure(fmt.Sprintf("%10s", p1name), playerNameTextFace, 1)
-	p2NameWidth, _ := text.Measure(fmt.Sprintf("%10s", p2name), playerNameTextFace, 1)
+// formatOf returns the format of a match given the states of its players.
+func formatOf(states []network.PlayerState) rules.Format {
+	format := rules.Singles
 
-	_, scoreHeight := text.Measure("0", scoreTextFace, 1)
+	for _, ps := range states {
+		if ps.Side.Horizontal() {
+			return rules.FourWay
+		}
+
+		if ps.Lane > 0 {
+			format = rules.Doubles
+		}
+	}
+
+	return format
+}
+
+// setFormat changes the format of the match, removing the players and resetting the scores.
+func (s *baseState) setFormat(format rules.Format) {
+	s.rules.Format = format
+	s.scores = newScores(s.game.font, format, s.game.screenWidth())
+	s.players, s.lineup = nil, nil
+}
+
+// syncPlayers updates the players and scores with the states received from the server,
+// adding the players that aren't on the field yet.
+func (s *baseState) syncPlayers(states []network.PlayerState) {
+	changed := false
+
+	for _, ps := range states {
+		if ps.Rating > 0 {
+			if s.ratings == nil {
+				s.ratings = make(map[string]playerRating)
+			}
+
+			s.ratings[ps.Name] = playerRating{value: ps.Rating, change: ps.RatingChange}
+		}
+
+		sl := slot{side: ps.Side, lane: ps.Lane}
+
+		i := slices.Index(s.lineup, sl)
+		if i < 0 {
+			area := playerArea(s.arena, s.rules.Format, sl)
+			s.addPlayer(player.NewNetwork(ps.Name, ps.Side, area, ps.Handicap.Paddle()), sl)
+			i, changed = len(s.players)-1, true
+		}
+
+		if p := s.players[i]; p.Name() != ps.Name {
+			p.SetName(ps.Name)
+			changed = true
+		}
+
+		syncPlayer(s.players[i], ps)
+
+		if sc, ok := s.scores[ps.Side]; ok {
+			// the server doesn't say which side conceded
+			if ps.Score > sc.value {
+				s.game.events.Publish(event.Goal{Side: geometry.Undefined, Scorer: ps.Side})
+			}
+
+			sc.value = ps.Score
+		}
+	}
 
-	p1NamePosition := geometry.Vector{
-		X: ScreenWidth/2 - 10 - p1NameWidth,
-		Y: scoreHeight + 50,
+	// update player name positions if they have changed
+	if changed {
+		s.updateNamePositions()
 	}
+}
+
+// syncPlayer updates the player with the state received from the server.
+func syncPlayer(p player.Player, playerState network.PlayerState) {
+	p.SetPosition(playerState.PositionY)
 
-	p2NamePosition := geometry.Vector{
-		X: ScreenWidth/2 + 10 + (p2NameWidth / 2),
-		Y: scoreHeight + 50,
+	if playerState.BouncerHeight > 0 {
+		p.SetBouncerHeight(playerState.BouncerHeight)
 	}
+}
+
+// syncBalls updates the balls with the ones received from the server.
+func (s *baseState) syncBalls(gameState network.GameState) {
+	ballStates := append([]network.BallState{gameState.Ball}, gameState.Balls...)
 
-	if p1Side == geometry.Right {
-		return p2NamePosition, p1NamePosition
+	for len(s.balls) < len(ballStates) {
+		s.balls = append(s.balls, ball.NewNetwork())
 	}
 
-	return p1NamePosition, p2NamePosition
+	s.balls = s.balls[:len(ballStates)]
+
+	s.updateBallTrail(s.balls[0])
+
+	now := time.Now()
+	elapsed := now.Sub(s.syncedAt).Seconds()
+	s.syncedAt = now
+
+	for i, ballState := range ballStates {
+		// the server only sends the paddle hits as a number of bounces, the side and speed
+		// of the hit are guessed from where the ball is and how far it moved since the last state
+		if ballState.Bounces > s.balls[i].Bounces() {
+			var speed float64
+			if elapsed > 0 && elapsed < 1 {
+				speed = ballState.Position.Distance(s.balls[i].Position()) / elapsed
+			}
+
+			s.game.events.Publish(event.PaddleHit{
+				Side:     s.nearestSide(ballState.Position),
+				Speed:    speed,
+				Position: ballState.Position,
+				Angle:    ballState.Angle,
+			})
+		}
+
+		s.balls[i].SetPosition(ballState.Position)
+		s.balls[i].SetAngle(ballState.Angle)
+		s.balls[i].SetBounces(ballState.Bounces)
+	}
 }
 
-func (s *multiplayerState) draw(screen *ebiten.Image) {
-	// draw common elements
-	s.baseState.draw(screen)
+// nearestSide returns the side of the match whose goal is the nearest to pos.
+func (s *baseState) nearestSide(pos geometry.Vector) geometry.Side {
+	distances := map[geometry.Side]float64{
+		geometry.Left:   pos.X,
+		geometry.Right:  s.arena.Width - pos.X,
+		geometry.Top:    pos.Y,
+		geometry.Bottom: s.arena.Height - pos.Y,
+	}
+
+	nearest := geometry.Undefined
+
+	for _, side := range s.rules.Format.Sides() {
+		if nearest == geometry.Undefined || distances[side] < distances[nearest] {
+			nearest = side
+		}
+	}
+
+	return nearest
+}
+
+// syncArcade updates the power-ups and shields with the ones received from the server.
+func (s *baseState) syncArcade(gameState network.GameState) {
+	s.remoteArcade = remoteArcade{
+		powerUps: gameState.PowerUps,
+		shields:  make(map[geometry.Side]bool),
+	}
+
+	for _, ps := range playerStates(gameState) {
+		s.remoteArcade.shields[ps.Side] = s.remoteArcade.shields[ps.Side] || ps.Shield
+	}
+}
+
+// syncClock updates the clock of timed matches with the one received from the server.
+func (s *baseState) syncClock(gameState network.GameState) {
+	s.remoteClock = gameState.Clock
+}
+
+// updateNamePositions calculates where the names of the players of each side are drawn.
+// In four-way matches they're drawn below the score of each side.
+func (s *baseState) updateNamePositions() {
+	playerNameTextFace, _ := s.game.font.Face("ui", 20)
+	scoreTextFace, _ := s.game.font.Face("score", 44)
+
+	_, scoreHeight := text.Measure("0", scoreTextFace, 1)
+
+	s.namePositions = make(map[geometry.Side]geometry.Vector, len(s.scores))
+
+	for side, sc := range s.scores {
+		if s.rules.Format == rules.FourWay {
+			s.namePositions[side] = geometry.Vector{X: sc.position.X, Y: sc.position.Y + scoreHeight + 30}
+			continue
+		}
 
-	// draw players, ball, and scores
-	drawPlayer(s.player1.Position(), s.player1.BouncerWidth(), s.player1.BouncerHeight(), screen)
-	drawPlayer(s.player2.Position(), s.player2.BouncerWidth(), s.player2.BouncerHeight(), screen)
-	drawBall(screen, s.ball.Position(), s.ball.Width(), s.ballTrail)
-	s.score1.draw(screen)
-	s.score2.draw(screen)
+		nameWidth, _ := text.Measure(fmt.Sprintf("%10s", teamName(s.players, side)), playerNameTextFace, 1)
 
-	// draw player1 name
-	if err := drawPlayerName(s.player1.Name(), s.p1NamePosition, screen, s.game.font); err != nil {
-		slog.Error("failed to draw player name", slog.Any("error", err))
+		x := s.game.screenWidth()/2 - 10 - nameWidth
+		if side == geometry.Right {
+			x = s.game.screenWidth()/2 + 10 + (nameWidth / 2)
+		}
+
+		s.namePositions[side] = geometry.Vector{X: x, Y: scoreHeight + 50}
 	}
+}
 
-	// draw player2 name
-	if err := drawPlayerName(s.player2.Name(), s.p2NamePosition, screen, s.game.font); err != nil {
-		slog.Error("failed to draw player name", slog.Any("error", err))
+// drawNames draws the names of the players of each side.
+func (s *baseState) drawNames(screen *ebiten.Image) {
+	for side, position := range s.namePositions {
+		if err := drawPlayerName(teamName(s.players, side), position, screen, s.game.font); err != nil {
+			slog.Error("failed to draw player name", slog.Any("error", err))
+		}
 	}
+}
+
+func (s *multiplayerState) draw(screen *ebiten.Image) {
+	// draw field, players and balls
+	s.drawWorld(screen)
+
+	// draw scores and player names
+	s.drawScores(screen)
+	s.drawNames(screen)
 
 	// draw metric
 	s.metric.DrawNetworkInfo(screen, s.pingCurrentPlayer, s.pingOpponent)
+
+	// draw common elements
+	s.drawOverlay(screen)
 }
 
 func (s *multiplayerState) getBall() ball.Ball {
-	return s.ball
+	return s.balls[0]
 }
 
 func (*multiplayerState) canPause() bool {
@@ -1,41 +1,55 @@
 package game
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/gandarez/pong-multiplayer-go/internal/ai"
+	"github.com/gandarez/pong-multiplayer-go/internal/profile"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
 // onePlayerState represents the state of the game when playing against the CPU.
+// The player defends the left side and the CPU controls every other paddle.
 type onePlayerState struct {
-	ball    ball.Ball
-	player1 player.Player
-	player2 player.Player
-	score1  *score
-	score2  *score
 	*baseState
 }
 
 // newOnePlayerState creates a new onePlayerState.
 func newOnePlayerState(game *Game) *onePlayerState {
-	base := newBasePlayingState(game, game.menu.Level())
+	field := game.menu.Arena()
+	base := newBasePlayingState(game, game.menu.Level(), field)
+
+	slots := lineup(base.rules.Format)
+	for i, sl := range slots {
+		name := "CPU"
+		if len(slots) > 2 {
+			name = fmt.Sprintf("CPU %d", i)
+		}
+
+		if i == 0 {
+			name = "Player"
+		}
 
-	player1 := player.NewLocal("Player", geometry.Left, ScreenWidth, ScreenHeight, fieldBorderWidth)
-	player2 := player.NewLocal("CPU", geometry.Right, ScreenWidth, ScreenHeight, fieldBorderWidth)
-	ball := ball.NewLocal(ScreenWidth, ScreenHeight, base.level)
-	score1 := newScore1(base.game.font)
-	score2 := newScore2(base.game.font)
+		paddle := game.menu.Handicap(i).Paddle()
+		base.addPlayer(player.NewLocal(name, sl.side, playerArea(field, base.rules.Format, sl), paddle), sl)
+	}
+
+	base.balls = []ball.Ball{ball.NewLocal(field, base.level, base.rules, game.events)}
+
+	game.effects.SetRumble(base.soloGamepads)
+	game.tracker.Start(profile.VsCPU, base.level, base.match.Elapsed,
+		profile.Player{Name: game.menu.PlayerName(), Side: base.lineup[0].side})
+	game.recorder.Start(base.match.Elapsed)
+	game.audio.SetLocalSides(base.lineup[0].side)
 
 	return &onePlayerState{
 		baseState: base,
-		ball:      ball,
-		player1:   player1,
-		player2:   player2,
-		score1:    score1,
-		score2:    score2,
 	}
 }
 
@@ -48,73 +62,101 @@ func (s *onePlayerState) update() error {
 		return nil
 	}
 
-	// handle player input
-	input := player.Input{
-		Up:   ebiten.IsKeyPressed(ebiten.KeyUp),
-		Down: ebiten.IsKeyPressed(ebiten.KeyDown),
-	}
-	s.player1.Update(input)
-
-	// update CPU player
-	s.player2.SetPosition(ai.GuessBallPosition(
-		s.ball.Bounds().Y,
-		s.player2.Position().Y,
-		s.player2.BouncerHeight(),
-		ScreenHeight,
-		fieldBorderWidth,
-	))
-
-	// update ball
-	s.updateBallTrail(s.ball)
-	s.ball.Update(s.player1.Bounds(), s.player2.Bounds())
-
-	// check for goals
-	if goal, side := s.ball.CheckGoal(); goal {
-		s.updateScore(side)
-		s.ball = s.ball.Reset()
-		s.player1.Reset()
-		s.player2.Reset()
-	}
+	// handle player input from the keyboard, a gamepad, the touch screen or the mouse
+	input := s.game.soloInput(s.lineup[0].side)
+	s.followPointer(&input, s.players[0])
 
-	// check for winner
-	if s.score1.value == maxScore || s.score2.value == maxScore {
-		winner := s.player1
-		if s.score2.value == maxScore {
-			winner = s.player2
+	// step the simulation in fixed ticks
+	for range s.timestep.Advance() {
+		if s.tick(s.timestep.Delta()*s.game.effects.TimeScale(), input) {
+			break
 		}
-
-		s.game.changeState(newWinnerState(s.game, winner.Name(), s))
 	}
 
 	return nil
 }
 
+// tick advances the game by a single simulation tick of dt seconds.
+// It returns true when the game is over.
+func (s *onePlayerState) tick(dt float64, input player.Input) bool {
+	s.match.Advance(dt)
+
+	s.players[0].Update(dt, input)
+
+	// update CPU players following the ball coming towards them
+	for i, cpu := range s.players[1:] {
+		sl := s.lineup[i+1]
+		low, high := areaLimits(sl.side, playerArea(s.arena, s.rules.Format, sl))
+
+		cpu.SetPosition(ai.GuessBallPosition(
+			dt,
+			alongAxis(sl.side, approachingBall(s.balls, sl.side, s.arena).Position()),
+			alongAxis(sl.side, cpu.Position()),
+			cpu.BouncerHeight(),
+			cpu.Speed(),
+			low,
+			high,
+		))
+	}
+
+	// update balls and check for goals
+	s.updateBalls(dt)
+
+	// check for winner
+	return s.checkMatchOver()
+}
+
 // draw draws the game elements.
 func (s *onePlayerState) draw(screen *ebiten.Image) {
-	// draw common elements
-	s.baseState.draw(screen)
-
-	// draw players, ball, and scores
-	drawPlayer(s.player1.Position(), s.player1.BouncerWidth(), s.player1.BouncerHeight(), screen)
-	drawPlayer(s.player2.Position(), s.player2.BouncerWidth(), s.player2.BouncerHeight(), screen)
-	drawBall(screen, s.ball.Position(), s.ball.Width(), s.ballTrail)
-	s.score1.draw(screen)
-	s.score2.draw(screen)
-}
+	// draw field, players and balls
+	s.drawWorld(screen)
 
-// updateScore updates the score based on which side the goal was made.
-func (s *onePlayerState) updateScore(side geometry.Side) {
-	if side == geometry.Left {
-		s.score2.value++
-	} else {
-		s.score1.value++
-	}
+	// draw scores
+	s.drawScores(screen)
+
+	// draw common elements
+	s.drawOverlay(screen)
 }
 
 func (s *onePlayerState) getBall() ball.Ball {
-	return s.ball
+	return s.balls[0]
 }
 
 func (*onePlayerState) canPause() bool {
 	return true
 }
+
+// approachingBall returns the closest ball moving towards the goal of side.
+// If no ball is moving towards it, the first ball is returned.
+func approachingBall(balls []ball.Ball, side geometry.Side, field arena.Arena) ball.Ball {
+	var (
+		closest  = balls[0]
+		distance = math.Inf(1)
+	)
+
+	for _, b := range balls {
+		var (
+			angle    = b.Angle() * math.Pi / 180
+			position = b.Position()
+			towards  bool
+			d        float64
+		)
+
+		switch side {
+		case geometry.Right:
+			towards, d = math.Cos(angle) > 0, field.Width-position.X
+		case geometry.Top:
+			towards, d = math.Sin(angle) < 0, position.Y
+		case geometry.Bottom:
+			towards, d = math.Sin(angle) > 0, field.Height-position.Y
+		default:
+			towards, d = math.Cos(angle) < 0, position.X
+		}
+
+		if towards && d < distance {
+			closest, distance = b, d
+		}
+	}
+
+	return closest
+}
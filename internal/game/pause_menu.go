@@ -2,32 +2,43 @@ package game
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text/v2"
 
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
 	"github.com/gandarez/pong-multiplayer-go/internal/font" // Your custom font package
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
 	"github.com/gandarez/pong-multiplayer-go/internal/ui"
-	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
+// pauseOptionSpacing is the vertical space between the options of the pause menu.
+const pauseOptionSpacing = 30
+
 // pauseMenu represents the pause menu.
 type pauseMenu struct {
-	font          *font.Font
-	screenWidth   float64
-	options       []string
-	selectedIndex int
-	ShouldExit    bool
-	ShouldResume  bool
-	isShown       bool
+	list         *ui.List
+	ShouldExit   bool
+	ShouldResume bool
+	isShown      bool
 }
 
 // newPauseMenu creates a new pauseMenu.
-func newPauseMenu(fontLoader *font.Font, screenWidth float64) *pauseMenu {
-	return &pauseMenu{
-		font:        fontLoader,
-		screenWidth: screenWidth,
-		options:     []string{"Resume", "Exit"},
+func newPauseMenu(fontLoader *font.Font) *pauseMenu {
+	pm := &pauseMenu{}
+
+	textFace, err := fontLoader.Face("ui", 20)
+	if err != nil {
+		panic(err)
+	}
+
+	options := []ui.Widget{
+		ui.NewOption("Resume", textFace, pm.resume),
+		ui.NewOption("Exit", textFace, pm.exit),
 	}
+
+	// the options are centered on the screen
+	pm.list = ui.NewList(display.Height/2-float64(len(options))*pauseOptionSpacing/2, pauseOptionSpacing, options...)
+
+	return pm
 }
 
 // show shows the pause menu.
@@ -35,68 +46,41 @@ func (pm *pauseMenu) show() {
 	pm.isShown = true
 	pm.ShouldExit = false
 	pm.ShouldResume = false
-	pm.selectedIndex = 0
+	pm.list.SetFocus(0)
 }
 
-// update updates the pause menu.
-func (pm *pauseMenu) update() {
-	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
-		if pm.selectedIndex > 0 {
-			pm.selectedIndex--
-		}
-	}
+// resume closes the pause menu to resume the match.
+func (pm *pauseMenu) resume() {
+	pm.ShouldResume = true
+	pm.isShown = false
+	pm.ShouldExit = false
+}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
-		if pm.selectedIndex < len(pm.options)-1 {
-			pm.selectedIndex++
-		}
-	}
+// exit closes the pause menu to leave the match.
+func (pm *pauseMenu) exit() {
+	pm.ShouldResume = false
+	pm.isShown = false
+	pm.ShouldExit = true
+}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-		switch pm.selectedIndex {
-		case 0: // resume
-			pm.ShouldResume = true
-			pm.isShown = false
-			pm.ShouldExit = false
-		case 1: // exit
-			pm.ShouldResume = false
-			pm.isShown = false
-			pm.ShouldExit = true
-		}
+// update updates the pause menu. Options can be chosen with the keyboard, a gamepad, the mouse
+// or tapped, and going back resumes the match.
+func (pm *pauseMenu) update(pointer *input.Pointer) {
+	in := ui.ReadInput(pointer)
+	if in.Back {
+		pm.resume()
+		return
 	}
+
+	pm.list.Update(in)
 }
 
 // draw draws the pause menu.
 func (pm *pauseMenu) draw(screen *ebiten.Image) {
 	// reduce alpha of the background
-	overlay := ebiten.NewImage(ScreenWidth, ScreenHeight)
-	overlay.Fill(ui.TransparentBlack)
+	overlay := ebiten.NewImage(screen.Bounds().Dx(), screen.Bounds().Dy())
+	overlay.Fill(theme.Active().Shade(128))
 	screen.DrawImage(overlay, nil)
 
-	// draw menu options
-	textFace, err := pm.font.Face("ui", 20)
-	if err != nil {
-		panic(err)
-	}
-
-	y := ScreenHeight/2 - float64(len(pm.options)*30)/2
-
-	for i, option := range pm.options {
-		color := ui.DefaultColor
-		if i == pm.selectedIndex {
-			color = ui.HighlightColor
-		}
-
-		textWidth, _ := text.Measure(option, textFace, 1)
-		uiText := ui.Text{
-			Value:    option,
-			FontFace: textFace,
-			Position: geometry.Vector{
-				X: (pm.screenWidth - textWidth) / 2,
-				Y: y + float64(i*30),
-			},
-			Color: color,
-		}
-		uiText.Draw(screen)
-	}
+	pm.list.Draw(screen)
 }
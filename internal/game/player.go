@@ -8,15 +8,16 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/gandarez/pong-multiplayer-go/internal/font"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
 	"github.com/gandarez/pong-multiplayer-go/internal/ui"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
-// drawPlayer draws a player on the screen.
-func drawPlayer(position geometry.Vector, bouncerWidth, bouncerHeight float64, screen *ebiten.Image) {
+// drawPlayer draws a player on the screen in the color clr.
+func drawPlayer(position geometry.Vector, bouncerWidth, bouncerHeight float64, clr color.RGBA, screen *ebiten.Image) {
 	// create bouncer image
 	playerImg := ebiten.NewImage(int(bouncerWidth), int(bouncerHeight))
-	playerImg.Fill(ui.DefaultColor)
+	playerImg.Fill(clr)
 
 	// translate player to the correct position
 	op := &ebiten.DrawImageOptions{}
@@ -36,7 +37,7 @@ func drawPlayerName(name string, namePosition geometry.Vector, screen *ebiten.Im
 		Value:    name,
 		FontFace: textface,
 		Position: namePosition,
-		Color:    color.RGBA{200, 200, 200, 200},
+		Color:    theme.Fade(theme.Active().Text, 200.0/255),
 	}
 	t.Draw(screen)
 
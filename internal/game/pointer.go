@@ -0,0 +1,158 @@
+package game
+
+import (
+	"log/slog"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// followRange is the distance to the target under which a paddle controlled over the
+// network by touch or mouse slows down, so it stops on the target instead of shaking around it.
+const followRange = 20
+
+// screenToWorld converts a position on the screen to a position in the arena.
+func (s *baseState) screenToWorld(pos geometry.Vector) geometry.Vector {
+	op := s.worldOptions()
+	op.GeoM.Invert()
+
+	x, y := op.GeoM.Apply(pos.X, pos.Y)
+
+	return geometry.Vector{X: x, Y: y}
+}
+
+// pointers returns the positions in the arena of the fingers on the screen, ignoring
+// the ones on the pause button, and of the mouse cursor. With drag set, the cursor is
+// only returned while the left button is held down, otherwise whenever the mouse is used.
+func (s *baseState) pointers(drag bool) []geometry.Vector {
+	pointer := s.game.controls.Pointer()
+	button := s.pauseButton()
+
+	var positions []geometry.Vector
+
+	for _, touch := range pointer.Touches() {
+		if button != nil && button.Contains(touch) {
+			continue
+		}
+
+		positions = append(positions, s.screenToWorld(touch))
+	}
+
+	cursor, ok := pointer.Cursor()
+	if drag {
+		cursor, ok = pointer.Dragging()
+	}
+
+	if ok {
+		positions = append(positions, s.screenToWorld(cursor))
+	}
+
+	return positions
+}
+
+// followPointer makes the paddle of p follow the first finger on the screen or the mouse
+// cursor, when there is one.
+func (s *baseState) followPointer(in *player.Input, p player.Player) {
+	positions := s.pointers(false)
+	if len(positions) == 0 {
+		return
+	}
+
+	in.Follow = true
+	in.Target = alongAxis(p.Side(), positions[0])
+}
+
+// followPointers makes the paddles of the local players follow the fingers on the screen
+// and the mouse cursor while dragging, each one moving the closest paddle.
+func (s *baseState) followPointers(inputs []player.Input) {
+	for _, pos := range s.pointers(true) {
+		closest, distance := -1, math.Inf(1)
+
+		for i, p := range s.players {
+			if d := p.Bounds().Center().Distance(pos); d < distance {
+				closest, distance = i, d
+			}
+		}
+
+		if closest < 0 {
+			continue
+		}
+
+		inputs[closest].Follow = true
+		inputs[closest].Target = alongAxis(s.players[closest].Side(), pos)
+	}
+}
+
+// followAxis returns the analog control moving the paddle towards target, used to send
+// touch and mouse controls over the network.
+func followAxis(p player.Player, target float64) float64 {
+	center := alongAxis(p.Side(), p.Bounds().Center())
+
+	return min(max((target-center)/followRange, -1), 1)
+}
+
+// pauseButton returns the on-screen button pausing the match, or nil when the touch screen
+// and the mouse aren't being used or the match can't be paused.
+func (s *baseState) pauseButton() *ui.Button {
+	if !s.game.controls.Pointer().Active() || !s.game.currentState.canPause() {
+		return nil
+	}
+
+	face, err := s.game.font.Face("ui", 16)
+	if err != nil {
+		return nil
+	}
+
+	return &ui.Button{
+		Label:    "II",
+		FontFace: face,
+		Position: geometry.Vector{X: s.game.screenWidth() - 40, Y: 10},
+	}
+}
+
+// pauseTapped returns true if the on-screen pause button was just tapped or clicked.
+func (s *baseState) pauseTapped() bool {
+	button := s.pauseButton()
+	if button == nil {
+		return false
+	}
+
+	pos, ok := s.game.controls.Pointer().Tap()
+
+	return ok && button.Contains(pos)
+}
+
+// backButton returns the on-screen button leaving the current state.
+func (g *Game) backButton() *ui.Button {
+	face, err := g.font.Face("ui", 16)
+	if err != nil {
+		slog.Error("failed to create text face", slog.Any("error", err))
+		return nil
+	}
+
+	return ui.NewBackButton(face)
+}
+
+// backPressed returns true if Esc was just pressed or the back button was just tapped.
+func (g *Game) backPressed() bool {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		return true
+	}
+
+	button := g.backButton()
+	pos, ok := g.controls.Pointer().Tap()
+
+	return ok && button != nil && button.Contains(pos)
+}
+
+// drawBackButton draws the on-screen back button.
+func (g *Game) drawBackButton(screen *ebiten.Image) {
+	if button := g.backButton(); button != nil {
+		button.Draw(screen)
+	}
+}
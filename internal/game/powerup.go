@@ -0,0 +1,70 @@
+package game
+
+import (
+	"log/slog"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/font"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/powerup"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// drawPowerUps draws the power-ups waiting to be collected.
+func drawPowerUps(screen *ebiten.Image, font *font.Font, items []powerup.PowerUp) {
+	if len(items) == 0 {
+		return
+	}
+
+	textFace, err := font.Face("ui", 12)
+	if err != nil {
+		slog.Error("failed to get text face to draw power-ups", slog.Any("error", err))
+		return
+	}
+
+	for _, p := range items {
+		vector.StrokeRect(
+			screen,
+			float32(p.Position.X), float32(p.Position.Y),
+			powerup.Size, powerup.Size,
+			2, theme.Active().Highlight, false,
+		)
+
+		symbol := p.Kind.Symbol()
+		width, height := text.Measure(symbol, textFace, 1)
+
+		t := ui.Text{
+			Value:    symbol,
+			FontFace: textFace,
+			Position: geometry.Vector{
+				X: p.Position.X + (powerup.Size-width)/2,
+				Y: p.Position.Y + (powerup.Size-height)/2,
+			},
+			Color: theme.Active().Highlight,
+		}
+		t.Draw(screen)
+	}
+}
+
+// drawShield draws the shield protecting the goal on side.
+func drawShield(screen *ebiten.Image, side geometry.Side, field arena.Arena) {
+	bounds := shieldBounds(side, field)
+
+	// don't draw over the borders
+	if !side.Horizontal() {
+		bounds.Y += field.BorderWidth
+		bounds.Height -= 2 * field.BorderWidth
+	}
+
+	vector.DrawFilledRect(
+		screen,
+		float32(bounds.X), float32(bounds.Y),
+		float32(bounds.Width), float32(bounds.Height),
+		theme.Active().Highlight, false,
+	)
+}
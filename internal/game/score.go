@@ -1,14 +1,16 @@
 package game
 
 import (
-	"image/color"
 	"strconv"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
 	"github.com/gandarez/pong-multiplayer-go/internal/font"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
 	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
@@ -19,16 +21,17 @@ type score struct {
 	textFace *text.GoTextFace
 }
 
-func new(textFace *text.GoTextFace, posX float64) *score {
+func new(textFace *text.GoTextFace, position geometry.Vector) *score {
 	return &score{
 		value:    0,
-		position: geometry.Vector{X: posX, Y: 30},
+		position: position,
 		textFace: textFace,
 	}
 }
 
-// newScore1 creates a new score instance.
-func newScore1(font *font.Font) *score {
+// newScores creates a score for each side defending a goal in the given format, on a screen
+// screenWidth wide.
+func newScores(font *font.Font, format rules.Format, screenWidth float64) map[geometry.Side]*score {
 	textFace, err := font.Face("score", 60)
 	if err != nil {
 		panic(err)
@@ -36,17 +39,36 @@ func newScore1(font *font.Font) *score {
 
 	scoreWidth, _ := text.Measure("0", textFace, 1)
 
-	return new(textFace, ScreenWidth/2-50-scoreWidth)
+	scores := make(map[geometry.Side]*score)
+
+	for _, side := range format.Sides() {
+		scores[side] = new(textFace, scorePosition(side, format, scoreWidth, screenWidth))
+	}
+
+	return scores
 }
 
-// newScore2 creates a new score instance.
-func newScore2(font *font.Font) *score {
-	textFace, err := font.Face("score", 60)
-	if err != nil {
-		panic(err)
+// scorePosition returns where the score of side is drawn. Scores of four-way
+// matches are drawn next to the goal of each side, otherwise they're at the top.
+func scorePosition(side geometry.Side, format rules.Format, scoreWidth, screenWidth float64) geometry.Vector {
+	if format != rules.FourWay {
+		if side == geometry.Right {
+			return geometry.Vector{X: screenWidth/2 + 70, Y: 30}
+		}
+
+		return geometry.Vector{X: screenWidth/2 - 50 - scoreWidth, Y: 30}
 	}
 
-	return new(textFace, ScreenWidth/2+70)
+	switch side {
+	case geometry.Right:
+		return geometry.Vector{X: screenWidth - 60 - scoreWidth, Y: display.Height/2 - 30}
+	case geometry.Top:
+		return geometry.Vector{X: screenWidth/2 + 40, Y: 50}
+	case geometry.Bottom:
+		return geometry.Vector{X: screenWidth/2 + 40, Y: display.Height - 110}
+	default:
+		return geometry.Vector{X: 60, Y: display.Height/2 - 30}
+	}
 }
 
 // draw renders the score on the screen.
@@ -55,7 +77,7 @@ func (s *score) draw(screen *ebiten.Image) {
 		Value:    strconv.Itoa(int(s.value)),
 		FontFace: s.textFace,
 		Position: s.position,
-		Color:    color.RGBA{200, 200, 200, 255},
+		Color:    theme.Active().Text,
 	}
 	uiText.Draw(screen)
 }
@@ -1,67 +1,97 @@
 package game
 
 import (
+	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
-	"github.com/gandarez/pong-multiplayer-go/internal/menu"
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
 	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+	"github.com/gandarez/pong-multiplayer-go/pkg/bracket"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
-	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
+// bracketRefresh is how often the bracket of a tournament run on another device is fetched
+// while watching one of its matches.
+const bracketRefresh = 15 * time.Second
+
 type spectatorState struct {
-	ball           ball.Ball
-	player1        player.Player
-	player2        player.Player
-	score1         *score
-	score2         *score
-	gameStateCh    chan network.GameState
-	sessionID      string
-	p1NamePosition geometry.Vector
-	p2NamePosition geometry.Vector
+	gameStateCh chan network.GameState
+	sessionID   string
+	// replayID is the replay being watched, empty when watching a live session.
+	replayID string
+	// profiles are the accounts of the verified players being watched, by name.
+	// They're fetched in the background, requested holding the names already asked for.
+	profiles   map[string]account.Account
+	profilesMu sync.Mutex
+	requested  map[string]bool
+	// bracket is the bracket of the tournament of the match, nil when it isn't a tournament match.
+	// For the tournaments run on other devices it's fetched in the background every bracketRefresh,
+	// following the other matches of the tournament, tournamentID being the one it was found in.
+	bracket      *bracket.Bracket
+	tournamentID string
+	bracketMu    sync.Mutex
+	// bracketFetched is when the bracket was last fetched, zero until then.
+	bracketFetched time.Time
+	// localBracket is true when the bracket is the one of the tournament run on this device.
+	localBracket bool
 	*baseState
 }
 
 func newSpectatorState(game *Game) *spectatorState {
-	base := newBasePlayingState(game, game.menu.Level())
-
-	// initialize players and ball
-	player1 := player.NewNetwork("", geometry.Left, ScreenWidth, ScreenHeight)
-	player2 := player.NewNetwork("", geometry.Right, ScreenWidth, ScreenHeight)
-	ball := ball.NewNetwork()
-	score1 := newScore1(base.game.font) // left
-	score2 := newScore2(base.game.font) // right
+	base := newBasePlayingState(game, game.menu.Level(), arena.Classic())
 
-	p1NamePosition, p2NamePosition := calculatePlayerNamePosition(*game.font, player1.Name(), player2.Name(), player1.Side())
+	// players are added as their states are received
+	base.balls = []ball.Ball{ball.NewNetwork()}
+	base.networked = true
 
 	gameStateCh := make(chan network.GameState)
 
 	state := &spectatorState{
-		baseState:      base,
-		ball:           ball,
-		player1:        player1,
-		player2:        player2,
-		score1:         score1,
-		score2:         score2,
-		gameStateCh:    gameStateCh,
-		sessionID:      game.menu.SessionID,
-		p1NamePosition: p1NamePosition,
-		p2NamePosition: p2NamePosition,
+		baseState:   base,
+		gameStateCh: gameStateCh,
+		sessionID:   game.menu.SessionID,
+		replayID:    game.menu.ReplayID,
+		profiles:    make(map[string]account.Account),
+		requested:   make(map[string]bool),
+	}
+
+	// watched matches aren't added to the profile of the player but are summed up,
+	// and the result of the matches of the tournament run on this device is recorded
+	game.tracker.Stop()
+	game.audio.SetLocalSides()
+
+	if match, ok := game.menu.TournamentMatch(); ok {
+		state.bracket, state.localBracket = game.menu.Tournament().Bracket, true
+		game.tournamentTracker.Start(game.menu.Tournament(), match.ID, func(side geometry.Side) string {
+			return teamName(base.players, side)
+		})
 	}
 
+	start := time.Now()
+	game.recorder.Start(func() time.Duration { return time.Since(start) })
+
 	state.connectAsSpectator()
 
 	return state
 }
 
 func (s *spectatorState) update() error {
-	// handle ESC key to go back to main menu
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+	// handle ESC key or the back button to go back to main menu
+	if s.game.backPressed() {
+		s.game.tournamentTracker.Stop()
 		s.game.networkClient.Close()
-		s.game.menu = menu.New(s.game.font, ScreenWidth, ScreenHeight)
+		s.game.resetMenu()
 		s.game.changeState(newMainMenuState(s.game))
 
 		return nil
@@ -75,69 +105,181 @@ func (s *spectatorState) update() error {
 }
 
 func (s *spectatorState) updateGameState(gameState network.GameState) {
-	// update ball
-	s.updateBallTrail(s.ball)
-	s.ball.SetPosition(gameState.Ball.Position)
-	s.ball.SetAngle(gameState.Ball.Angle)
-	s.ball.SetBounces(gameState.Ball.Bounces)
-
-	// update players
-	s.player1.SetPosition(gameState.CurrentPlayer.PositionY)
-	s.player2.SetPosition(gameState.OpponentPlayer.PositionY)
-
-	// update player names if they have changed
-	if s.player1.Name() != gameState.CurrentPlayer.Name {
-		s.player1.SetName(gameState.CurrentPlayer.Name)
-		s.updatePlayerNamePosition()
-	}
-
-	if s.player2.Name() != gameState.OpponentPlayer.Name {
-		s.player2.SetName(gameState.OpponentPlayer.Name)
-		s.updatePlayerNamePosition()
+	// update balls, power-ups and clock
+	s.syncBalls(gameState)
+	s.syncArcade(gameState)
+	s.syncClock(gameState)
+
+	// update players, their names and scores
+	states := playerStates(gameState)
+	if format := formatOf(states); format != s.rules.Format {
+		s.setFormat(format)
 	}
 
-	// update scores
-	s.score1.value = gameState.CurrentPlayer.Score
-	s.score2.value = gameState.OpponentPlayer.Score
+	s.syncPlayers(states)
+	s.fetchProfiles(states)
+	s.fetchBracket(states)
 
 	// check winner
-	if gameState.CurrentPlayer.Winner || gameState.OpponentPlayer.Winner {
-		winnerName := gameState.CurrentPlayer.Name
-		if gameState.OpponentPlayer.Winner {
-			winnerName = gameState.OpponentPlayer.Name
-		}
-
+	if side, ok := winnerSide(gameState); ok {
 		// close network connection
 		s.game.networkClient.Close()
+		s.game.events.Publish(event.MatchOver{Winner: side})
 
 		// change state to winner screen
-		s.game.changeState(newWinnerState(s.game, winnerName, s))
+		s.game.changeState(newWinnerState(s.game, s.baseState, side, s))
 	}
 }
 
 func (s *spectatorState) draw(screen *ebiten.Image) {
+	// draw field, players and balls
+	s.drawWorld(screen)
+
+	// draw scores and player names
+	s.drawScores(screen)
+	s.drawNames(screen)
+	s.drawProfiles(screen)
+	s.drawBracket(screen)
+
 	// draw common elements
-	s.baseState.draw(screen)
-
-	// draw players, ball, and scores
-	drawPlayer(s.player1.Position(), s.player1.BouncerWidth(), s.player1.BouncerHeight(), screen)
-	drawPlayer(s.player2.Position(), s.player2.BouncerWidth(), s.player2.BouncerHeight(), screen)
-	drawBall(screen, s.ball.Position(), s.ball.Width(), s.ballTrail)
-	s.score1.draw(screen)
-	s.score2.draw(screen)
-
-	// draw player names
-	if err := drawPlayerName(s.player1.Name(), s.p1NamePosition, screen, s.game.font); err != nil {
-		slog.Error("failed to draw player name", slog.Any("error", err))
+	s.drawOverlay(screen)
+	s.game.drawBackButton(screen)
+}
+
+// fetchProfiles fetches the accounts of the verified players not asked for yet.
+func (s *spectatorState) fetchProfiles(states []network.PlayerState) {
+	for _, ps := range states {
+		if !ps.Verified || s.requested[ps.Name] {
+			continue
+		}
+
+		s.requested[ps.Name] = true
+
+		go func(name string) {
+			profile, err := network.FetchProfile(s.game.ctx, name)
+			if err != nil {
+				slog.Error("failed to fetch player profile", slog.String("name", name), slog.Any("error", err))
+				return
+			}
+
+			s.profilesMu.Lock()
+			s.profiles[name] = profile
+			s.profilesMu.Unlock()
+		}(ps.Name)
 	}
+}
 
-	if err := drawPlayerName(s.player2.Name(), s.p2NamePosition, screen, s.game.font); err != nil {
-		slog.Error("failed to draw player name", slog.Any("error", err))
+// drawProfiles draws the accounts of the verified players below their names.
+func (s *spectatorState) drawProfiles(screen *ebiten.Image) {
+	face, err := s.game.font.Face("ui", 10)
+	if err != nil {
+		slog.Error("failed to create text face", slog.Any("error", err))
+		return
+	}
+
+	s.profilesMu.Lock()
+	defer s.profilesMu.Unlock()
+
+	for side, position := range s.namePositions {
+		y := position.Y + 18
+
+		for _, p := range s.players {
+			profile, ok := s.profiles[p.Name()]
+			if p.Side() != side || !ok {
+				continue
+			}
+
+			line := fmt.Sprintf("%s verified since %s, rated %.f",
+				profile.Name, profile.Created.Format(time.DateOnly), profile.Rating.Rating)
+			drawText(screen, line, face, position.X, y, theme.Active().Text)
+
+			y += 14
+		}
 	}
 }
 
+// fetchBracket fetches the bracket of the tournament the players being watched play in, if any,
+// once both players are known and again every bracketRefresh.
+func (s *spectatorState) fetchBracket(states []network.PlayerState) {
+	if s.localBracket || len(states) < 2 || time.Since(s.bracketFetched) < bracketRefresh {
+		return
+	}
+
+	s.bracketFetched = time.Now()
+
+	first, second := states[0].Name, states[1].Name
+
+	go func() {
+		tournaments, err := network.FetchTournaments(s.game.ctx, first)
+		if err != nil {
+			slog.Error("failed to fetch tournaments", slog.String("name", first), slog.Any("error", err))
+			return
+		}
+
+		s.bracketMu.Lock()
+		defer s.bracketMu.Unlock()
+
+		for _, t := range tournaments {
+			// brackets that can't be drawn are skipped, whatever the server sent
+			if t.Bracket == nil || t.Bracket.Validate() != nil {
+				continue
+			}
+
+			// the tournament is the one where the players are to meet next, until it's found
+			found := t.ID == s.tournamentID
+			if s.tournamentID == "" {
+				next, ok := t.Bracket.Next()
+				found = ok && next.Between(first, second)
+			}
+
+			if found {
+				s.bracket, s.tournamentID = t.Bracket, t.ID
+				return
+			}
+		}
+	}()
+}
+
+// drawBracket draws the bracket of the tournament while the ShowBracket action is held,
+// or a hint telling so.
+func (s *spectatorState) drawBracket(screen *ebiten.Image) {
+	s.bracketMu.Lock()
+	defer s.bracketMu.Unlock()
+
+	if s.bracket == nil {
+		return
+	}
+
+	face, err := s.game.font.Face("ui", 12)
+	if err != nil {
+		slog.Error("failed to create text face", slog.Any("error", err))
+		return
+	}
+
+	if !s.game.controls.Pressed(input.ShowBracket) {
+		hint := fmt.Sprintf("Tournament match, hold %s to see the bracket", s.game.controls.Binding(input.ShowBracket))
+		drawCenteredText(screen, hint, face, display.Height-24, theme.Active().Text)
+
+		return
+	}
+
+	// show the stage of the match being played
+	stage, next := bracket.Winners, -1
+	if m, ok := s.bracket.Next(); ok {
+		stage, next = m.Stage, m.ID
+	}
+
+	width := s.game.screenWidth()
+
+	vector.DrawFilledRect(screen, 0, 0, float32(width), display.Height, theme.Active().Shade(220), false)
+	drawCenteredText(screen, stage.String(), face, 30, theme.Active().Highlight)
+
+	area := geometry.Rect{X: 20, Y: 60, Width: width - 40, Height: display.Height - 90}
+	ui.DrawBracket(screen, face, s.bracket, stage, area, next)
+}
+
 func (s *spectatorState) getBall() ball.Ball {
-	return s.ball
+	return s.balls[0]
 }
 
 func (*spectatorState) canPause() bool {
@@ -146,7 +288,13 @@ func (*spectatorState) canPause() bool {
 
 func (s *spectatorState) connectAsSpectator() {
 	s.game.networkClient = network.NewSpectatorClient(s.game.ctx, s.game.cancel)
-	if err := s.game.networkClient.ConnectAsSpectator(s.sessionID); err != nil {
+
+	connect := func() error { return s.game.networkClient.ConnectAsSpectator(s.sessionID) }
+	if s.replayID != "" {
+		connect = func() error { return s.game.networkClient.ConnectToReplay(s.replayID) }
+	}
+
+	if err := connect(); err != nil {
 		slog.Error("failed to connect as spectator", slog.Any("error", err))
 		s.game.changeState(newMainMenuState(s.game))
 
@@ -159,7 +307,3 @@ func (s *spectatorState) connectAsSpectator() {
 		}
 	}()
 }
-
-func (s *spectatorState) updatePlayerNamePosition() {
-	s.p1NamePosition, s.p2NamePosition = calculatePlayerNamePosition(*s.game.font, s.player1.Name(), s.player2.Name(), s.player1.Side())
-}
@@ -0,0 +1,250 @@
+package game
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/drill"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	// launcherInset is how far the launcher stands from the right edge of the field.
+	launcherInset = 40.0
+	launcherSize  = 14.0
+	// targetFlashDuration is how long a target lights up once a ball goes through it, in seconds.
+	targetFlashDuration = 0.3
+	// targetFlashAlpha is the opacity of the highlight filling a target while it's lit up.
+	targetFlashAlpha = 90.0 / 255
+)
+
+// trainingState represents the state of the game while playing a training drill: a launcher
+// serves balls at the player, who tries to return them through the targets of the drill.
+type trainingState struct {
+	*baseState
+	drill    drill.Drill
+	launcher *drill.Launcher
+	result   drill.Result
+	// shots are the balls in play, in the same order as the balls of the base state.
+	shots []shot
+	// launcherY is where the launcher served the last ball from.
+	launcherY float64
+	// flash is the number of seconds the targets stay lit up.
+	flash float64
+}
+
+// shot is a ball served by the launcher and what the player did with it.
+type shot struct {
+	ball     ball.Ball
+	returned bool
+	onTarget bool
+}
+
+// newTrainingState creates a new trainingState playing the drill chosen in the menu.
+// Drills are played on the classic arena.
+func newTrainingState(game *Game) *trainingState {
+	field := arena.Classic()
+	base := newBasePlayingState(game, game.menu.Level(), field)
+
+	sl := lineup(base.rules.Format)[0]
+	paddle := game.menu.Handicap(0).Paddle()
+	base.addPlayer(player.NewLocal(game.menu.PlayerName(), sl.side, playerArea(field, base.rules.Format, sl), paddle), sl)
+
+	game.effects.SetRumble(base.soloGamepads)
+
+	d := game.menu.Drill()
+
+	return &trainingState{
+		baseState: base,
+		drill:     d,
+		launcher:  drill.NewLauncher(d),
+		launcherY: field.Height / 2,
+	}
+}
+
+// update updates the game logic.
+func (s *trainingState) update() error {
+	// update common elements
+	s.baseState.update()
+
+	if s.gamePaused {
+		return nil
+	}
+
+	// handle player input from the keyboard, a gamepad, the touch screen or the mouse
+	input := s.game.soloInput(s.lineup[0].side)
+	s.followPointer(&input, s.players[0])
+
+	// step the simulation in fixed ticks
+	for range s.timestep.Advance() {
+		if s.tick(s.timestep.Delta()*s.game.effects.TimeScale(), input) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// tick advances the drill by a single simulation tick of dt seconds.
+// It returns true when the drill is over.
+func (s *trainingState) tick(dt float64, input player.Input) bool {
+	s.players[0].Update(dt, input)
+	s.flash = max(s.flash-dt, 0)
+
+	if shot, ok := s.launcher.Update(dt); ok {
+		s.serve(shot)
+	}
+
+	s.updateShots(dt)
+
+	if !s.launcher.Done() || len(s.shots) > 0 {
+		return false
+	}
+
+	s.game.changeState(newTrainingOverState(s.game, s.drill, s.result, s.game.currentState))
+
+	return true
+}
+
+// serve launches a ball of the launcher.
+func (s *trainingState) serve(sh drill.Shot) {
+	s.launcherY = sh.Y
+	s.result.Served++
+
+	b := ball.NewLaunched(
+		s.arena,
+		s.level,
+		s.rules,
+		s.game.events,
+		geometry.Vector{X: s.arena.Width - launcherInset, Y: sh.Y},
+		sh.Angle,
+		sh.Speed,
+	)
+
+	s.shots = append(s.shots, shot{ball: b})
+}
+
+// updateShots moves every ball by dt seconds against the paddle, counting the balls returned
+// and the ones going through a target. Balls leaving the field are removed.
+func (s *trainingState) updateShots(dt float64) {
+	paddle := ball.NewPaddle(s.players[0].Bounds(), s.players[0].Side())
+	side := s.players[0].Side()
+
+	if len(s.shots) > 0 {
+		s.updateBallTrail(s.shots[0].ball)
+	}
+
+	kept := s.shots[:0]
+
+	for _, sh := range s.shots {
+		sh.ball.Update(dt, paddle)
+
+		if !sh.returned && sh.ball.LastHit() == side {
+			sh.returned = true
+			s.result.Returned++
+		}
+
+		if sh.returned && !sh.onTarget && s.drill.OnTarget(sh.ball.Bounds()) {
+			sh.onTarget = true
+			s.result.OnTarget++
+			s.flash = targetFlashDuration
+		}
+
+		if goal, conceded := sh.ball.CheckGoal(); goal {
+			if conceded == side {
+				// missed balls are goals, for the sound and the effects
+				s.game.events.Publish(event.Goal{Side: side, Scorer: geometry.Undefined, Position: sh.ball.Bounds().Center()})
+			}
+
+			continue
+		}
+
+		kept = append(kept, sh)
+	}
+
+	s.shots = kept
+
+	s.balls = s.balls[:0]
+	for _, sh := range s.shots {
+		s.balls = append(s.balls, sh.ball)
+	}
+}
+
+// draw draws the game elements.
+func (s *trainingState) draw(screen *ebiten.Image) {
+	// draw field, player and balls
+	s.drawWorld(screen)
+
+	// draw the targets and the launcher above the field
+	s.drawDrill(screen)
+
+	// draw the progress of the drill
+	s.drawProgress(screen)
+
+	// draw common elements
+	s.drawOverlay(screen)
+}
+
+// drawDrill draws the targets, lit up while a ball goes through them, and the launcher.
+func (s *trainingState) drawDrill(screen *ebiten.Image) {
+	op := s.worldOptions()
+
+	toScreen := func(r geometry.Rect) (float32, float32, float32, float32) {
+		x0, y0 := op.GeoM.Apply(r.X, r.Y)
+		x1, y1 := op.GeoM.Apply(r.MaxX(), r.MaxY())
+
+		return float32(x0), float32(y0), float32(x1 - x0), float32(y1 - y0)
+	}
+
+	for _, t := range s.drill.Targets {
+		x, y, w, h := toScreen(t)
+
+		if s.flash > 0 {
+			vector.DrawFilledRect(screen, x, y, w, h, theme.Fade(theme.Active().Highlight, targetFlashAlpha), false)
+		}
+
+		vector.StrokeRect(screen, x, y, w, h, 2, theme.Active().Highlight, false)
+	}
+
+	x, y, w, h := toScreen(geometry.Rect{
+		X:      s.arena.Width - launcherInset - launcherSize/2,
+		Y:      s.launcherY - launcherSize/2,
+		Width:  launcherSize,
+		Height: launcherSize,
+	})
+	vector.StrokeRect(screen, x, y, w, h, 2, theme.Active().Text, false)
+}
+
+// drawProgress draws the balls served and the returns of the drill.
+func (s *trainingState) drawProgress(screen *ebiten.Image) {
+	face, err := s.game.font.Face("ui", 14)
+	if err != nil {
+		slog.Error("failed to create training text face", slog.Any("error", err))
+		return
+	}
+
+	progress := fmt.Sprintf("%s   Ball %d/%d   Returned %d   On target %d", s.drill.Name, s.launcher.Served(),
+		s.drill.Balls, s.result.Returned, s.result.OnTarget)
+
+	drawCenteredText(screen, progress, face, 16, theme.Active().Text)
+}
+
+func (s *trainingState) getBall() ball.Ball {
+	if len(s.balls) == 0 {
+		return nil
+	}
+
+	return s.balls[0]
+}
+
+func (*trainingState) canPause() bool {
+	return true
+}
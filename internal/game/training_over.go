@@ -0,0 +1,124 @@
+package game
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/drill"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
+)
+
+// trainingOverState represents the state once every ball of a drill was played.
+// It shows the result of the session next to the best one before it, and saves it.
+type trainingOverState struct {
+	game   *Game
+	drill  drill.Drill
+	result drill.Result
+	// best is the accuracy of the best session of the drill before this one, played is false
+	// when it's the first session.
+	best      float64
+	played    bool
+	prevState state
+}
+
+// newTrainingOverState creates a new trainingOverState for the session of the drill, adding it
+// to the training history.
+func newTrainingOverState(game *Game, d drill.Drill, result drill.Result, prevState state) *trainingOverState {
+	s := &trainingOverState{game: game, drill: d, result: result, prevState: prevState}
+
+	history := game.menu.Training()
+	if history == nil {
+		return s
+	}
+
+	if best, ok := history.Best(d.Name); ok {
+		s.best, s.played = best.Accuracy(), true
+	}
+
+	history.Add(d.Name, result, time.Now())
+
+	if err := history.Save(); err != nil {
+		slog.Error("failed to save training history", slog.Any("error", err))
+	}
+
+	return s
+}
+
+// update goes back to the drills once the player is done reading the result.
+func (s *trainingOverState) update() error {
+	_, tapped := s.game.controls.Pointer().Tap()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || tapped {
+		s.game.resetMenu()
+		s.game.changeState(newMainMenuState(s.game))
+		s.game.menu.ShowTraining(s.drill.Name)
+	}
+
+	return nil
+}
+
+// draw draws the result of the session over the field.
+func (s *trainingOverState) draw(screen *ebiten.Image) {
+	s.prevState.draw(screen)
+
+	// overlay a dark layer to keep the result readable
+	overlay := ebiten.NewImage(screen.Bounds().Dx(), screen.Bounds().Dy())
+	overlay.Fill(theme.Active().Shade(210))
+	screen.DrawImage(overlay, nil)
+
+	titleFace, err := s.game.font.Face("ui", 40)
+	if err != nil {
+		slog.Error("failed to create training text face", slog.Any("error", err))
+		return
+	}
+
+	face, err := s.game.font.Face("ui", 20)
+	if err != nil {
+		slog.Error("failed to create training text face", slog.Any("error", err))
+		return
+	}
+
+	drawCenteredText(screen, s.drill.Name+" done", titleFace, 60, theme.Active().Text)
+
+	accuracy := s.result.Accuracy() * 100
+
+	lines := []string{
+		fmt.Sprintf("Returned %d of %d (%.0f%%)", s.result.Returned, s.result.Served, s.result.ReturnRate()*100),
+		fmt.Sprintf("On target %d of %d", s.result.OnTarget, s.result.Served),
+		fmt.Sprintf("Accuracy %.0f%%", accuracy),
+	}
+
+	switch {
+	case !s.played:
+		lines = append(lines, "First session of this drill")
+	case accuracy > s.best*100:
+		lines = append(lines, fmt.Sprintf("New best, up from %.0f%%!", s.best*100))
+	default:
+		lines = append(lines, fmt.Sprintf("Best so far %.0f%%", s.best*100))
+	}
+
+	for i, line := range lines {
+		clr := theme.Active().Text
+		if i == len(lines)-1 {
+			clr = theme.Active().Highlight
+		}
+
+		drawCenteredText(screen, line, face, 160+float64(i)*35, clr)
+	}
+
+	drawCenteredText(screen, "Press Enter or tap to continue", face, display.Height-40, theme.Active().Text)
+}
+
+func (*trainingOverState) getBall() ball.Ball {
+	panic("not implemented")
+}
+
+func (*trainingOverState) canPause() bool {
+	return false
+}
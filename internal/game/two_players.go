@@ -1,43 +1,81 @@
 package game
 
 import (
+	"fmt"
+
 	"github.com/hajimehoshi/ebiten/v2"
 
+	"github.com/gandarez/pong-multiplayer-go/internal/profile"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
-// twoPlayersState represents the state of the game when two local players are playing.
+// twoPlayersState represents the state of the game when local players share the keyboard.
 type twoPlayersState struct {
 	*baseState
-	ball    ball.Ball
-	player1 player.Player
-	player2 player.Player
-	score1  *score
-	score2  *score
 }
 
 // newTwoPlayersState creates a new twoPlayersState.
 func newTwoPlayersState(game *Game) *twoPlayersState {
-	base := newBasePlayingState(game, game.menu.Level())
+	field := game.menu.Arena()
+	base := newBasePlayingState(game, game.menu.Level(), field)
+
+	match, inTournament := game.menu.TournamentMatch()
+	fixture, inLeague := game.menu.LeagueFixture()
+
+	for i, sl := range lineup(base.rules.Format) {
+		name := fmt.Sprintf("Player %d", i+1)
+		// tournament matches and league fixtures are played in singles between their players
+		switch {
+		case inTournament:
+			name = match.Entrants[i]
+		case inLeague:
+			name = []string{fixture.Home, fixture.Away}[i]
+		}
+
+		paddle := game.menu.Handicap(i).Paddle()
+		base.addPlayer(player.NewLocal(name, sl.side, playerArea(field, base.rules.Format, sl), paddle), sl)
+	}
+
+	base.balls = []ball.Ball{ball.NewLocal(field, base.level, base.rules, game.events)}
+
+	game.effects.SetRumble(base.localGamepads)
+	game.tracker.Start(profile.Local, base.level, base.match.Elapsed, localProfiles(game, base, inTournament || inLeague)...)
+	game.recorder.Start(base.match.Elapsed)
+	game.audio.SetLocalSides(base.rules.Format.Sides()...)
+
+	if inTournament {
+		game.tournamentTracker.Start(game.menu.Tournament(), match.ID, func(side geometry.Side) string {
+			return teamName(base.players, side)
+		})
+	}
 
-	player1 := player.NewLocal("Player 1", geometry.Left, ScreenWidth, ScreenHeight, fieldBorderWidth)
-	player2 := player.NewLocal("Player 2", geometry.Right, ScreenWidth, ScreenHeight, fieldBorderWidth)
-	ball := ball.NewLocal(ScreenWidth, ScreenHeight, base.level)
-	score1 := newScore1(base.game.font)
-	score2 := newScore2(base.game.font)
+	if inLeague {
+		game.leagueTracker.Start(game.menu.League(), fixture.ID, base.lineup[0].side, base.match.Score)
+	}
 
 	return &twoPlayersState{
 		baseState: base,
-		ball:      ball,
-		player1:   player1,
-		player2:   player2,
-		score1:    score1,
-		score2:    score2,
 	}
 }
 
+// localProfiles returns the players whose profiles are updated with a local match. Each entrant
+// of a tournament match or a league fixture gets the match in their own profile, otherwise it goes
+// to the profile of the name entered in the menus, playing the first side.
+func localProfiles(game *Game, base *baseState, entrants bool) []profile.Player {
+	if !entrants {
+		return []profile.Player{{Name: game.menu.PlayerName(), Side: base.lineup[0].side}}
+	}
+
+	players := make([]profile.Player, 0, len(base.players))
+	for i, p := range base.players {
+		players = append(players, profile.Player{Name: p.Name(), Side: base.lineup[i].side})
+	}
+
+	return players
+}
+
 // update updates the game logic.
 func (s *twoPlayersState) update() error {
 	// update common elements
@@ -47,68 +85,54 @@ func (s *twoPlayersState) update() error {
 		return nil
 	}
 
-	// handle player inputs
-	input1 := player.Input{
-		Up:   ebiten.IsKeyPressed(ebiten.KeyQ),
-		Down: ebiten.IsKeyPressed(ebiten.KeyA),
-	}
-	input2 := player.Input{
-		Up:   ebiten.IsKeyPressed(ebiten.KeyUp),
-		Down: ebiten.IsKeyPressed(ebiten.KeyDown),
+	// handle player inputs from the keyboard, their gamepads and the touch screen
+	inputs := make([]player.Input, len(s.players))
+	for i, sl := range s.lineup {
+		inputs[i] = s.game.localInput(i, sl.side)
 	}
 
-	s.player1.Update(input1)
-	s.player2.Update(input2)
+	s.followPointers(inputs)
 
-	// update ball
-	s.updateBallTrail(s.ball)
-	s.ball.Update(s.player1.Bounds(), s.player2.Bounds())
-
-	// check for goals
-	if goal, side := s.ball.CheckGoal(); goal {
-		s.updateScore(side)
-		s.ball = s.ball.Reset()
-		s.player1.Reset()
-		s.player2.Reset()
+	// step the simulation in fixed ticks
+	for range s.timestep.Advance() {
+		if s.tick(s.timestep.Delta()*s.game.effects.TimeScale(), inputs) {
+			break
+		}
 	}
 
-	// check for winner
-	if s.score1.value == maxScore || s.score2.value == maxScore {
-		winner := s.player1
-		if s.score2.value == maxScore {
-			winner = s.player2
-		}
+	return nil
+}
 
-		s.game.changeState(newWinnerState(s.game, winner.Name(), s))
+// tick advances the game by a single simulation tick of dt seconds.
+// It returns true when the game is over.
+func (s *twoPlayersState) tick(dt float64, inputs []player.Input) bool {
+	s.match.Advance(dt)
+
+	for i, p := range s.players {
+		p.Update(dt, inputs[i])
 	}
 
-	return nil
+	// update balls and check for goals
+	s.updateBalls(dt)
+
+	// check for winner
+	return s.checkMatchOver()
 }
 
 // draw draws the game elements.
 func (s *twoPlayersState) draw(screen *ebiten.Image) {
-	// draw common elements
-	s.baseState.draw(screen)
-
-	// draw players, ball, and scores
-	drawPlayer(s.player1.Position(), s.player1.BouncerWidth(), s.player1.BouncerHeight(), screen)
-	drawPlayer(s.player2.Position(), s.player2.BouncerWidth(), s.player2.BouncerHeight(), screen)
-	drawBall(screen, s.ball.Position(), s.ball.Width(), s.ballTrail)
-	s.score1.draw(screen)
-	s.score2.draw(screen)
-}
+	// draw field, players and balls
+	s.drawWorld(screen)
 
-// updateScore updates the score based on which side the goal was made.
-func (s *twoPlayersState) updateScore(side geometry.Side) {
-	if side == geometry.Left {
-		s.score2.value++
-	} else {
-		s.score1.value++
-	}
+	// draw scores
+	s.drawScores(screen)
+
+	// draw common elements
+	s.drawOverlay(screen)
 }
 
 func (s *twoPlayersState) getBall() ball.Ball {
-	return s.ball
+	return s.balls[0]
 }
 
 func (*twoPlayersState) canPause() bool {
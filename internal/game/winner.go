@@ -3,38 +3,97 @@ package game
 import (
 	"context"
 	"fmt"
+	"image/color"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 
-	"github.com/gandarez/pong-multiplayer-go/internal/menu"
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
+	"github.com/gandarez/pong-multiplayer-go/internal/summary"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
 	"github.com/gandarez/pong-multiplayer-go/internal/ui"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
+const (
+	// timelineX, timelineY and timelineHeight are where the score timeline is drawn, as wide as the screen
+	// minus timelineX on each side.
+	timelineX      = 80.0
+	timelineY      = 90.0
+	timelineHeight = 110.0
+	// summaryStartY and summaryLineSpacing are where the match statistics are drawn.
+	summaryStartY      = 235.0
+	summaryLineSpacing = 20.0
+	// heatmapX and heatmapY are where the shot directions of the first side are drawn,
+	// each cell being heatmapCellWidth wide and heatmapCellHeight high.
+	heatmapX          = 220.0
+	heatmapY          = 340.0
+	heatmapCellWidth  = 60.0
+	heatmapCellHeight = 18.0
+)
+
+// shotLabels name the shot directions of the heatmap, from the top or left end of the field.
+var shotLabels = [summary.ShotBins]string{"Wide", "Angled", "Straight", "Angled", "Wide"} // nolint:gochecknoglobals
+
 // winnerState represents the state when a player has won the game.
+// It sums up the match: the score timeline, the rallies, the ball speed and the shots of each side.
 type winnerState struct {
-	game      *Game
-	winner    string
+	game   *Game
+	winner string
+	// sides are the sides of the match and teams the names of their players.
+	sides   []geometry.Side
+	teams   map[geometry.Side]string
+	summary *summary.Summary
+	// ratings shows the skill rating of each player of network matches and how much it changed,
+	// empty when the server doesn't rate them.
+	ratings   string
 	prevState state
 }
 
-// newWinnerState creates a new winnerState.
-func newWinnerState(game *Game, winner string, prevState state) *winnerState {
+// newWinnerState creates a new winnerState for the match played in base, won by the winner side.
+func newWinnerState(game *Game, base *baseState, winner geometry.Side, prevState state) *winnerState {
+	sides := base.rules.Format.Sides()
+
+	teams := make(map[geometry.Side]string, len(sides))
+	for _, side := range sides {
+		teams[side] = teamName(base.players, side)
+	}
+
+	ratings := make([]string, 0, len(base.ratings))
+
+	for _, p := range base.players {
+		if r, ok := base.ratings[p.Name()]; ok {
+			ratings = append(ratings, fmt.Sprintf("%s %.f (%+.f)", p.Name(), r.value, r.change))
+		}
+	}
+
 	return &winnerState{
 		game:      game,
-		winner:    winner,
+		winner:    teams[winner],
+		sides:     sides,
+		teams:     teams,
+		summary:   game.recorder.Summary(),
+		ratings:   strings.Join(ratings, "   "),
 		prevState: prevState,
 	}
 }
 
 // update updates the winner state.
 func (s *winnerState) update() error {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-		s.game.menu = menu.New(s.game.font, ScreenWidth, ScreenHeight)
+	_, tapped := s.game.controls.Pointer().Tap()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || tapped {
+		// after a tournament match or a league fixture, go back to its bracket or standings
+		_, inTournament := s.game.menu.TournamentMatch()
+		_, inLeague := s.game.menu.LeagueFixture()
+
+		s.game.resetMenu()
 		s.game.networkClient = nil
 
 		ctx, cancel := context.WithCancel(context.Background())
@@ -42,6 +101,13 @@ func (s *winnerState) update() error {
 		s.game.cancel = cancel
 
 		s.game.changeState(newMainMenuState(s.game))
+
+		switch {
+		case inTournament:
+			s.game.menu.ShowTournament()
+		case inLeague:
+			s.game.menu.ShowLeague()
+		}
 	}
 
 	return nil
@@ -52,12 +118,12 @@ func (s *winnerState) draw(screen *ebiten.Image) {
 	// draw previous state
 	s.prevState.draw(screen)
 
-	// overlay a semi-transparent layer
-	overlay := ebiten.NewImage(ScreenWidth, ScreenHeight)
-	overlay.Fill(ui.TransparentBlack)
+	// overlay a dark layer to keep the summary readable
+	overlay := ebiten.NewImage(screen.Bounds().Dx(), screen.Bounds().Dy())
+	overlay.Fill(theme.Active().Shade(210))
 	screen.DrawImage(overlay, nil)
 
-	textFaceSmall, err := s.game.font.Face("ui", 30)
+	textFaceSmall, err := s.game.font.Face("ui", 20)
 	if err != nil {
 		slog.Error("failed to create winner text face", slog.Any("error", err))
 		panic(err)
@@ -68,19 +134,13 @@ func (s *winnerState) draw(screen *ebiten.Image) {
 		panic(err)
 	}
 
-	instructionText := "Press Enter to play again"
-	textWidth, _ := text.Measure(instructionText, textFaceSmall, 1)
-
-	uiText := ui.Text{
-		Value:    instructionText,
-		FontFace: textFaceSmall,
-		Position: geometry.Vector{
-			X: (ScreenWidth - textWidth) / 2,
-			Y: 300,
-		},
-		Color: ui.DefaultColor,
+	if s.summary != nil {
+		if err := s.drawSummary(screen); err != nil {
+			slog.Error("failed to draw match summary", slog.Any("error", err))
+		}
 	}
-	uiText.Draw(screen)
+
+	drawCenteredText(screen, "Press Enter or tap to play again", textFaceSmall, display.Height-40, theme.Active().Text)
 }
 
 func (s *winnerState) drawWinner(screen *ebiten.Image) error {
@@ -89,23 +149,164 @@ func (s *winnerState) drawWinner(screen *ebiten.Image) error {
 		return fmt.Errorf("failed to create winner text face: %w", err)
 	}
 
-	winnerText := fmt.Sprintf("%s WON", s.winner)
-	textWidth, _ := text.Measure(winnerText, textFaceLarge, 1)
+	drawCenteredText(screen, fmt.Sprintf("%s WON", s.winner), textFaceLarge, 14, theme.Active().Text)
 
-	uiText := ui.Text{
-		Value:    winnerText,
-		FontFace: textFaceLarge,
-		Position: geometry.Vector{
-			X: (ScreenWidth - textWidth) / 2,
-			Y: 200,
-		},
-		Color: ui.DefaultColor,
+	if s.ratings == "" {
+		return nil
 	}
-	uiText.Draw(screen)
+
+	face, err := s.game.font.Face("ui", 14)
+	if err != nil {
+		return fmt.Errorf("failed to create ratings text face: %w", err)
+	}
+
+	drawCenteredText(screen, "Rating: "+s.ratings, face, 62, theme.Active().Highlight)
 
 	return nil
 }
 
+// drawSummary draws the score timeline, the statistics and the shot heatmap of the match.
+func (s *winnerState) drawSummary(screen *ebiten.Image) error {
+	face, err := s.game.font.Face("ui", 14)
+	if err != nil {
+		return fmt.Errorf("failed to create summary text face: %w", err)
+	}
+
+	s.drawTimeline(screen, face)
+
+	y := summaryStartY
+
+	for _, line := range s.statsLines() {
+		drawCenteredText(screen, line, face, y, theme.Active().Text)
+
+		y += summaryLineSpacing
+	}
+
+	s.drawHeatmap(screen, face)
+
+	return nil
+}
+
+// drawTimeline draws the score of each side after every point, with a legend naming the sides.
+func (s *winnerState) drawTimeline(screen *ebiten.Image, face text.Face) {
+	points := s.summary.Points
+	timelineWidth := s.game.screenWidth() - 2*timelineX
+
+	top := 0
+	for _, side := range s.sides {
+		top = max(top, s.summary.Score(side))
+	}
+
+	// axes
+	vector.StrokeLine(screen, timelineX, timelineY+timelineHeight, float32(timelineX+timelineWidth),
+		timelineY+timelineHeight, 1, theme.Active().Text, false)
+	vector.StrokeLine(screen, timelineX, timelineY, timelineX, timelineY+timelineHeight, 1, theme.Active().Text, false)
+
+	drawText(screen, fmt.Sprint(top), face, timelineX-20, timelineY-6, theme.Active().Text)
+	drawText(screen, "0", face, timelineX-20, timelineY+timelineHeight-12, theme.Active().Text)
+
+	if len(points) > 0 && top > 0 {
+		step := timelineWidth / float64(len(points))
+		scale := timelineHeight / float64(top)
+
+		for i, side := range s.sides {
+			clr := theme.Active().Player(i)
+			x, y := float32(timelineX), float32(timelineY+timelineHeight)
+
+			for j, p := range points {
+				nx := float32(timelineX + float64(j+1)*step)
+				ny := float32(timelineY + timelineHeight - float64(p.Scores[side])*scale)
+
+				vector.StrokeLine(screen, x, y, nx, ny, 2, clr, true)
+
+				x, y = nx, ny
+			}
+		}
+	}
+
+	// legend
+	legend := timelineX
+	for i, side := range s.sides {
+		name := fmt.Sprintf("%s %d", s.teams[side], s.summary.Score(side))
+		drawText(screen, name, face, legend, timelineY+timelineHeight+8, theme.Active().Player(i))
+
+		width, _ := text.Measure(name, face, 1)
+		legend += width + 30
+	}
+}
+
+// statsLines returns the statistics of the match as displayed to players.
+func (s *winnerState) statsLines() []string {
+	sum := s.summary
+
+	hits := make([]string, 0, len(s.sides))
+	for _, side := range s.sides {
+		hits = append(hits, fmt.Sprintf("%s %d", s.teams[side], sum.Hits[side]))
+	}
+
+	return []string{
+		fmt.Sprintf("Points: %d   Longest rally: %d hits", len(sum.Points), sum.LongestRally),
+		fmt.Sprintf("Ball speed: %.f average, %.f max", sum.AverageSpeed(), sum.MaxSpeed),
+		"Hits: " + strings.Join(hits, "   "),
+	}
+}
+
+// drawHeatmap draws how often each side shot the ball in each direction, brighter cells being more frequent.
+func (s *winnerState) drawHeatmap(screen *ebiten.Image, face text.Face) {
+	drawCenteredText(screen, "Shot directions", face, heatmapY-40, theme.Active().Text)
+
+	for i, label := range shotLabels {
+		width, _ := text.Measure(label, face, 1)
+		x := heatmapX + float64(i)*heatmapCellWidth + (heatmapCellWidth-width)/2
+		drawText(screen, label, face, x, heatmapY-20, theme.Active().Text)
+	}
+
+	most := 0
+	for _, shots := range s.summary.Shots {
+		most = max(most, slices.Max(shots[:]))
+	}
+
+	for row, side := range s.sides {
+		y := heatmapY + float64(row)*(heatmapCellHeight+4)
+		clr := theme.Active().Player(row)
+
+		name := s.teams[side]
+		width, _ := text.Measure(name, face, 1)
+		drawText(screen, name, face, heatmapX-10-width, y+2, clr)
+
+		shots := s.summary.Shots[side]
+
+		for bin := range summary.ShotBins {
+			x := heatmapX + float64(bin)*heatmapCellWidth
+
+			if shots != nil && most > 0 && shots[bin] > 0 {
+				alpha := 0.15 + 0.85*float64(shots[bin])/float64(most)
+				vector.DrawFilledRect(screen, float32(x), float32(y), heatmapCellWidth-2, heatmapCellHeight,
+					theme.Fade(clr, alpha), false)
+			}
+
+			vector.StrokeRect(screen, float32(x), float32(y), heatmapCellWidth-2, heatmapCellHeight, 1, clr, false)
+		}
+	}
+}
+
+// drawCenteredText draws a line of text centered horizontally on the screen at y.
+func drawCenteredText(screen *ebiten.Image, value string, face text.Face, y float64, clr color.RGBA) {
+	width, _ := text.Measure(value, face, 1)
+	drawText(screen, value, face, (float64(screen.Bounds().Dx())-width)/2, y, clr)
+}
+
+// drawText draws a line of text with its top left corner at x, y.
+func drawText(screen *ebiten.Image, value string, face text.Face, x, y float64, clr color.RGBA) {
+	uiText := ui.Text{
+		Value:    value,
+		FontFace: face,
+		Position: geometry.Vector{X: x, Y: y},
+		Color:    clr,
+	}
+	uiText.Draw(screen)
+}
+
 func (*winnerState) getBall() ball.Ball {
 	panic("not implemented")
 }
@@ -0,0 +1,82 @@
+// Package identity keeps the keypair proving who the player is in online matches,
+// created the first time it's needed and kept on this device.
+package identity
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+)
+
+// fileName is the name of the file keeping the keypair.
+const fileName = "identity.json"
+
+// Key is the keypair of the player.
+type Key struct {
+	private ed25519.PrivateKey
+}
+
+// file is the keypair as saved on this device.
+type file struct {
+	PrivateKey ed25519.PrivateKey `json:"private_key"`
+}
+
+// Load loads the keypair of the player, creating and saving a new one the first time.
+func Load() (*Key, error) {
+	data, err := storage.Read(fileName)
+	if errors.Is(err, storage.ErrNotFound) {
+		return create()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load identity: %w", err)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse identity: %w", err)
+	}
+
+	if len(f.PrivateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("failed to parse identity: bad private key")
+	}
+
+	return &Key{private: f.PrivateKey}, nil
+}
+
+// create creates a new keypair and saves it.
+func create() (*Key, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate identity: %w", err)
+	}
+
+	data, err := json.Marshal(file{PrivateKey: private})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode identity: %w", err)
+	}
+
+	if err := storage.Write(fileName, data); err != nil {
+		return nil, fmt.Errorf("failed to save identity: %w", err)
+	}
+
+	return &Key{private: private}, nil
+}
+
+// Identity signs the name of the player joining the server, answering the challenge with the nonce.
+func (k *Key) Identity(name, server, nonce string) *account.Identity {
+	return account.Sign(k.private, name, server, nonce, time.Now())
+}
+
+// Fingerprint returns a short digest of the public key, shown to players to tell accounts apart.
+func (k *Key) Fingerprint() string {
+	public, _ := k.private.Public().(ed25519.PublicKey)
+
+	return account.Fingerprint(public)
+}
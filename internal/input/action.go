@@ -0,0 +1,249 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Action is something a player can do in a match, bound to a key and optionally to a gamepad button.
+type Action int
+
+const (
+	// MoveUp moves the paddle up when playing alone on this device.
+	MoveUp Action = iota
+	// MoveDown moves the paddle down when playing alone on this device.
+	MoveDown
+	// MoveLeft moves the paddle left when playing alone on this device and defending the top or bottom side.
+	MoveLeft
+	// MoveRight moves the paddle right when playing alone on this device and defending the top or bottom side.
+	MoveRight
+	// P1Up moves the paddle of the first local player up, or left when defending the top or bottom side.
+	P1Up
+	// P1Down moves the paddle of the first local player down, or right when defending the top or bottom side.
+	P1Down
+	// P2Up moves the paddle of the second local player up, or left when defending the top or bottom side.
+	P2Up
+	// P2Down moves the paddle of the second local player down, or right when defending the top or bottom side.
+	P2Down
+	// P3Up moves the paddle of the third local player up, or left when defending the top or bottom side.
+	P3Up
+	// P3Down moves the paddle of the third local player down, or right when defending the top or bottom side.
+	P3Down
+	// P4Up moves the paddle of the fourth local player up, or left when defending the top or bottom side.
+	P4Up
+	// P4Down moves the paddle of the fourth local player down, or right when defending the top or bottom side.
+	P4Down
+	// Pause pauses and resumes the match.
+	Pause
+	// ToggleMetrics shows or hides the performance metrics.
+	ToggleMetrics
+	// ShowBracket shows the bracket of the tournament while held, when watching a tournament match.
+	ShowBracket
+)
+
+// MaxLocalPlayers is the number of local players that have their own actions.
+const MaxLocalPlayers = 4
+
+// actionIDs are the names of the actions in the controls file.
+// nolint:gochecknoglobals
+var actionIDs = map[Action]string{
+	MoveUp:        "move_up",
+	MoveDown:      "move_down",
+	MoveLeft:      "move_left",
+	MoveRight:     "move_right",
+	P1Up:          "p1_up",
+	P1Down:        "p1_down",
+	P2Up:          "p2_up",
+	P2Down:        "p2_down",
+	P3Up:          "p3_up",
+	P3Down:        "p3_down",
+	P4Up:          "p4_up",
+	P4Down:        "p4_down",
+	Pause:         "pause",
+	ToggleMetrics: "toggle_metrics",
+	ShowBracket:   "show_bracket",
+}
+
+// Actions returns all the actions in the order they are displayed.
+func Actions() []Action {
+	actions := make([]Action, 0, len(actionIDs))
+	for a := MoveUp; a <= ShowBracket; a++ {
+		actions = append(actions, a)
+	}
+
+	return actions
+}
+
+// PlayerUp returns the action moving the paddle of the local player with the given index up.
+func PlayerUp(player int) Action {
+	return P1Up + Action(2*player)
+}
+
+// PlayerDown returns the action moving the paddle of the local player with the given index down.
+func PlayerDown(player int) Action {
+	return P1Down + Action(2*player)
+}
+
+// Player returns the index of the local player the action belongs to.
+// It returns false for actions shared by everyone on this device.
+func (a Action) Player() (int, bool) {
+	if a < P1Up || a > P4Down {
+		return 0, false
+	}
+
+	return int(a-P1Up) / 2, true
+}
+
+// solo reports whether the action is only used when playing alone on this device.
+func (a Action) solo() bool {
+	return a >= MoveUp && a <= MoveRight
+}
+
+// conflictsWith reports whether both actions can be used in the same match,
+// so they can't share a key or button.
+func (a Action) conflictsWith(other Action) bool {
+	if a == other {
+		return false
+	}
+
+	_, local := a.Player()
+	_, otherLocal := other.Player()
+
+	return !(a.solo() && otherLocal || local && other.solo())
+}
+
+// String returns the name of the action as displayed to players.
+func (a Action) String() string {
+	if player, ok := a.Player(); ok {
+		if a == PlayerUp(player) {
+			return fmt.Sprintf("P%d Up/Left", player+1)
+		}
+
+		return fmt.Sprintf("P%d Down/Right", player+1)
+	}
+
+	switch a {
+	case MoveUp:
+		return "Move Up"
+	case MoveDown:
+		return "Move Down"
+	case MoveLeft:
+		return "Move Left"
+	case MoveRight:
+		return "Move Right"
+	case Pause:
+		return "Pause"
+	case ToggleMetrics:
+		return "Toggle Metrics"
+	case ShowBracket:
+		return "Show Bracket"
+	default:
+		return "Unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a Action) MarshalText() ([]byte, error) {
+	id, ok := actionIDs[a]
+	if !ok {
+		return nil, fmt.Errorf("invalid action: %d", a)
+	}
+
+	return []byte(id), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *Action) UnmarshalText(text []byte) error {
+	for action, id := range actionIDs {
+		if id == string(text) {
+			*a = action
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid action: %q", text)
+}
+
+// NoButton is used when an action isn't bound to a gamepad button.
+const NoButton ebiten.StandardGamepadButton = -1
+
+// Binding is the input bound to an action.
+type Binding struct {
+	Key ebiten.Key `json:"key"`
+	// Button is the gamepad button bound to the action, or NoButton.
+	// The buttons of local players only work on the gamepad assigned to them.
+	Button ebiten.StandardGamepadButton `json:"button"`
+}
+
+// DefaultBindings returns the default binding of every action.
+func DefaultBindings() map[Action]Binding {
+	return map[Action]Binding{
+		MoveUp:        {Key: ebiten.KeyArrowUp, Button: NoButton},
+		MoveDown:      {Key: ebiten.KeyArrowDown, Button: NoButton},
+		MoveLeft:      {Key: ebiten.KeyArrowLeft, Button: NoButton},
+		MoveRight:     {Key: ebiten.KeyArrowRight, Button: NoButton},
+		P1Up:          {Key: ebiten.KeyQ, Button: NoButton},
+		P1Down:        {Key: ebiten.KeyA, Button: NoButton},
+		P2Up:          {Key: ebiten.KeyArrowUp, Button: NoButton},
+		P2Down:        {Key: ebiten.KeyArrowDown, Button: NoButton},
+		P3Up:          {Key: ebiten.KeyE, Button: NoButton},
+		P3Down:        {Key: ebiten.KeyD, Button: NoButton},
+		P4Up:          {Key: ebiten.KeyO, Button: NoButton},
+		P4Down:        {Key: ebiten.KeyL, Button: NoButton},
+		Pause:         {Key: ebiten.KeyEscape, Button: ebiten.StandardGamepadButtonCenterRight},
+		ToggleMetrics: {Key: ebiten.KeyTab, Button: ebiten.StandardGamepadButtonCenterLeft},
+		ShowBracket:   {Key: ebiten.KeyB, Button: ebiten.StandardGamepadButtonRightTop},
+	}
+}
+
+// String returns the key and button of the binding as displayed to players.
+func (b Binding) String() string {
+	if b.Button == NoButton {
+		return KeyName(b.Key)
+	}
+
+	return KeyName(b.Key) + " / " + ButtonName(b.Button)
+}
+
+// KeyName returns the name of a key as displayed to players.
+func KeyName(key ebiten.Key) string {
+	name := key.String()
+	if direction, ok := strings.CutPrefix(name, "Arrow"); ok {
+		return direction + " Arrow"
+	}
+
+	return name
+}
+
+// buttonNames are the names of the standard gamepad buttons, using the Xbox layout.
+// nolint:gochecknoglobals
+var buttonNames = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "A",
+	ebiten.StandardGamepadButtonRightRight:       "B",
+	ebiten.StandardGamepadButtonRightLeft:        "X",
+	ebiten.StandardGamepadButtonRightTop:         "Y",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "LB",
+	ebiten.StandardGamepadButtonFrontTopRight:    "RB",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "LT",
+	ebiten.StandardGamepadButtonFrontBottomRight: "RT",
+	ebiten.StandardGamepadButtonCenterLeft:       "Back",
+	ebiten.StandardGamepadButtonCenterRight:      "Start",
+	ebiten.StandardGamepadButtonLeftStick:        "L3",
+	ebiten.StandardGamepadButtonRightStick:       "R3",
+	ebiten.StandardGamepadButtonLeftTop:          "D-pad Up",
+	ebiten.StandardGamepadButtonLeftBottom:       "D-pad Down",
+	ebiten.StandardGamepadButtonLeftLeft:         "D-pad Left",
+	ebiten.StandardGamepadButtonLeftRight:        "D-pad Right",
+	ebiten.StandardGamepadButtonCenterCenter:     "Home",
+}
+
+// ButtonName returns the name of a gamepad button as displayed to players.
+func ButtonName(button ebiten.StandardGamepadButton) string {
+	if name, ok := buttonNames[button]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Button %d", button)
+}
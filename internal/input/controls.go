@@ -0,0 +1,148 @@
+package input
+
+import (
+	"errors"
+	"maps"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// ErrConflict is returned when binding an input already bound to another action.
+var ErrConflict = errors.New("input already bound")
+
+// Controls maps actions to the keys and gamepad buttons bound to them.
+// It also gives access to the gamepads and to the pointer.
+type Controls struct {
+	gamepads *Gamepads
+	pointer  *Pointer
+	bindings map[Action]Binding
+}
+
+// NewControls creates controls with the default bindings.
+func NewControls(gamepads *Gamepads) *Controls {
+	return &Controls{
+		gamepads: gamepads,
+		pointer:  NewPointer(),
+		bindings: DefaultBindings(),
+	}
+}
+
+// Gamepads returns the gamepads used by the controls.
+func (c *Controls) Gamepads() *Gamepads {
+	return c.gamepads
+}
+
+// Pointer returns the touches and the mouse.
+func (c *Controls) Pointer() *Pointer {
+	return c.pointer
+}
+
+// Update detects the gamepads that were just connected or disconnected and
+// whether the touch screen or the mouse is being used.
+func (c *Controls) Update() {
+	c.gamepads.Update()
+	c.pointer.Update()
+}
+
+// Binding returns the binding of the action.
+func (c *Controls) Binding(action Action) Binding {
+	return c.bindings[action]
+}
+
+// Bind binds the action to b. It returns ErrConflict, along with the other action,
+// when the key or button of b is bound to an action usable in the same match.
+func (c *Controls) Bind(action Action, b Binding) (Action, error) {
+	for _, other := range Actions() {
+		if !action.conflictsWith(other) {
+			continue
+		}
+
+		bound := c.bindings[other]
+		if bound.Key == b.Key || (b.Button != NoButton && bound.Button == b.Button) {
+			return other, ErrConflict
+		}
+	}
+
+	c.bindings[action] = b
+
+	return action, nil
+}
+
+// Reset restores the default bindings.
+func (c *Controls) Reset() {
+	c.bindings = DefaultBindings()
+}
+
+// Bindings returns a copy of the binding of every action.
+func (c *Controls) Bindings() map[Action]Binding {
+	return maps.Clone(c.bindings)
+}
+
+// SetBindings replaces the bindings of the given actions, the other ones keep their binding.
+func (c *Controls) SetBindings(bindings map[Action]Binding) {
+	maps.Copy(c.bindings, bindings)
+}
+
+// Pressed reports whether the key or button bound to the action is held down.
+func (c *Controls) Pressed(action Action) bool {
+	b := c.bindings[action]
+	if ebiten.IsKeyPressed(b.Key) {
+		return true
+	}
+
+	if b.Button == NoButton {
+		return false
+	}
+
+	for _, id := range c.buttonGamepads(action) {
+		if ebiten.IsStandardGamepadButtonPressed(id, b.Button) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// JustPressed reports whether the key or button bound to the action was pressed in this tick.
+func (c *Controls) JustPressed(action Action) bool {
+	b := c.bindings[action]
+	if inpututil.IsKeyJustPressed(b.Key) {
+		return true
+	}
+
+	if b.Button == NoButton {
+		return false
+	}
+
+	for _, id := range c.buttonGamepads(action) {
+		if inpututil.IsStandardGamepadButtonJustPressed(id, b.Button) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buttonGamepads returns the gamepads whose buttons trigger the action: the gamepad assigned
+// to the local player the action belongs to, the one used when playing alone for the solo
+// actions, or any gamepad otherwise.
+func (c *Controls) buttonGamepads(action Action) []ebiten.GamepadID {
+	if player, ok := action.Player(); ok {
+		if id, ok := c.gamepads.Assigned(player); ok {
+			return []ebiten.GamepadID{id}
+		}
+
+		return nil
+	}
+
+	if action.solo() {
+		if id, ok := c.gamepads.First(); ok {
+			return []ebiten.GamepadID{id}
+		}
+
+		return nil
+	}
+
+	return c.gamepads.Connected()
+}
@@ -0,0 +1,159 @@
+package input
+
+import (
+	"log/slog"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// deadZone is how far the stick must be pushed before it moves the paddle.
+const deadZone = 0.15
+
+// Gamepads keeps track of the connected gamepads and the player each one is assigned to.
+type Gamepads struct {
+	connected []ebiten.GamepadID
+	// assigned maps the index of a local player to its gamepad.
+	assigned map[int]ebiten.GamepadID
+}
+
+// NewGamepads creates a new Gamepads with the gamepads already connected.
+func NewGamepads() *Gamepads {
+	return &Gamepads{
+		connected: ebiten.AppendGamepadIDs(nil),
+		assigned:  make(map[int]ebiten.GamepadID),
+	}
+}
+
+// Update detects the gamepads that were just connected or disconnected.
+// Disconnected gamepads are unassigned from their players.
+func (g *Gamepads) Update() {
+	for _, id := range inpututil.AppendJustConnectedGamepadIDs(nil) {
+		slog.Info("gamepad connected", slog.Int("id", int(id)), slog.String("name", ebiten.GamepadName(id)))
+
+		g.connected = append(g.connected, id)
+	}
+
+	g.connected = slices.DeleteFunc(g.connected, func(id ebiten.GamepadID) bool {
+		if !inpututil.IsGamepadJustDisconnected(id) {
+			return false
+		}
+
+		slog.Info("gamepad disconnected", slog.Int("id", int(id)))
+
+		if player, ok := g.PlayerOf(id); ok {
+			delete(g.assigned, player)
+		}
+
+		return true
+	})
+}
+
+// Connected returns the connected gamepads in the order they were connected.
+func (g *Gamepads) Connected() []ebiten.GamepadID {
+	return g.connected
+}
+
+// Assign assigns the gamepad to the local player with the given index,
+// taking it away from any other player.
+func (g *Gamepads) Assign(player int, id ebiten.GamepadID) {
+	if previous, ok := g.PlayerOf(id); ok {
+		delete(g.assigned, previous)
+	}
+
+	g.assigned[player] = id
+}
+
+// Unassign removes the gamepad assigned to the local player with the given index.
+func (g *Gamepads) Unassign(player int) {
+	delete(g.assigned, player)
+}
+
+// Assigned returns the gamepad assigned to the local player with the given index.
+func (g *Gamepads) Assigned(player int) (ebiten.GamepadID, bool) {
+	id, ok := g.assigned[player]
+
+	return id, ok
+}
+
+// PlayerOf returns the index of the local player the gamepad is assigned to.
+func (g *Gamepads) PlayerOf(id ebiten.GamepadID) (int, bool) {
+	for player, assigned := range g.assigned {
+		if assigned == id {
+			return player, true
+		}
+	}
+
+	return 0, false
+}
+
+// First returns the gamepad assigned to the first player or, if there's none,
+// the first connected gamepad. It's used when a single player is playing.
+func (g *Gamepads) First() (ebiten.GamepadID, bool) {
+	if id, ok := g.Assigned(0); ok {
+		return id, true
+	}
+
+	if len(g.connected) == 0 {
+		return 0, false
+	}
+
+	return g.connected[0], true
+}
+
+// JustPressed returns the first connected gamepad whose button was just pressed.
+func (g *Gamepads) JustPressed(button ebiten.StandardGamepadButton) (ebiten.GamepadID, bool) {
+	for _, id := range g.connected {
+		if inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// Axis returns the position of the left stick of the gamepad between -1 and 1, along the
+// horizontal axis if horizontal is true or the vertical one otherwise. Negative values
+// point up or left. The directional pad overrides the stick and values within the dead zone are 0.
+// Gamepads without the standard layout fall back to their first two axes.
+func Axis(id ebiten.GamepadID, horizontal bool) float64 {
+	if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+		axis := ebiten.GamepadAxisType(1)
+		if horizontal {
+			axis = 0
+		}
+
+		return applyDeadZone(ebiten.GamepadAxisValue(id, axis))
+	}
+
+	backward, forward := ebiten.StandardGamepadButtonLeftTop, ebiten.StandardGamepadButtonLeftBottom
+	axis := ebiten.StandardGamepadAxisLeftStickVertical
+
+	if horizontal {
+		backward, forward = ebiten.StandardGamepadButtonLeftLeft, ebiten.StandardGamepadButtonLeftRight
+		axis = ebiten.StandardGamepadAxisLeftStickHorizontal
+	}
+
+	switch {
+	case ebiten.IsStandardGamepadButtonPressed(id, backward):
+		return -1
+	case ebiten.IsStandardGamepadButtonPressed(id, forward):
+		return 1
+	}
+
+	return applyDeadZone(ebiten.StandardGamepadAxisValue(id, axis))
+}
+
+// applyDeadZone returns 0 for values within the dead zone and rescales the others,
+// so the paddle speeds up smoothly from the edge of the dead zone.
+func applyDeadZone(value float64) float64 {
+	if math.Abs(value) < deadZone {
+		return 0
+	}
+
+	value = math.Copysign((math.Abs(value)-deadZone)/(1-deadZone), value)
+
+	return math.Max(-1, math.Min(1, value))
+}
@@ -0,0 +1,108 @@
+package input
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Pointer keeps track of the touches and the mouse, used to play and navigate the menus
+// without a keyboard. Positions are in screen coordinates.
+type Pointer struct {
+	// toScreen converts positions in the window into screen coordinates.
+	toScreen func(x, y int) geometry.Vector
+	cursor   geometry.Vector
+	// mouse is true from the moment the mouse moves until a key or gamepad button is pressed.
+	mouse bool
+	// touched is true once the screen has been touched.
+	touched bool
+}
+
+// NewPointer creates a new Pointer.
+func NewPointer() *Pointer {
+	return &Pointer{
+		toScreen: func(x, y int) geometry.Vector {
+			return geometry.Vector{X: float64(x), Y: float64(y)}
+		},
+	}
+}
+
+// SetScreen sets how positions in the window are converted into screen coordinates,
+// when the screen is scaled to the window.
+func (p *Pointer) SetScreen(toScreen func(x, y int) geometry.Vector) {
+	p.toScreen = toScreen
+}
+
+// Update detects whether the mouse or the touch screen is being used.
+func (p *Pointer) Update() {
+	cursor := p.toScreen(ebiten.CursorPosition())
+
+	if cursor != p.cursor || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		p.mouse = true
+	}
+
+	p.cursor = cursor
+
+	if len(inpututil.AppendJustPressedKeys(nil)) > 0 || anyGamepadButtonJustPressed() {
+		p.mouse = false
+	}
+
+	if len(inpututil.AppendJustPressedTouchIDs(nil)) > 0 {
+		p.touched = true
+		p.mouse = false
+	}
+}
+
+// Active returns true if the touch screen or the mouse is being used, so on-screen
+// buttons should be shown.
+func (p *Pointer) Active() bool {
+	return p.touched || p.mouse
+}
+
+// Touches returns the positions of the fingers on the screen.
+func (p *Pointer) Touches() []geometry.Vector {
+	ids := ebiten.AppendTouchIDs(nil)
+	positions := make([]geometry.Vector, 0, len(ids))
+
+	for _, id := range ids {
+		positions = append(positions, p.toScreen(ebiten.TouchPosition(id)))
+	}
+
+	return positions
+}
+
+// Cursor returns the position of the mouse cursor while the mouse is being used.
+func (p *Pointer) Cursor() (geometry.Vector, bool) {
+	return p.cursor, p.mouse
+}
+
+// Dragging returns the position of the mouse cursor while its left button is held down.
+func (p *Pointer) Dragging() (geometry.Vector, bool) {
+	return p.cursor, p.mouse && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+}
+
+// Tap returns the position of the finger that just touched the screen or of the mouse cursor
+// when its left button was just pressed.
+func (p *Pointer) Tap() (geometry.Vector, bool) {
+	if ids := inpututil.AppendJustPressedTouchIDs(nil); len(ids) > 0 {
+		return p.toScreen(ebiten.TouchPosition(ids[0])), true
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return p.cursor, true
+	}
+
+	return geometry.Vector{}, false
+}
+
+// anyGamepadButtonJustPressed returns true if a button of any gamepad was just pressed.
+func anyGamepadButtonJustPressed() bool {
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if len(inpututil.AppendJustPressedGamepadButtons(id, nil)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+// Package league keeps the local league run on this device and records the results of its fixtures.
+package league
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/pkg/roundrobin"
+)
+
+// fileName is the name of the file keeping the league.
+const fileName = "league.json"
+
+// Version is the version of the league schema.
+const Version = 1
+
+// League is a league played on this device, its players sharing the keyboard.
+type League struct {
+	Version  int                `json:"version"`
+	Schedule *roundrobin.League `json:"schedule"`
+}
+
+// New creates a league between the players.
+func New(players []string) (*League, error) {
+	schedule, err := roundrobin.New(players)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule league: %w", err)
+	}
+
+	return &League{Version: Version, Schedule: schedule}, nil
+}
+
+// Load loads the saved league, nil when none is being played.
+func Load() (*League, error) {
+	data, err := storage.Read(fileName)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load league: %w", err)
+	}
+
+	var l *League
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("failed to parse league: %w", err)
+	}
+
+	if l == nil || l.Schedule == nil {
+		return nil, nil
+	}
+
+	return l, nil
+}
+
+// Save saves the league, so it can be resumed after the game is closed.
+func (l *League) Save() error {
+	l.Version = Version
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode league: %w", err)
+	}
+
+	if err := storage.Write(fileName, data); err != nil {
+		return fmt.Errorf("failed to save league: %w", err)
+	}
+
+	return nil
+}
+
+// Clear forgets the saved league.
+func Clear() error {
+	if err := storage.Write(fileName, []byte("null")); err != nil {
+		return fmt.Errorf("failed to clear league: %w", err)
+	}
+
+	return nil
+}
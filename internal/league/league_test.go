@@ -0,0 +1,78 @@
+package league
+
+import (
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/storage/storagetest"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+func TestTracker_Record(t *testing.T) {
+	storagetest.UseTempDir(t)
+
+	l, err := New([]string{"Ada", "Bob", "Cyd"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	tracker := NewTracker()
+	bus := event.NewBus()
+	tracker.Subscribe(bus)
+
+	scores := map[geometry.Side]int{geometry.Left: 2, geometry.Right: 5}
+	score := func(side geometry.Side) int { return scores[side] }
+
+	// the home player defends the right side
+	tracker.Start(l, 0, geometry.Right, score)
+	bus.Publish(event.MatchOver{Winner: geometry.Right})
+
+	f := l.Schedule.Fixtures[0]
+	if !f.Played || f.HomeScore != 5 || f.AwayScore != 2 {
+		t.Errorf("fixture = %+v, want played 5-2", f)
+	}
+
+	// fixtures that are stopped are left to be played again
+	tracker.Start(l, 1, geometry.Left, score)
+	tracker.Stop()
+	bus.Publish(event.MatchOver{Winner: geometry.Right})
+
+	if l.Schedule.Fixtures[1].Played {
+		t.Error("stopped fixture was recorded")
+	}
+
+	// the recorded fixture was saved
+	saved, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load league: %v", err)
+	}
+
+	if saved == nil || saved.Schedule.Played() != 1 || saved.Schedule.Fixtures[0] != f {
+		t.Errorf("saved league = %+v, want the recorded fixture", saved)
+	}
+}
+
+func TestLoad_Cleared(t *testing.T) {
+	storagetest.UseTempDir(t)
+
+	if l, err := Load(); err != nil || l != nil {
+		t.Fatalf("league = %+v, %v, want none saved", l, err)
+	}
+
+	l, err := New([]string{"Ada", "Bob"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	if err := l.Save(); err != nil {
+		t.Fatalf("failed to save league: %v", err)
+	}
+
+	if err := Clear(); err != nil {
+		t.Fatalf("failed to clear league: %v", err)
+	}
+
+	if l, err := Load(); err != nil || l != nil {
+		t.Errorf("league = %+v, %v, want none after clearing it", l, err)
+	}
+}
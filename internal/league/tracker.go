@@ -0,0 +1,66 @@
+package league
+
+import (
+	"log/slog"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Tracker records the score of the league fixture being played once it's over.
+type Tracker struct {
+	// active is true while a league fixture is being played.
+	active  bool
+	league  *League
+	fixture int
+	// home is the side defended by the home player.
+	home  geometry.Side
+	score func(side geometry.Side) int
+}
+
+// NewTracker creates a new Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Subscribe records the scores of the matches ending on the bus.
+func (t *Tracker) Subscribe(bus *event.Bus) {
+	event.On(bus, t.matchOver)
+}
+
+// Start tracks the fixture with the ID of the league, its home player defending the home side.
+// score returns the score of a side, asked once the match is over.
+func (t *Tracker) Start(league *League, fixture int, home geometry.Side, score func(side geometry.Side) int) {
+	*t = Tracker{
+		active:  true,
+		league:  league,
+		fixture: fixture,
+		home:    home,
+		score:   score,
+	}
+}
+
+// Stop stops tracking the fixture, leaving it to be played again, e.g. when the players quit it.
+func (t *Tracker) Stop() {
+	t.active = false
+}
+
+// matchOver records the score of the fixture, then saves the league.
+func (t *Tracker) matchOver(event.MatchOver) {
+	if !t.active {
+		return
+	}
+
+	t.active = false
+
+	home, away := t.score(t.home), t.score(t.home.Opposite())
+
+	if err := t.league.Schedule.Record(t.fixture, home, away); err != nil {
+		slog.Error("failed to record league fixture", slog.Int("fixture", t.fixture), slog.Any("error", err))
+		return
+	}
+
+	if err := t.league.Save(); err != nil {
+		slog.Error("failed to save league", slog.Any("error", err))
+	}
+}
@@ -1,74 +1,127 @@
 package menu
 
 import (
-	"log/slog"
-	"math"
-
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 
 	"github.com/gandarez/pong-multiplayer-go/internal/ui"
-	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	// optionsStartY is the Y position of the first option, below the splash title.
+	optionsStartY = 250.0
+	// maxOptionsSpacing is the maximum vertical space between two options.
+	maxOptionsSpacing = 50.0
+	// minPreferencesSpacing is the minimum vertical space between two preferences, before their list scrolls.
+	minPreferencesSpacing = 22.0
 )
 
 // baseState is a base struct for all menu states that contains common fields and methods.
 type baseState struct {
-	selectedOption int
-	options        []string
-	menu           *Menu
+	menu *Menu
+	// list holds the widgets of the state and dialog is the dialog shown over them, nil when none is.
+	list   *ui.List
+	dialog *ui.Dialog
+	// message is the line below the preferences telling how the last change went, nil for the other menus,
+	// and hint is shown on it, smaller, until then.
+	message *ui.Label
+	hint    string
 }
 
-func (s *baseState) navigateOptions(maxOptions int) {
-	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
-		if s.selectedOption < maxOptions-1 {
-			s.selectedOption++
-		}
-	}
+// update handles the input of the frame, which it returns. The dialog shown takes all of it,
+// otherwise back is called on Back and the rest goes to the list.
+func (s *baseState) update(back func()) *ui.Input {
+	in := s.menu.input()
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
-		if s.selectedOption > 0 {
-			s.selectedOption--
-		}
+	switch {
+	case s.dialog != nil:
+		s.dialog.Update(in)
+	case in.Back:
+		back()
+	case s.list != nil:
+		s.list.Update(in)
 	}
+
+	return in
 }
 
-func (s *baseState) drawOptions(screen *ebiten.Image) {
-	textFace, err := s.menu.font.Face("ui", 20)
-	if err != nil {
-		slog.Error("failed to create text face", slog.Any("error", err))
-		return
+// showDialog shows a dialog asking to confirm the message, calling onConfirm if it's confirmed.
+func (s *baseState) showDialog(message string, onConfirm func()) {
+	s.dialog = ui.NewConfirmDialog(
+		s.menu.face(20),
+		message,
+		func() {
+			s.dialog = nil
+			onConfirm()
+		},
+		func() {
+			s.dialog = nil
+		},
+	)
+}
+
+// Draw draws the list and the dialog shown over it.
+func (s *baseState) Draw(screen *ebiten.Image) {
+	if s.list != nil {
+		s.list.Draw(screen)
+	}
+
+	if s.dialog != nil {
+		s.dialog.Draw(screen)
 	}
+}
+
+// options creates the list of the options of a menu below the splash title, an indicator
+// drawn left of the focused one.
+func (s *baseState) options(options ...ui.Widget) *ui.List {
+	list := s.menu.newList(optionsStartY, maxOptionsSpacing, options...)
+	list.MinSpacing = 0
+	list.Indicator = true
 
-	var maxWidth float64
+	return list
+}
 
-	y := 250.0
+// preferences creates the list of the preferences of a menu from the Y position y, spacing pixels
+// between them, followed by the line telling how the last change went, showing the hint until then.
+func (s *baseState) preferences(y, spacing float64, hint string, options ...ui.Widget) *ui.List {
+	s.hint = hint
+	s.message = &ui.Label{Highlighted: true}
+	s.setMessage("")
 
-	for _, val := range s.options {
-		width, _ := text.Measure(val, textFace, 1)
-		uiText := ui.Text{
-			Value:    val,
-			FontFace: textFace,
-			Position: geometry.Vector{
-				X: (float64(s.menu.screenWidth) - width) / 2,
-				Y: y,
-			},
-			Color: ui.DefaultColor,
-		}
-		uiText.Draw(screen)
+	list := s.menu.newList(y, spacing, append(options, s.message)...)
+	list.MinSpacing = minPreferencesSpacing
 
-		maxWidth = math.Max(maxWidth, width)
+	return list
+}
 
-		y += 50
+// setMessage sets the line below the preferences, the hint when the message is empty.
+func (s *baseState) setMessage(message string) {
+	if s.message == nil {
+		return
 	}
 
-	// Draw selected option indicator
-	y = 255.0 + 50.0*float64(s.selectedOption)
+	s.message.Value, s.message.FontFace = message, s.menu.face(20)
+	if message == "" {
+		s.message.Value, s.message.FontFace = s.hint, s.menu.face(14)
+	}
+}
 
-	vector.DrawFilledRect(
-		screen,
-		float32((float64(s.menu.screenWidth)-maxWidth)/2-30), float32(y),
-		15, 15, ui.DefaultColor, true,
-	)
+// saved sets the line below the preferences after saving the settings, telling if they couldn't be.
+func (s *baseState) saved(ok bool) {
+	if ok {
+		s.setMessage("")
+		return
+	}
+
+	s.setMessage("Failed to save settings")
+}
+
+// labels returns a label of the face for each line.
+func labels(face text.Face, lines ...string) []ui.Widget {
+	widgets := make([]ui.Widget, 0, len(lines))
+	for _, line := range lines {
+		widgets = append(widgets, ui.NewLabel(line, face))
+	}
+
+	return widgets
 }
@@ -0,0 +1,174 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+)
+
+const (
+	resetControlsStr = "Reset to defaults"
+
+	controlsStartY      = 150.0
+	controlsLineSpacing = 18.0
+)
+
+// controlsState is the state where the player can rebind the keys and gamepad buttons of each action.
+type controlsState struct {
+	*baseState
+	actions  []input.Action
+	bindings []*ui.Button
+	// waiting is true while waiting for the key or button to bind to the selected action.
+	waiting bool
+	status  *ui.Label
+}
+
+var _ state = (*controlsState)(nil)
+
+// newControlsState creates a new controlsState.
+func newControlsState(menu *Menu) *controlsState {
+	s := &controlsState{
+		baseState: &baseState{menu: menu},
+		actions:   input.Actions(),
+	}
+
+	face := menu.face(14)
+	items := make([]ui.Widget, 0, len(s.actions)+3)
+
+	for _, action := range s.actions {
+		binding := ui.NewOption(action.String(), face, func() {
+			s.waiting = true
+			s.setStatus(fmt.Sprintf("Press a key or gamepad button for %s, Esc to cancel", action))
+		})
+
+		s.bindings = append(s.bindings, binding)
+		items = append(items, binding)
+	}
+
+	s.status = &ui.Label{FontFace: face, Highlighted: true}
+	s.setStatus("")
+
+	items = append(items,
+		ui.NewOption(resetControlsStr, face, func() {
+			s.menu.controls.Reset()
+			s.setStatus("Default controls restored")
+			s.save()
+		}),
+		ui.NewOption(backStr, face, s.back),
+		s.status,
+	)
+
+	s.list = menu.newList(controlsStartY, controlsLineSpacing, items...)
+
+	return s
+}
+
+// Update updates the state.
+func (s *controlsState) Update() {
+	in := s.menu.input()
+
+	if s.waiting {
+		s.waitBinding(in)
+	} else {
+		s.handle(in)
+	}
+
+	for i, action := range s.actions {
+		s.bindings[i].Label = fmt.Sprintf("%s: %s", action, s.menu.controls.Binding(action))
+	}
+}
+
+// handle handles the input of the frame while no binding is awaited.
+func (s *controlsState) handle(in *ui.Input) {
+	if in.Back {
+		s.back()
+		return
+	}
+
+	// backspace removes the gamepad button of the selected action
+	if i := s.list.Focus(); in.Erase && i < len(s.actions) {
+		action := s.actions[i]
+		binding := s.menu.controls.Binding(action)
+		binding.Button = input.NoButton
+		s.bind(action, binding)
+
+		return
+	}
+
+	s.list.Update(in)
+}
+
+// waitBinding binds the next key or gamepad button pressed to the selected action. Esc and the
+// back button cancel, while any gamepad button can be bound.
+func (s *controlsState) waitBinding(in *ui.Input) {
+	action := s.actions[s.list.Focus()]
+	binding := s.menu.controls.Binding(action)
+
+	for _, id := range s.menu.controls.Gamepads().Connected() {
+		if buttons := inpututil.AppendJustPressedStandardGamepadButtons(id, nil); len(buttons) > 0 {
+			binding.Button = buttons[0]
+			s.bind(action, binding)
+
+			return
+		}
+	}
+
+	if in.Back {
+		s.waiting = false
+		s.setStatus("")
+
+		return
+	}
+
+	if keys := inpututil.AppendJustPressedKeys(nil); len(keys) > 0 {
+		binding.Key = keys[0]
+		s.bind(action, binding)
+	}
+}
+
+// bind binds the action and saves the controls, reporting conflicts with other actions.
+func (s *controlsState) bind(action input.Action, binding input.Binding) {
+	s.waiting = false
+
+	other, err := s.menu.controls.Bind(action, binding)
+	if errors.Is(err, input.ErrConflict) {
+		s.setStatus(fmt.Sprintf("Already bound to %s", other))
+		return
+	}
+
+	s.setStatus("")
+	s.save()
+}
+
+// save saves the controls in the settings, showing a message when they can't be saved.
+func (s *controlsState) save() {
+	s.menu.settings.SetBindings(s.menu.controls.Bindings())
+
+	if !s.menu.saveSettings() {
+		s.setStatus("Failed to save controls")
+	}
+}
+
+// setStatus sets the line below the controls, telling how to rebind them when the message is empty.
+func (s *controlsState) setStatus(message string) {
+	if message == "" {
+		message = "Enter to rebind, Backspace to remove the gamepad button"
+	}
+
+	s.status.Value = message
+}
+
+// back returns to the settings menu.
+func (s *controlsState) back() {
+	s.setStatus("")
+	s.menu.ChangeState(newSettingsState(s.menu))
+}
+
+// String returns the state name.
+func (*controlsState) String() string {
+	return "controlsState"
+}
@@ -0,0 +1,154 @@
+package menu
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
+	"github.com/gandarez/pong-multiplayer-go/internal/settings"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+)
+
+const (
+	displayStr      = "Display"
+	fullscreenStr   = "Fullscreen"
+	scalingStr      = "Scaling"
+	aspectStr       = "Aspect ratio"
+	windowSizeStr   = "Window size"
+	themeStr        = "Theme"
+	playerColorsStr = "Player colors"
+)
+
+// windowScales are the sizes the window can take, relative to the screen size.
+// nolint:gochecknoglobals
+var windowScales = []float64{1, 1.5, 2, 2.5, 3}
+
+// displayState is the state where the player chooses how the game is shown: fullscreen or in a window
+// of which size, how the screen is scaled to it and the colors it's drawn with.
+type displayState struct {
+	*baseState
+}
+
+var _ state = (*displayState)(nil)
+
+// newDisplayState creates a new displayState.
+func newDisplayState(menu *Menu) *displayState {
+	s := &displayState{baseState: &baseState{menu: menu}}
+
+	face := menu.face(20)
+	current := menu.display.Settings
+
+	options := []ui.Widget{
+		ui.NewToggle(fullscreenStr, face, ebiten.IsFullscreen, func(on bool) {
+			s.change(func(d *display.Settings) { d.Fullscreen = on })
+		}),
+		ui.NewChoice(scalingStr, face, func() string { return current().Scaling.String() }, func(dir int) {
+			s.change(func(d *display.Settings) { d.Scaling = cycle(display.Scalings(), d.Scaling, dir) })
+		}),
+		ui.NewChoice(aspectStr, face, func() string { return current().Aspect.String() }, func(dir int) {
+			s.change(func(d *display.Settings) {
+				d.Aspect = cycle(display.Aspects(), d.Aspect, dir)
+				s.fitWindow(d.Aspect)
+			})
+		}),
+		ui.NewChoice(themeStr, face, func() string { return current().Theme }, func(dir int) {
+			s.change(func(d *display.Settings) { d.Theme = cycle(theme.Names(), d.Theme, dir) })
+		}),
+		ui.NewToggle(playerColorsStr, face, func() bool { return current().PlayerColors }, func(on bool) {
+			s.change(func(d *display.Settings) { d.PlayerColors = on })
+		}),
+	}
+
+	// the size of the browser window can't be changed
+	if runtime.GOOS != "js" {
+		options = append(options, ui.NewChoice(windowSizeStr, face, s.windowSizeName, func(dir int) {
+			s.change(func(*display.Settings) {
+				menu.settings.Window = cycle(s.windowSizes(), s.windowSize(), dir)
+				ebiten.SetWindowSize(menu.settings.Window.Width, menu.settings.Window.Height)
+			})
+		}))
+	}
+
+	s.list = s.preferences(
+		preferencesStartY, preferencesLineSpacing,
+		"Integer scaling keeps the pixels sharp, F11 toggles fullscreen",
+		append(options, ui.NewOption(backStr, face, s.back))...,
+	)
+
+	return s
+}
+
+// Update updates the state.
+func (s *displayState) Update() {
+	s.update(s.back)
+}
+
+// change changes the display settings with edit, applies them and saves the settings.
+func (s *displayState) change(edit func(d *display.Settings)) {
+	userSettings := s.menu.settings
+	// fullscreen can also be left with F11 or by the browser
+	userSettings.Display.Fullscreen = ebiten.IsFullscreen()
+
+	edit(&userSettings.Display)
+
+	s.menu.display.Apply(userSettings.Display)
+	s.menu.audio.Play(audio.MenuMove)
+
+	s.saved(s.menu.saveSettings())
+}
+
+// fitWindow resizes the window to the aspect ratio, keeping its height.
+func (s *displayState) fitWindow(aspect display.Aspect) {
+	size := s.windowSize()
+	if size.Height == 0 || ebiten.IsFullscreen() {
+		return
+	}
+
+	size.Width = size.Height * aspect.Width() / display.Height
+	s.menu.settings.Window = size
+
+	ebiten.SetWindowSize(size.Width, size.Height)
+}
+
+// back returns to the settings menu.
+func (s *displayState) back() {
+	s.setMessage("")
+	s.menu.ChangeState(newSettingsState(s.menu))
+}
+
+// windowSizes returns the sizes the window can take.
+func (s *displayState) windowSizes() []settings.Window {
+	sizes := make([]settings.Window, 0, len(windowScales))
+
+	for _, scale := range windowScales {
+		sizes = append(sizes, settings.Window{
+			Width:  int(s.menu.screenWidth() * scale),
+			Height: int(s.menu.screenHeight() * scale),
+		})
+	}
+
+	return sizes
+}
+
+// windowSize returns the current size of the window.
+func (*displayState) windowSize() settings.Window {
+	width, height := ebiten.WindowSize()
+
+	return settings.Window{Width: width, Height: height}
+}
+
+// windowSizeName returns the current size of the window as a string.
+func (s *displayState) windowSizeName() string {
+	size := s.windowSize()
+
+	return fmt.Sprintf("%dx%d", size.Width, size.Height)
+}
+
+// String returns the state name.
+func (*displayState) String() string {
+	return "displayState"
+}
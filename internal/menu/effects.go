@@ -0,0 +1,83 @@
+package menu
+
+import (
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+)
+
+const (
+	visualEffectsStr = "Visual effects"
+	rumbleStr        = "Gamepad rumble"
+	shakeStr         = "Screen shake"
+	flashStr         = "Hit flashes"
+	particlesStr     = "Particles"
+	slowMotionStr    = "Goal slow motion"
+	reducedMotionStr = "Reduced motion"
+)
+
+// effectsState is the state where the player can turn each visual and haptic effect on or off.
+type effectsState struct {
+	*baseState
+}
+
+var _ state = (*effectsState)(nil)
+
+// newEffectsState creates a new effectsState.
+func newEffectsState(menu *Menu) *effectsState {
+	s := &effectsState{baseState: &baseState{menu: menu}}
+
+	face := menu.face(20)
+	effects := &menu.settings.Effects
+	// camera tells whether reduced motion turns the effect off
+	toggle := func(label string, value *bool, camera bool) ui.Widget {
+		t := ui.NewToggle(label, face, func() bool { return *value }, func(on bool) {
+			*value = on
+			s.changed()
+		})
+		t.Format = func(on bool) string {
+			if on && camera && effects.ReducedMotion {
+				return "Off (reduced motion)"
+			}
+
+			return onOff(on)
+		}
+
+		return t
+	}
+
+	s.list = s.preferences(
+		preferencesStartY, preferencesLineSpacing,
+		"Reduced motion turns off the shake, flashes and slow motion",
+		toggle(rumbleStr, &effects.Rumble, false),
+		toggle(shakeStr, &effects.Shake, true),
+		toggle(flashStr, &effects.Flash, true),
+		toggle(particlesStr, &effects.Particles, false),
+		toggle(slowMotionStr, &effects.SlowMotion, true),
+		toggle(reducedMotionStr, &effects.ReducedMotion, false),
+		ui.NewOption(backStr, face, s.back),
+	)
+
+	return s
+}
+
+// Update updates the state.
+func (s *effectsState) Update() {
+	s.update(s.back)
+}
+
+// changed saves the settings once an effect was turned on or off.
+func (s *effectsState) changed() {
+	s.menu.audio.Play(audio.MenuMove)
+	s.saved(s.menu.saveSettings())
+}
+
+// back returns to the settings menu.
+func (s *effectsState) back() {
+	s.setMessage("")
+	s.menu.ChangeState(newSettingsState(s.menu))
+}
+
+// String returns the state name.
+func (*effectsState) String() string {
+	return "effectsState"
+}
@@ -0,0 +1,96 @@
+package menu
+
+import (
+	"fmt"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+)
+
+const handicapsStr = "Handicaps"
+
+// handicapsState is the state where the players choose the handicap of each player of local
+// matches, by their place in the lineup of the format of the match.
+type handicapsState struct {
+	*baseState
+	// format is the format the list of players was built for and paddle describes the paddle
+	// of the focused player.
+	format rules.Format
+	paddle *ui.Label
+}
+
+var _ state = (*handicapsState)(nil)
+
+// newHandicapsState creates a new handicapsState.
+func newHandicapsState(menu *Menu) *handicapsState {
+	s := &handicapsState{baseState: &baseState{menu: menu}}
+	s.build()
+
+	return s
+}
+
+// build builds the list of the players of the format of the match.
+func (s *handicapsState) build() {
+	s.format = s.menu.rules.Format
+
+	face := s.menu.face(20)
+	hintFace := s.menu.face(14)
+	count := len(s.format.Sides()) * s.format.Lanes()
+
+	items := make([]ui.Widget, 0, count+3)
+
+	for i := range count {
+		items = append(items, ui.NewChoice(
+			fmt.Sprintf("Player %d", i+1),
+			face,
+			func() string { return s.menu.settings.Handicap(i).String() },
+			func(dir int) { s.change(i, dir) },
+		))
+	}
+
+	s.paddle = ui.NewLabel("", hintFace)
+
+	items = append(items,
+		ui.NewOption(backStr, face, s.back),
+		s.paddle,
+		ui.NewLabel("Player 1 is you against the CPU, and your handicap online if the server allows it", hintFace),
+	)
+
+	s.list = s.menu.newList(preferencesStartY, preferencesLineSpacing, items...)
+	s.list.MinSpacing = minPreferencesSpacing
+}
+
+// Update updates the state.
+func (s *handicapsState) Update() {
+	// the players change with the format
+	if s.menu.rules.Format != s.format {
+		s.build()
+	}
+
+	s.update(s.back)
+
+	s.paddle.Value = ""
+	if i := s.list.Focus(); i < len(s.format.Sides())*s.format.Lanes() {
+		paddle := s.menu.settings.Handicap(i).Paddle()
+		s.paddle.Value = fmt.Sprintf("Paddle %.0f long, moving %.0f units a second", paddle.Height, paddle.Speed)
+	}
+}
+
+// change gives the player i the handicap dir steps away from theirs and saves it.
+func (s *handicapsState) change(i, dir int) {
+	s.menu.settings.SetHandicap(i, cycle(player.Handicaps(), s.menu.settings.Handicap(i), dir))
+	s.menu.audio.Play(audio.MenuMove)
+	s.menu.saveSettings()
+}
+
+// back returns to the match settings.
+func (s *handicapsState) back() {
+	s.menu.ChangeState(newMatchSettingsState(s.menu))
+}
+
+// String returns the state name.
+func (*handicapsState) String() string {
+	return "handicapsState"
+}
@@ -0,0 +1,200 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+)
+
+// historyState is the state listing the recent online matches of a player, a page at a time.
+// Matches with a stored replay can be watched.
+type historyState struct {
+	*baseState
+	// name is the name of the player whose matches are listed.
+	name     string
+	page     int
+	fetched  bool
+	history  remote[network.HistoryPage]
+	previous state
+	// loading is true while the page is being fetched, title is drawn above the page
+	// and pageRow is the row of the list turning the pages.
+	loading bool
+	title   string
+	pageRow int
+}
+
+var _ state = (*historyState)(nil)
+
+// newHistoryState creates a new historyState listing the matches of the player with the name,
+// going back to previous.
+func newHistoryState(menu *Menu, name string, previous state) *historyState {
+	s := &historyState{
+		baseState: &baseState{menu: menu},
+		name:      name,
+		page:      1,
+		previous:  previous,
+	}
+	s.showMessage("Fetching matches...")
+
+	return s
+}
+
+// fetch fetches the current page.
+func (s *historyState) fetch() {
+	s.fetched = true
+
+	name, page := s.name, s.page
+
+	s.history.fetch(func(ctx context.Context) (network.HistoryPage, error) {
+		return network.FetchHistory(ctx, name, page)
+	})
+}
+
+// Update updates the state.
+func (s *historyState) Update() {
+	if !s.fetched {
+		s.fetch()
+	}
+
+	in := s.update(func() {
+		s.menu.ChangeState(s.previous)
+	})
+
+	history, loading, err := s.history.get()
+	if loading != s.loading {
+		s.loading = loading
+
+		if !loading {
+			s.build(history, err)
+		}
+	}
+
+	// the pages can be turned from any row
+	if s.list.Focus() == s.pageRow {
+		return
+	}
+
+	switch {
+	case in.Left:
+		s.turn(-1)
+	case in.Right:
+		s.turn(1)
+	}
+}
+
+// build lists the matches of the page fetched, or tells why there are none.
+func (s *historyState) build(history network.HistoryPage, err error) {
+	switch {
+	case err != nil:
+		s.showMessage("Failed to fetch matches")
+		return
+	case len(history.Matches) == 0:
+		s.showMessage("No online matches played by " + s.name)
+		return
+	}
+
+	rowFace := s.menu.face(14)
+	items := make([]ui.Widget, 0, len(history.Matches)+2)
+
+	for _, match := range history.Matches {
+		items = append(items, ui.NewOption(matchLine(match, s.name), rowFace, func() {
+			s.watch(match.Replay)
+		}))
+	}
+
+	pages := max(history.Pages, 1)
+
+	s.title = fmt.Sprintf("Matches of %s %d/%d", s.name, s.page, pages)
+	s.pageRow = len(items)
+	s.list = s.menu.newList(listStartY, listLineSpacing, append(items,
+		ui.NewChoice("Page", rowFace, func() string { return fmt.Sprintf("%d/%d", s.page, pages) }, s.turn),
+		ui.NewLabel("Enter: watch replay   Left/Right: page", rowFace),
+	)...)
+}
+
+// showMessage shows the message instead of the matches.
+func (s *historyState) showMessage(message string) {
+	s.title = ""
+	s.pageRow = -1
+	s.list = s.menu.newList(250, listLineSpacing, ui.NewLabel(message, s.menu.face(20)))
+}
+
+// watch watches the replay of a match, nothing for matches without one.
+func (s *historyState) watch(replay string) {
+	if replay == "" {
+		return
+	}
+
+	s.menu.SessionID = ""
+	s.menu.ReplayID = replay
+	s.menu.ChangeState(newSpectatorConnectingState(s.menu))
+}
+
+// turn shows the page dir pages away from the current one.
+func (s *historyState) turn(dir int) {
+	history, loading, _ := s.history.get()
+	if loading || s.page+dir < 1 || s.page+dir > history.Pages {
+		return
+	}
+
+	s.page += dir
+	s.menu.audio.Play(audio.MenuMove)
+	s.fetch()
+}
+
+// matchLine returns the match as listed to the player with the name.
+func matchLine(match network.MatchRecord, name string) string {
+	var (
+		player    network.MatchPlayer
+		scores    []string
+		opponents []string
+	)
+
+	for _, p := range match.Players {
+		scores = append(scores, fmt.Sprint(p.Score))
+
+		if strings.EqualFold(p.Name, name) {
+			player = p
+			continue
+		}
+
+		opponents = append(opponents, p.Name)
+	}
+
+	result := "Lost"
+	if player.Winner {
+		result = "Won"
+	}
+
+	line := fmt.Sprintf("%s %s %s vs %s", match.Date.Local().Format("Jan 02"), result,
+		strings.Join(scores, "-"), strings.Join(opponents, ", "))
+
+	if player.RatingChange != 0 {
+		line += fmt.Sprintf(" (%+.f)", player.RatingChange)
+	}
+
+	if match.Replay != "" {
+		line += "  Watch"
+	}
+
+	return line
+}
+
+// Draw draws the state.
+func (s *historyState) Draw(screen *ebiten.Image) {
+	ui.DrawCentered(screen, s.title, s.menu.face(20), 145, theme.Active().Text)
+
+	s.baseState.Draw(screen)
+}
+
+// String returns the state name.
+func (s *historyState) String() string {
+	return "historyState:" + s.name
+}
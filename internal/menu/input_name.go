@@ -1,149 +1,150 @@
 package menu
 
 import (
-	"log/slog"
-	"math"
 	"regexp"
-	"time"
 	"unicode/utf8"
 
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text/v2"
-
 	"github.com/gandarez/pong-multiplayer-go/internal/ui"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
-	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
-const maxNameLength = 10
+const (
+	maxNameLength = 10
+	// defaultPlayerName is the name used when playing without typing one.
+	defaultPlayerName = "Guest"
+)
 
 var validNameRegexp = regexp.MustCompile(`^[a-zA-Z-\.]+$`)
 
-// inputNameState is the state where the player can input their name.
+// inputNameState is the state where the player can input their name, before a multiplayer
+// match or from the settings menu.
 type inputNameState struct {
-	cursorVisible bool
-	cursorTicker  *time.Ticker
-	menu          *Menu
+	*baseState
+	name *ui.TextInput
+	// fromSettings is true when the name is changed in the settings menu instead of
+	// before a multiplayer match.
+	fromSettings bool
 }
 
 var _ state = (*inputNameState)(nil)
 
-// newInputNameState creates a new inputNameState.
+// newInputNameState creates a new inputNameState to join a multiplayer match.
 func newInputNameState(menu *Menu) *inputNameState {
-	state := &inputNameState{
-		menu: menu,
+	return newNameState(menu, false)
+}
+
+// newEditNameState creates a new inputNameState to change the name in the settings.
+func newEditNameState(menu *Menu) *inputNameState {
+	return newNameState(menu, true)
+}
+
+// newNameState creates a new inputNameState.
+func newNameState(menu *Menu, fromSettings bool) *inputNameState {
+	s := &inputNameState{
+		baseState:    &baseState{menu: menu},
+		fromSettings: fromSettings,
 	}
 
-	state.cursorTicker = time.NewTicker(500 * time.Millisecond)
-	go func() {
-		for range state.cursorTicker.C {
-			state.cursorVisible = !state.cursorVisible
+	face := menu.face(20)
+
+	s.name = ui.NewTextInput("", face, menu.playerName, maxNameLength)
+	s.name.Accept = validNameChar
+	s.name.OnChange = func(name string) {
+		menu.playerName = name
+	}
+	s.name.OnSubmit = func(name string) {
+		if name != "" {
+			s.confirm()
 		}
-	}()
+	}
 
-	return state
-}
+	label := "Play"
+	if fromSettings {
+		label = "Save"
+	}
 
-// Update updates the state.
-func (s *inputNameState) Update() {
-	for _, char := range ebiten.AppendInputChars(nil) {
-		if !validNameRegexp.MatchString(string(char)) {
-			continue
+	// without a keyboard, the play button continues with a default name
+	play := ui.NewButton(label, menu.buttonFace(), func() {
+		if menu.playerName == "" {
+			menu.playerName = defaultPlayerName
 		}
 
-		//  limit the name length
-		if len(s.menu.playerName) == maxNameLength {
-			continue
-		}
+		s.confirm()
+	})
 
-		// do not allow starting with dot or dash
-		if len(s.menu.playerName) == 0 && (char == '.' || char == '-') {
-			continue
-		}
+	s.list = menu.newList(250, 40, ui.NewLabel("Enter your name:", face), s.name, play)
 
-		// do not allow consecutive dots or dashes
-		if len(s.menu.playerName) > 0 {
-			lastChar, _ := utf8.DecodeLastRuneInString(s.menu.playerName)
-			if (lastChar == '.' || lastChar == '-') && (char == '.' || char == '-') {
-				continue
-			}
-		}
+	return s
+}
 
-		s.menu.playerName += string(char)
+// validNameChar returns true if the character can follow the name: letters, and dots or dashes
+// neither leading nor doubled.
+func validNameChar(name string, char rune) bool {
+	if !validNameRegexp.MatchString(string(char)) {
+		return false
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(s.menu.playerName) > 0 {
-		_, size := utf8.DecodeLastRuneInString(s.menu.playerName)
-		s.menu.playerName = s.menu.playerName[:len(s.menu.playerName)-size]
+	if char != '.' && char != '-' {
+		return true
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && len(s.menu.playerName) > 0 {
-		// trim any dot or dash at the end
-		lastChar, _ := utf8.DecodeLastRuneInString(s.menu.playerName)
-		if lastChar == '.' || lastChar == '-' {
-			s.menu.playerName = s.menu.playerName[:len(s.menu.playerName)-1]
-		}
-
-		s.menu.gameMode = Multiplayer
-		s.menu.level = level.Medium
-		s.menu.readyToPlay = true
+	if name == "" {
+		return false
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		s.menu.playerName = ""
-		s.menu.ChangeState(newMainMenuState(s.menu))
-	}
+	lastChar, _ := utf8.DecodeLastRuneInString(name)
+
+	return lastChar != '.' && lastChar != '-'
 }
 
-// Draw draws the state.
-func (s *inputNameState) Draw(screen *ebiten.Image) {
-	textFace, err := s.menu.font.Face("ui", 20)
-	if err != nil {
-		slog.Error("failed to create text face", slog.Any("error", err))
-		return
-	}
+// Update updates the state.
+func (s *inputNameState) Update() {
+	// the name is restored when going back and cleared when the settings are reset
+	s.name.Value = s.menu.playerName
+
+	s.update(func() {
+		// restore the saved name
+		s.menu.playerName = s.menu.settings.PlayerName
+		s.menu.ChangeState(s.previousState())
+	})
+}
 
-	prompt := "Enter your name:"
-	width, _ := text.Measure(prompt, textFace, 1)
-	y := 250.0
-	uiText := ui.Text{
-		Value:    prompt,
-		FontFace: textFace,
-		Position: geometry.Vector{
-			X: (float64(s.menu.screenWidth) - width) / 2,
-			Y: y,
-		},
-		Color: ui.DefaultColor,
+// previousState returns the state to go back to.
+func (s *inputNameState) previousState() state {
+	if s.fromSettings {
+		return newSettingsState(s.menu)
 	}
-	uiText.Draw(screen)
 
-	name := s.menu.playerName
+	return newMainMenuState(s.menu)
+}
 
-	widthCursor, _ := text.Measure(name+"_", textFace, 1)
-	widthWithoutCursor, _ := text.Measure(name+"", textFace, 1)
+// confirm trims and saves the player name, then starts a multiplayer match
+// or goes back to the settings menu.
+func (s *inputNameState) confirm() {
+	// trim any dot or dash at the end
+	lastChar, _ := utf8.DecodeLastRuneInString(s.menu.playerName)
+	if lastChar == '.' || lastChar == '-' {
+		s.menu.playerName = s.menu.playerName[:len(s.menu.playerName)-1]
+	}
 
-	// get max width to not make text jump
-	widthName := math.Max(widthCursor, widthWithoutCursor)
+	s.menu.settings.PlayerName = s.menu.playerName
+	s.menu.saveSettings()
 
-	// only show cursor if the name is not at max length
-	if s.cursorVisible && len(name) < maxNameLength {
-		name += "_"
+	if s.fromSettings {
+		s.menu.ChangeState(newSettingsState(s.menu))
+		return
 	}
 
-	uiText = ui.Text{
-		Value:    name,
-		FontFace: textFace,
-		Position: geometry.Vector{
-			X: (float64(s.menu.screenWidth) - widthName) / 2,
-			Y: y + 30,
-		},
-		Color: ui.DefaultColor,
-	}
-	uiText.Draw(screen)
+	s.menu.gameMode = Multiplayer
+	s.menu.level = level.Medium
+	s.menu.readyToPlay = true
 }
 
 // String returns the state name.
-func (*inputNameState) String() string {
+func (s *inputNameState) String() string {
+	if s.fromSettings {
+		return "editNameState"
+	}
+
 	return "inputNameState"
 }
@@ -1,14 +1,10 @@
 package menu
 
 import (
+	"fmt"
 	"strings"
 
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text/v2"
-
-	"github.com/gandarez/pong-multiplayer-go/internal/ui"
-	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
 )
 
 const instructionsDetailedStr = `Welcome to PONGO!
@@ -16,15 +12,14 @@ const instructionsDetailedStr = `Welcome to PONGO!
 The goal of the game is to score points by hitting the ball with your bouncer and 
 prevent the opponent from hitting it.
 
-The game ends when one of the players reaches 10 points.
-
-Player 1
-- Move up: Q
-- Move down: A
+By default the game ends when one of the players reaches 10 points.
+Rules, arenas and the Doubles and Four-way formats can be changed in Match Settings.
+In Arcade mode, balls collect power-ups for the last player who hit them.
 
-Player 2
-- Move up: Up arrow
-- Move down: Down arrow
+Against the CPU or online - Move up: %s, Move down: %s
+Sharing the keyboard - Player 1: %s/%s, Player 2: %s/%s
+Pause: %s, Show metrics: %s. Keys can be changed in Settings.
+Gamepads can be used too: the left stick moves the paddle.
 
 Select the game mode, level and press Enter to start the game.
 
@@ -32,56 +27,39 @@ Press Esc to go back to the previous menu.`
 
 // instructionsState is the state where the player can see the game instructions.
 type instructionsState struct {
-	menu *Menu
+	*baseState
 }
 
 var _ state = (*instructionsState)(nil)
 
 // newInstructionsState creates a new InstructionsState.
 func newInstructionsState(menu *Menu) *instructionsState {
-	return &instructionsState{
-		menu: menu,
-	}
+	s := &instructionsState{baseState: &baseState{menu: menu}}
+	s.list = menu.newList(200, 14)
+
+	return s
 }
 
 // Update updates the state.
 func (s *instructionsState) Update() {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+	// the keys can be rebound in the meantime
+	s.list.Items = labels(s.menu.face(12), strings.Split(strings.ReplaceAll(s.text(), "\r\n", "\n"), "\n")...)
+
+	s.update(func() {
 		s.menu.ChangeState(newMainMenuState(s.menu))
-	}
+	})
 }
 
-// Draw draws the state.
-func (s *instructionsState) Draw(screen *ebiten.Image) {
-	textFace, err := s.menu.font.Face("ui", 12)
-	if err != nil {
-		return
+// text returns the instructions with the keys currently bound to each action.
+func (s *instructionsState) text() string {
+	keys := make([]any, 0, 8)
+	for _, action := range []input.Action{
+		input.MoveUp, input.MoveDown, input.P1Up, input.P1Down, input.P2Up, input.P2Down, input.Pause, input.ToggleMetrics,
+	} {
+		keys = append(keys, input.KeyName(s.menu.controls.Binding(action).Key))
 	}
 
-	y := 200.0
-	val := strings.ReplaceAll(instructionsDetailedStr, "\r\n", "\n")
-	splitted := strings.Split(val, "\n")
-
-	for _, str := range splitted {
-		width, height := text.Measure(str, textFace, 1)
-		if str == "" && height == 0 {
-			height = 10
-		}
-
-		uiText := ui.Text{
-			Value:    str,
-			FontFace: textFace,
-			Position: geometry.Vector{
-				X: (float64(s.menu.screenWidth) - width) / 2,
-				Y: y,
-			},
-			Color: ui.DefaultColor,
-		}
-
-		uiText.Draw(screen)
-
-		y += float64(height)
-	}
+	return fmt.Sprintf(instructionsDetailedStr, keys...)
 }
 
 // String returns the name of the state.
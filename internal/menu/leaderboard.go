@@ -0,0 +1,155 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+)
+
+const (
+	leaderboardStr = "Leaderboard"
+
+	// listStartY and listLineSpacing are where the rows of the leaderboard and of the history are drawn.
+	listStartY      = 180.0
+	listLineSpacing = 22.0
+)
+
+// leaderboardState is the state listing the online players ranked by rating, a page at a time.
+// Choosing a player shows their recent matches.
+type leaderboardState struct {
+	*baseState
+	page        int
+	fetched     bool
+	leaderboard remote[network.LeaderboardPage]
+	// loading is true while the page is being fetched, title is drawn above the page
+	// and pageRow is the row of the list turning the pages.
+	loading bool
+	title   string
+	pageRow int
+}
+
+var _ state = (*leaderboardState)(nil)
+
+// newLeaderboardState creates a new leaderboardState showing the first page.
+func newLeaderboardState(menu *Menu) *leaderboardState {
+	s := &leaderboardState{
+		baseState: &baseState{menu: menu},
+		page:      1,
+	}
+	s.showMessage("Fetching leaderboard...")
+
+	return s
+}
+
+// fetch fetches the current page.
+func (s *leaderboardState) fetch() {
+	s.fetched = true
+
+	page := s.page
+
+	s.leaderboard.fetch(func(ctx context.Context) (network.LeaderboardPage, error) {
+		return network.FetchLeaderboard(ctx, page)
+	})
+}
+
+// Update updates the state.
+func (s *leaderboardState) Update() {
+	if !s.fetched {
+		s.fetch()
+	}
+
+	in := s.update(s.back)
+
+	leaderboard, loading, err := s.leaderboard.get()
+	if loading != s.loading {
+		s.loading = loading
+
+		if !loading {
+			s.build(leaderboard, err)
+		}
+	}
+
+	// the pages can be turned from any row
+	if s.list.Focus() == s.pageRow {
+		return
+	}
+
+	switch {
+	case in.Left:
+		s.turn(-1)
+	case in.Right:
+		s.turn(1)
+	}
+}
+
+// build lists the players of the page fetched, or tells why there are none.
+func (s *leaderboardState) build(leaderboard network.LeaderboardPage, err error) {
+	switch {
+	case err != nil:
+		s.showMessage("Failed to fetch leaderboard")
+		return
+	case len(leaderboard.Players) == 0:
+		s.showMessage("No rated players yet")
+		return
+	}
+
+	rowFace := s.menu.face(14)
+	items := make([]ui.Widget, 0, len(leaderboard.Players)+2)
+
+	for _, p := range leaderboard.Players {
+		items = append(items, ui.NewOption(fmt.Sprintf("%3d. %-10s %6.f", p.Rank, p.Name, p.Rating), rowFace, func() {
+			s.menu.ChangeState(newHistoryState(s.menu, p.Name, s))
+		}))
+	}
+
+	pages := max(leaderboard.Pages, 1)
+
+	s.title = fmt.Sprintf("%s %d/%d", leaderboardStr, s.page, pages)
+	s.pageRow = len(items)
+	s.list = s.menu.newList(listStartY, listLineSpacing, append(items,
+		ui.NewChoice("Page", rowFace, func() string { return fmt.Sprintf("%d/%d", s.page, pages) }, s.turn),
+		ui.NewLabel("Enter: matches   Left/Right: page", rowFace),
+	)...)
+}
+
+// showMessage shows the message instead of the players.
+func (s *leaderboardState) showMessage(message string) {
+	s.title = ""
+	s.pageRow = -1
+	s.list = s.menu.newList(250, listLineSpacing, ui.NewLabel(message, s.menu.face(20)))
+}
+
+// turn shows the page dir pages away from the current one.
+func (s *leaderboardState) turn(dir int) {
+	leaderboard, loading, _ := s.leaderboard.get()
+	if loading || s.page+dir < 1 || s.page+dir > leaderboard.Pages {
+		return
+	}
+
+	s.page += dir
+	s.menu.audio.Play(audio.MenuMove)
+	s.fetch()
+}
+
+// back returns to the main menu.
+func (s *leaderboardState) back() {
+	s.menu.ChangeState(newMainMenuState(s.menu))
+}
+
+// Draw draws the state.
+func (s *leaderboardState) Draw(screen *ebiten.Image) {
+	ui.DrawCentered(screen, s.title, s.menu.face(20), 145, theme.Active().Text)
+
+	s.baseState.Draw(screen)
+}
+
+// String returns the state name.
+func (*leaderboardState) String() string {
+	return "leaderboardState"
+}
@@ -0,0 +1,231 @@
+package menu
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/league"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	abandonLeagueStr = "Abandon league"
+	newLeagueStr     = "New league"
+
+	// leagueTableY is where the standings and the fixtures of the round shown are drawn,
+	// side by side.
+	leagueTableY = 240.0
+	// fixturesWidth is the width of the fixtures of the round shown, drawn at the right of the screen
+	// next to the standings.
+	fixturesWidth      = 260.0
+	fixturesLineHeight = 16.0
+)
+
+// leagueState is the state showing the standings of the league played on this device
+// and the fixtures of a round at a time, from where its next fixture is played.
+type leagueState struct {
+	*baseState
+	// round is the round whose fixtures are shown, from 1, 0 showing the round of the next fixture.
+	round int
+	// next is the ID of the next fixture the options were built for, -1 once the league is over.
+	next int
+}
+
+var _ state = (*leagueState)(nil)
+
+// newLeagueState creates a new leagueState, or the setup of a new league when none is played.
+func newLeagueState(menu *Menu) state {
+	if menu.league == nil {
+		return newLeagueSetupState(menu)
+	}
+
+	s := &leagueState{baseState: &baseState{menu: menu}}
+	s.build(menu.league)
+
+	return s
+}
+
+// Update updates the state.
+func (s *leagueState) Update() {
+	l := s.menu.league
+	if l == nil {
+		s.menu.ChangeState(newLeagueSetupState(s.menu))
+		return
+	}
+
+	// the options change as the league goes on
+	if next, ok := l.Schedule.Next(); (ok && next.ID != s.next) || (!ok && s.next >= 0) {
+		s.build(l)
+	}
+
+	in := s.update(s.back)
+
+	if s.dialog == nil {
+		switch {
+		case in.Left:
+			s.turn(l, -1)
+		case in.Right:
+			s.turn(l, 1)
+		}
+	}
+}
+
+// build builds the options of the league: playing its next fixture, abandoning it and going back.
+func (s *leagueState) build(l *league.League) {
+	face := s.menu.face(20)
+
+	next, ok := l.Schedule.Next()
+	if !ok {
+		s.next = -1
+		s.list = s.menu.newList(preferencesStartY, preferencesLineSpacing,
+			ui.NewOption(newLeagueStr, face, s.abandon),
+			ui.NewOption(backStr, face, s.back),
+		)
+
+		return
+	}
+
+	s.next = next.ID
+	s.list = s.menu.newList(preferencesStartY, preferencesLineSpacing,
+		ui.NewOption(fmt.Sprintf("%s%s vs %s", playNextStr, next.Home, next.Away), face, func() { s.play(l) }),
+		ui.NewOption(abandonLeagueStr, face, func() {
+			s.showDialog("Abandon the league?", s.abandon)
+		}),
+		ui.NewOption(backStr, face, s.back),
+	)
+}
+
+// back returns to the local mode menu.
+func (s *leagueState) back() {
+	s.menu.ChangeState(newLocalModeState(s.menu))
+}
+
+// shownRound returns the round whose fixtures are shown, the round of the next fixture
+// until another is chosen, and the last once the league is over.
+func (s *leagueState) shownRound(l *league.League) int {
+	if s.round > 0 {
+		return s.round
+	}
+
+	if next, ok := l.Schedule.Next(); ok {
+		return next.Round
+	}
+
+	return l.Schedule.Rounds()
+}
+
+// turn shows the round dir rounds away from the one shown.
+func (s *leagueState) turn(l *league.League, dir int) {
+	current := s.shownRound(l)
+
+	round := min(max(current+dir, 1), l.Schedule.Rounds())
+	if round != current {
+		s.round = round
+		s.menu.audio.Play(audio.MenuMove)
+	}
+}
+
+// play plays the next fixture of the league on this device, between its players.
+func (s *leagueState) play(l *league.League) {
+	next, ok := l.Schedule.Next()
+	if !ok {
+		return
+	}
+
+	s.menu.leagueFixture = &next
+	s.menu.gameMode = TwoPlayers
+	s.menu.readyToPlay = true
+}
+
+// abandon forgets the league and goes back to the setup of a new one.
+func (s *leagueState) abandon() {
+	if err := league.Clear(); err != nil {
+		slog.Error("failed to clear league", slog.Any("error", err))
+	}
+
+	s.menu.league = nil
+	s.round = 0
+	s.menu.ChangeState(newLeagueSetupState(s.menu))
+}
+
+// Draw draws the state.
+func (s *leagueState) Draw(screen *ebiten.Image) {
+	l := s.menu.league
+	if l == nil {
+		return
+	}
+
+	tableFace := s.menu.face(12)
+	y := preferencesStartY + float64(len(s.list.Items))*preferencesLineSpacing
+
+	standings := l.Schedule.Standings()
+
+	status := fmt.Sprintf("%d players, %d of %d fixtures played", len(l.Schedule.Players), l.Schedule.Played(),
+		len(l.Schedule.Fixtures))
+	if l.Schedule.Over() {
+		status = standings[0].Player + " won the league!"
+	}
+
+	ui.DrawCentered(screen, status, tableFace, y+5, theme.Active().Text)
+
+	ui.DrawStandings(screen, tableFace, standings, geometry.Rect{
+		X:      20,
+		Y:      leagueTableY,
+		Width:  s.fixturesX() - 40,
+		Height: s.menu.screenHeight() - leagueTableY - 10,
+	})
+
+	s.drawRound(screen, l, tableFace)
+
+	s.baseState.Draw(screen)
+}
+
+// drawRound draws the fixtures of the round shown, with their score once played.
+func (s *leagueState) drawRound(screen *ebiten.Image, l *league.League, face text.Face) {
+	round := s.shownRound(l)
+	x := s.fixturesX()
+
+	(&ui.Text{
+		Value:    fmt.Sprintf("< Round %d of %d >", round, l.Schedule.Rounds()),
+		FontFace: face,
+		Position: geometry.Vector{X: x, Y: leagueTableY},
+		Color:    theme.Active().Highlight,
+	}).Draw(screen)
+
+	next, _ := l.Schedule.Next()
+
+	for i, f := range l.Schedule.Round(round) {
+		line := fmt.Sprintf("%s vs %s", f.Home, f.Away)
+		if f.Played {
+			line = fmt.Sprintf("%s %d-%d %s", f.Home, f.HomeScore, f.AwayScore, f.Away)
+		}
+
+		clr := theme.Active().Text
+		if !f.Played && f.ID == next.ID {
+			clr = theme.Active().Highlight
+		}
+
+		(&ui.Text{
+			Value:    line,
+			FontFace: face,
+			Position: geometry.Vector{X: x, Y: leagueTableY + float64(i+1)*fixturesLineHeight},
+			Color:    clr,
+		}).Draw(screen)
+	}
+}
+
+// fixturesX returns where the fixtures of the round shown are drawn.
+func (s *leagueState) fixturesX() float64 {
+	return s.menu.screenWidth() - fixturesWidth
+}
+
+// String returns the state name.
+func (*leagueState) String() string {
+	return "leagueState"
+}
@@ -0,0 +1,86 @@
+package menu
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/league"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/roundrobin"
+)
+
+const (
+	addPlayerStr    = "Add player"
+	removePlayerStr = "Remove last player"
+)
+
+// leagueSetupState is the state where the players enter the names of the players of a new league.
+type leagueSetupState struct {
+	*baseState
+	players roster
+}
+
+var _ state = (*leagueSetupState)(nil)
+
+// newLeagueSetupState creates a new leagueSetupState.
+func newLeagueSetupState(menu *Menu) *leagueSetupState {
+	s := &leagueSetupState{
+		baseState: &baseState{menu: menu},
+		players:   roster{max: roundrobin.MaxPlayers},
+	}
+
+	face := menu.face(20)
+
+	s.list = menu.newList(preferencesStartY, preferencesLineSpacing,
+		s.players.input(addPlayerStr, face),
+		ui.NewOption(removePlayerStr, face, s.players.removeLast),
+		ui.NewOption(startStr, face, s.start),
+		ui.NewOption(backStr, face, s.back),
+	)
+
+	return s
+}
+
+// Update updates the state.
+func (s *leagueSetupState) Update() {
+	s.update(s.back)
+}
+
+// back returns to the local mode menu.
+func (s *leagueSetupState) back() {
+	s.menu.ChangeState(newLocalModeState(s.menu))
+}
+
+// start schedules the league and shows its standings.
+func (s *leagueSetupState) start() {
+	l, err := league.New(slices.Clone(s.players.names))
+	if err != nil {
+		slog.Error("failed to create league", slog.Any("error", err))
+		s.players.message = "Enter at least two players"
+
+		return
+	}
+
+	if err := l.Save(); err != nil {
+		slog.Error("failed to save league", slog.Any("error", err))
+	}
+
+	s.menu.league = l
+	s.menu.ChangeState(newLeagueState(s.menu))
+}
+
+// Draw draws the state.
+func (s *leagueSetupState) Draw(screen *ebiten.Image) {
+	hint := fmt.Sprintf("%d players, each playing every other once", len(s.players.names))
+	s.players.draw(screen, s.menu.face(14), hint)
+
+	s.baseState.Draw(screen)
+}
+
+// String returns the state name.
+func (*leagueSetupState) String() string {
+	return "leagueSetupState"
+}
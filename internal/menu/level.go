@@ -1,9 +1,7 @@
 package menu
 
 import (
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
-
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
 )
 
@@ -14,49 +12,43 @@ type levelSelectionState struct {
 
 var _ state = (*levelSelectionState)(nil)
 
-// newLevelSelectionState creates a new levelSelectionState.
+// newLevelSelectionState creates a new levelSelectionState, selecting the level chosen last time.
 func newLevelSelectionState(menu *Menu) *levelSelectionState {
-	return &levelSelectionState{
-		baseState: &baseState{
-			menu: menu,
-			options: []string{
-				level.Easy.String(),
-				level.Medium.String(),
-				level.Hard.String(),
-				backStr,
-			},
-		},
+	s := &levelSelectionState{baseState: &baseState{menu: menu}}
+
+	face := menu.face(20)
+	option := func(lvl level.Level) ui.Widget {
+		return ui.NewOption(lvl.String(), face, func() { s.play(lvl) })
 	}
+
+	s.list = s.options(
+		option(level.Easy),
+		option(level.Medium),
+		option(level.Hard),
+		ui.NewOption(backStr, face, s.back),
+	)
+	s.list.SetFocus(int(menu.level))
+
+	return s
 }
 
 // Update updates the state.
 func (s *levelSelectionState) Update() {
-	s.navigateOptions(len(s.options))
-
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-		switch s.selectedOption {
-		case 0:
-			s.menu.level = level.Easy
-			s.menu.readyToPlay = true
-		case 1:
-			s.menu.level = level.Medium
-			s.menu.readyToPlay = true
-		case 2:
-			s.menu.level = level.Hard
-			s.menu.readyToPlay = true
-		case 3:
-			s.menu.ChangeState(newMainMenuState(s.menu))
-		}
-	}
+	s.update(s.back)
+}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		s.menu.ChangeState(newMainMenuState(s.menu))
-	}
+// back returns to the main menu.
+func (s *levelSelectionState) back() {
+	s.menu.ChangeState(newMainMenuState(s.menu))
 }
 
-// Draw draws the state.
-func (s *levelSelectionState) Draw(screen *ebiten.Image) {
-	s.drawOptions(screen)
+// play starts the match at the given level, remembering it for the next time.
+func (s *levelSelectionState) play(lvl level.Level) {
+	s.menu.level = lvl
+	s.menu.readyToPlay = true
+
+	s.menu.settings.Level = lvl
+	s.menu.saveSettings()
 }
 
 // String returns the state name.
@@ -1,17 +1,18 @@
 package menu
 
 import (
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
 )
 
 const (
 	onePlayerStr  = "One Player"
 	twoPlayersStr = "Two Players"
+	leagueStr     = "League"
 	backStr       = "Back"
 )
 
-// localModeState is the state where the player can select between one or two players.
+// localModeState is the state where the player can select between one or two players,
+// a league of matches between two players or a training drill.
 type localModeState struct {
 	*baseState
 }
@@ -20,39 +21,35 @@ var _ state = (*localModeState)(nil)
 
 // newLocalModeState creates a new localModeState.
 func newLocalModeState(menu *Menu) *localModeState {
-	return &localModeState{
-		baseState: &baseState{
-			menu:    menu,
-			options: []string{onePlayerStr, twoPlayersStr, backStr},
-		},
-	}
+	s := &localModeState{baseState: &baseState{menu: menu}}
+
+	face := menu.face(20)
+
+	s.list = s.options(
+		ui.NewOption(onePlayerStr, face, func() {
+			menu.gameMode = OnePlayer
+			menu.ChangeState(newLevelSelectionState(menu))
+		}),
+		ui.NewOption(twoPlayersStr, face, func() {
+			menu.gameMode = TwoPlayers
+			menu.ChangeState(newTwoPlayersInstructionsState(menu))
+		}),
+		ui.NewOption(leagueStr, face, menu.ShowLeague),
+		ui.NewOption(trainingStr, face, func() { menu.ChangeState(newTrainingState(menu)) }),
+		ui.NewOption(backStr, face, s.back),
+	)
+
+	return s
 }
 
 // Update updates the state.
 func (s *localModeState) Update() {
-	s.navigateOptions(len(s.options))
-
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-		switch s.selectedOption {
-		case 0:
-			s.menu.gameMode = OnePlayer
-			s.menu.ChangeState(newLevelSelectionState(s.menu))
-		case 1:
-			s.menu.gameMode = TwoPlayers
-			s.menu.ChangeState(newTwoPlayersInstructionsState(s.menu))
-		case 2:
-			s.menu.ChangeState(newMainMenuState(s.menu))
-		}
-	}
-
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		s.menu.ChangeState(newMainMenuState(s.menu))
-	}
+	s.update(s.back)
 }
 
-// Draw draws the state.
-func (s *localModeState) Draw(screen *ebiten.Image) {
-	s.drawOptions(screen)
+// back returns to the main menu.
+func (s *localModeState) back() {
+	s.menu.ChangeState(newMainMenuState(s.menu))
 }
 
 func (*localModeState) String() string {
@@ -1,18 +1,21 @@
 package menu
 
 import (
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
 )
 
 const (
-	localModeStr    = "Local Mode"
-	multiplayerStr  = "Multiplayer"
-	spectateStr     = "Watch"
-	instructionsStr = "Instructions"
+	localModeStr     = "Local Mode"
+	multiplayerStr   = "Multiplayer"
+	spectateStr      = "Watch"
+	matchSettingsStr = "Match Settings"
+	settingsStr      = "Settings"
+	controlsStr      = "Controls"
+	instructionsStr  = "Instructions"
 )
 
-// mainMenuState is the state where the player can select between local mode, multiplayer or see the instructions.
+// mainMenuState is the state where the player can select between the game modes, tournaments, the stats,
+// the leaderboard, the settings or the instructions.
 type mainMenuState struct {
 	*baseState
 }
@@ -21,41 +24,40 @@ var _ state = (*mainMenuState)(nil)
 
 // newMainMenuState creates a new mainMenuState.
 func newMainMenuState(menu *Menu) *mainMenuState {
-	return &mainMenuState{
-		baseState: &baseState{
-			menu:    menu,
-			options: []string{localModeStr, multiplayerStr, spectateStr, instructionsStr},
-		},
+	s := &mainMenuState{baseState: &baseState{menu: menu}}
+
+	face := menu.face(20)
+	open := func(next func() state) func() {
+		return func() { menu.ChangeState(next()) }
 	}
+
+	s.list = s.options(
+		ui.NewOption(localModeStr, face, open(func() state { return newLocalModeState(menu) })),
+		ui.NewOption(multiplayerStr, face, open(func() state { return newInputNameState(menu) })),
+		ui.NewOption(spectateStr, face, open(func() state { return newSpectateSessionsState(menu) })),
+		ui.NewOption(tournamentStr, face, menu.ShowTournament),
+		ui.NewOption(matchSettingsStr, face, open(func() state { return newMatchSettingsState(menu) })),
+		ui.NewOption(statsStr, face, open(func() state { return newStatsState(menu) })),
+		ui.NewOption(leaderboardStr, face, open(func() state { return newLeaderboardState(menu) })),
+		ui.NewOption(settingsStr, face, open(func() state { return newSettingsState(menu) })),
+		ui.NewOption(instructionsStr, face, open(func() state { return newInstructionsState(menu) })),
+	)
+
+	return s
 }
 
 // Update updates the state.
 func (s *mainMenuState) Update() {
-	s.navigateOptions(len(s.options))
-
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-		switch s.selectedOption {
-		case 0:
-			s.menu.ChangeState(newLocalModeState(s.menu))
-		case 1:
-			s.menu.ChangeState(newInputNameState(s.menu))
-		case 2:
-			s.menu.ChangeState(newSpectateSessionsState(s.menu))
-		case 3:
-			s.menu.ChangeState(newInstructionsState(s.menu))
-		}
-	}
-
-	// return to quit the game
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		s.menu.gameMode = Undefined
-		s.menu.readyToPlay = true
-	}
+	// back asks to quit the game
+	s.update(func() {
+		s.showDialog("Quit the game?", s.quit)
+	})
 }
 
-// Draw draws the state.
-func (s *mainMenuState) Draw(screen *ebiten.Image) {
-	s.drawOptions(screen)
+// quit quits the game.
+func (s *mainMenuState) quit() {
+	s.menu.gameMode = Undefined
+	s.menu.readyToPlay = true
 }
 
 // String returns the state name.
@@ -0,0 +1,205 @@
+package menu
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+)
+
+const (
+	modeStr         = "Mode"
+	arenaStr        = "Arena"
+	formatStr       = "Format"
+	classicStr      = "Classic"
+	arcadeStr       = "Arcade"
+	scoreLimitStr   = "Score limit"
+	winByTwoStr     = "Win by two"
+	timeLimitStr    = "Time limit"
+	serveStr        = "Serve"
+	ballSpeedStr    = "Ball speed"
+	maxBallSpeedStr = "Max ball speed"
+	offStr          = "Off"
+	onStr           = "On"
+
+	settingsStartY      = 160.0
+	settingsLineSpacing = 27.0
+)
+
+// nolint:gochecknoglobals
+var (
+	scoreLimits    = []int{0, 3, 5, 7, 10, 11, 15, 21}
+	timeLimits     = []time.Duration{0, time.Minute, 2 * time.Minute, 3 * time.Minute, 5 * time.Minute, 10 * time.Minute}
+	ballSpeeds     = []float64{90, rules.DefaultInitialBallSpeed, 180, 240}
+	maxBallSpeeds  = []float64{360, rules.DefaultMaxBallSpeed, 600, 720}
+	ballSpeedNames = []string{"Slow", "Normal", "Fast", "Insane"}
+)
+
+// matchSettingsState is the state where the player can edit the match rules.
+type matchSettingsState struct {
+	*baseState
+}
+
+var _ state = (*matchSettingsState)(nil)
+
+// newMatchSettingsState creates a new matchSettingsState.
+func newMatchSettingsState(menu *Menu) *matchSettingsState {
+	s := &matchSettingsState{baseState: &baseState{menu: menu}}
+
+	face := menu.face(20)
+	options := []string{
+		modeStr, formatStr, arenaStr, scoreLimitStr, winByTwoStr, timeLimitStr, serveStr, ballSpeedStr, maxBallSpeedStr,
+	}
+
+	items := make([]ui.Widget, 0, len(options)+2)
+
+	for _, option := range options {
+		items = append(items, ui.NewChoice(
+			option,
+			face,
+			func() string { return s.value(option) },
+			func(dir int) { s.change(option, dir) },
+		))
+	}
+
+	items = append(items,
+		ui.NewOption(handicapsStr, face, func() { menu.ChangeState(newHandicapsState(menu)) }),
+		ui.NewOption(backStr, face, s.back),
+	)
+
+	s.list = s.preferences(settingsStartY, settingsLineSpacing, "", items...)
+
+	return s
+}
+
+// Update updates the state.
+func (s *matchSettingsState) Update() {
+	s.update(s.back)
+}
+
+// String returns the state name.
+func (*matchSettingsState) String() string {
+	return "matchSettingsState"
+}
+
+// back returns to the main menu if the rules are valid.
+func (s *matchSettingsState) back() {
+	if err := s.menu.rules.Validate(); err != nil {
+		s.setMessage("Set a score or a time limit")
+		return
+	}
+
+	s.setMessage("")
+	s.menu.ChangeState(newMainMenuState(s.menu))
+}
+
+// change moves the value of the option by dir steps.
+func (s *matchSettingsState) change(option string, dir int) {
+	r := &s.menu.rules
+
+	switch option {
+	case modeStr:
+		r.Arcade = !r.Arcade
+	case formatStr:
+		r.Format = cycle([]rules.Format{rules.Singles, rules.Doubles, rules.FourWay}, r.Format, dir)
+	case arenaStr:
+		s.changeArena(dir)
+	case scoreLimitStr:
+		r.ScoreLimit = cycle(scoreLimits, r.ScoreLimit, dir)
+	case winByTwoStr:
+		r.WinByTwo = !r.WinByTwo
+	case timeLimitStr:
+		r.TimeLimit = cycle(timeLimits, r.TimeLimit, dir)
+	case serveStr:
+		r.Serve = cycle([]rules.ServeRule{rules.ServeAlternate, rules.ServeToLoser}, r.Serve, dir)
+	case ballSpeedStr:
+		r.InitialBallSpeed = cycle(ballSpeeds, r.InitialBallSpeed, dir)
+		r.MaxBallSpeed = max(r.MaxBallSpeed, r.InitialBallSpeed)
+	case maxBallSpeedStr:
+		r.MaxBallSpeed = cycle(maxBallSpeeds, r.MaxBallSpeed, dir)
+		r.InitialBallSpeed = min(r.MaxBallSpeed, r.InitialBallSpeed)
+	}
+}
+
+// changeArena selects the arena dir steps away from the current one, wrapping around.
+func (s *matchSettingsState) changeArena(dir int) {
+	arenas := s.menu.arenas
+
+	i := slices.IndexFunc(arenas, func(a arena.Arena) bool {
+		return a.Name == s.menu.arena.Name
+	})
+
+	s.menu.arena = arenas[(i+dir+len(arenas))%len(arenas)]
+}
+
+// value returns the current value of the option as a string.
+func (s *matchSettingsState) value(option string) string {
+	r := s.menu.rules
+
+	switch option {
+	case modeStr:
+		if r.Arcade {
+			return arcadeStr
+		}
+
+		return classicStr
+	case formatStr:
+		return r.Format.String()
+	case arenaStr:
+		return s.menu.arena.Name
+	case scoreLimitStr:
+		if r.ScoreLimit == 0 {
+			return offStr
+		}
+
+		return fmt.Sprint(r.ScoreLimit)
+	case winByTwoStr:
+		return onOff(r.WinByTwo)
+	case timeLimitStr:
+		if r.TimeLimit == 0 {
+			return offStr
+		}
+
+		return fmt.Sprintf("%d min", int(r.TimeLimit.Minutes()))
+	case serveStr:
+		return r.Serve.String()
+	case ballSpeedStr:
+		return speedName(ballSpeeds, r.InitialBallSpeed)
+	case maxBallSpeedStr:
+		return speedName(maxBallSpeeds, r.MaxBallSpeed)
+	}
+
+	return ""
+}
+
+// speedName returns the name of the speed in the given list of speeds.
+func speedName(speeds []float64, speed float64) string {
+	if i := slices.Index(speeds, speed); i >= 0 {
+		return ballSpeedNames[i]
+	}
+
+	return fmt.Sprintf("%.f", speed)
+}
+
+// onOff returns On or Off depending on the value of a setting.
+func onOff(value bool) string {
+	if value {
+		return onStr
+	}
+
+	return offStr
+}
+
+// cycle returns the value dir steps away from current in values, wrapping around.
+// If current isn't in values, it returns the first value.
+func cycle[T comparable](values []T, current T, dir int) T {
+	i := slices.Index(values, current)
+	if i < 0 {
+		return values[0]
+	}
+
+	return values[(i+dir+len(values))%len(values)]
+}
@@ -1,9 +1,31 @@
 package menu
 
 import (
+	"log/slog"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
 	"github.com/gandarez/pong-multiplayer-go/internal/font"
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
+	"github.com/gandarez/pong-multiplayer-go/internal/league"
+	"github.com/gandarez/pong-multiplayer-go/internal/profile"
+	"github.com/gandarez/pong-multiplayer-go/internal/settings"
+	"github.com/gandarez/pong-multiplayer-go/internal/tournament"
+	"github.com/gandarez/pong-multiplayer-go/internal/training"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/bracket"
+	"github.com/gandarez/pong-multiplayer-go/pkg/drill"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
-	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+	"github.com/gandarez/pong-multiplayer-go/pkg/roundrobin"
 )
 
 // GameMode is the game mode.
@@ -20,32 +42,74 @@ const (
 	Multiplayer
 	// Spectator represents a spectator game mode.
 	Spectator
+	// Training represents a training drill, a launcher serving balls at a single player.
+	Training
 )
 
 // Menu represents the game menu.
 type Menu struct {
-	font         *font.Font
-	gameMode     GameMode
-	level        level.Level
-	readyToPlay  bool
-	playerName   string
-	screenHeight int
-	screenWidth  int
+	font     *font.Font
+	audio    *audio.Player
+	controls *input.Controls
+	settings *settings.Settings
+	profiles *profile.Store
+	// training is the history of the drills played, drills are the drills the player can choose from
+	// and drill is the one chosen.
+	training *training.History
+	drills   []drill.Drill
+	drill    drill.Drill
+	// tournament is the tournament run on this device, nil when none is.
+	tournament *tournament.Tournament
+	// tournamentMatch is the tournament match chosen to be played or watched, nil for other matches.
+	tournamentMatch *bracket.Match
+	// league is the league played on this device, nil when none is.
+	league *league.League
+	// leagueFixture is the league fixture chosen to be played, nil for other matches.
+	leagueFixture *roundrobin.Fixture
+	gameMode      GameMode
+	level         level.Level
+	rules         rules.Rules
+	arenas        []arena.Arena
+	arena         arena.Arena
+	readyToPlay   bool
+	playerName    string
+	// display is the screen the menu is drawn on, whose width follows the aspect ratio chosen.
+	display      *display.Screen
 	currentState state
 
 	// states act as a cache to avoid creating the same state multiple times.
 	states    map[string]state
 	SessionID string
+	// ReplayID is the replay to watch as a spectator, empty to watch the live session SessionID.
+	ReplayID string
 }
 
 // New creates a new game menu.
-func New(font *font.Font, screenWidth, screenHeight int) *Menu {
+// controls are the bindings of the actions, which can be changed in the menu, and the
+// connected gamepads, which can be assigned to local players. userSettings are the saved
+// preferences of the user, edited in the menu. player plays the sounds of the menu and
+// follows the changes made to the audio settings, it can be nil. screen is the screen the menu
+// is drawn on, which follows the changes made to the display settings.
+func New(
+	font *font.Font,
+	player *audio.Player,
+	controls *input.Controls,
+	userSettings *settings.Settings,
+	screen *display.Screen,
+) *Menu {
 	menu := &Menu{
-		font:         font,
-		gameMode:     Undefined,
-		screenWidth:  screenWidth,
-		screenHeight: screenHeight,
-		states:       make(map[string]state),
+		font:       font,
+		audio:      player,
+		controls:   controls,
+		settings:   userSettings,
+		gameMode:   Undefined,
+		level:      userSettings.Level,
+		playerName: userSettings.PlayerName,
+		rules:      rules.Default(),
+		arenas:     []arena.Arena{arena.Classic()},
+		arena:      arena.Classic(),
+		display:    screen,
+		states:     make(map[string]state),
 	}
 
 	menu.ChangeState(newMainMenuState(menu))
@@ -55,6 +119,10 @@ func New(font *font.Font, screenWidth, screenHeight int) *Menu {
 
 // ChangeState changes the current state of the menu.
 func (m *Menu) ChangeState(state state) {
+	if m.currentState != nil {
+		m.audio.Play(audio.MenuSelect)
+	}
+
 	if st, ok := m.states[state.String()]; ok {
 		m.currentState = st
 		return
@@ -72,6 +140,113 @@ func (m *Menu) Update() {
 // Draw draws the menu.
 func (m *Menu) Draw(screen *ebiten.Image) {
 	m.currentState.Draw(screen)
+
+	// every state but the main menu can go back with the on-screen button
+	if _, ok := m.currentState.(*mainMenuState); ok {
+		return
+	}
+
+	if button := m.backButton(); button != nil {
+		button.Draw(screen)
+	}
+}
+
+// buttonFace returns the font face of the on-screen buttons.
+func (m *Menu) buttonFace() text.Face {
+	return m.face(16)
+}
+
+// face returns the font face of the menu of the size, nil if it couldn't be created.
+func (m *Menu) face(size float64) text.Face {
+	face, err := m.font.Face("ui", size)
+	if err != nil {
+		slog.Error("failed to create text face", slog.Any("error", err))
+		return nil
+	}
+
+	return face
+}
+
+// newList creates a list of widgets from the Y position y, spacing pixels between them,
+// playing a sound when the focus moves.
+func (m *Menu) newList(y, spacing float64, items ...ui.Widget) *ui.List {
+	list := ui.NewList(y, spacing, items...)
+	list.OnMove = func() {
+		m.audio.Play(audio.MenuMove)
+	}
+
+	return list
+}
+
+// saveSettings saves the settings of the user. It returns false if they couldn't be saved.
+func (m *Menu) saveSettings() bool {
+	if err := m.settings.Save(); err != nil {
+		slog.Error("failed to save settings", slog.Any("error", err))
+		return false
+	}
+
+	return true
+}
+
+// backButton returns the on-screen button going back to the previous state.
+func (m *Menu) backButton() *ui.Button {
+	face := m.buttonFace()
+	if face == nil {
+		return nil
+	}
+
+	return ui.NewBackButton(face)
+}
+
+// backPressed returns true if Esc was just pressed or the back button was just tapped.
+func (m *Menu) backPressed() bool {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		return true
+	}
+
+	button := m.backButton()
+	pos, ok := m.controls.Pointer().Tap()
+
+	return ok && button != nil && button.Contains(pos)
+}
+
+// input reads the input of the frame. Tapping the back button counts as Back.
+func (m *Menu) input() *ui.Input {
+	in := ui.ReadInput(m.controls.Pointer())
+
+	if _, ok := m.currentState.(*mainMenuState); ok || !in.Tapped {
+		return in
+	}
+
+	if button := m.backButton(); button != nil && button.Contains(in.Tap) {
+		in.Back, in.Tapped = true, false
+	}
+
+	return in
+}
+
+// screenWidth returns the width of the screen, which follows its aspect ratio.
+func (m *Menu) screenWidth() float64 {
+	return m.display.Width()
+}
+
+// screenHeight returns the height of the screen.
+func (*Menu) screenHeight() float64 {
+	return display.Height
+}
+
+// tap returns the position just tapped or clicked, ignoring taps on the back button.
+func (m *Menu) tap() (geometry.Vector, bool) {
+	pos, ok := m.controls.Pointer().Tap()
+	if !ok {
+		return geometry.Vector{}, false
+	}
+
+	if button := m.backButton(); button != nil && button.Contains(pos) {
+		return geometry.Vector{}, false
+	}
+
+	return pos, true
 }
 
 // IsReadyToPlay returns if the game is ready to play.
@@ -89,6 +264,161 @@ func (m *Menu) Level() level.Level {
 	return m.level
 }
 
+// Rules returns the match rules.
+func (m *Menu) Rules() rules.Rules {
+	return m.rules
+}
+
+// SetRules sets the match rules.
+func (m *Menu) SetRules(r rules.Rules) {
+	m.rules = r
+}
+
+// Arena returns the selected arena.
+func (m *Menu) Arena() arena.Arena {
+	return m.arena
+}
+
+// SetArena selects the arena to play local matches in.
+func (m *Menu) SetArena(a arena.Arena) {
+	m.arena = a
+}
+
+// Arenas returns the arenas the player can choose from.
+func (m *Menu) Arenas() []arena.Arena {
+	return m.arenas
+}
+
+// SetArenas sets the arenas the player can choose from and selects the first one.
+func (m *Menu) SetArenas(arenas []arena.Arena) {
+	if len(arenas) == 0 {
+		return
+	}
+
+	m.arenas = arenas
+	m.arena = arenas[0]
+}
+
+// Profiles returns the profiles browsed in the stats menu.
+func (m *Menu) Profiles() *profile.Store {
+	return m.profiles
+}
+
+// SetProfiles sets the profiles browsed in the stats menu.
+func (m *Menu) SetProfiles(profiles *profile.Store) {
+	m.profiles = profiles
+}
+
+// Training returns the history of the drills played.
+func (m *Menu) Training() *training.History {
+	return m.training
+}
+
+// SetTraining sets the history of the drills played.
+func (m *Menu) SetTraining(history *training.History) {
+	m.training = history
+}
+
+// Drills returns the drills the player can choose from.
+func (m *Menu) Drills() []drill.Drill {
+	return m.drills
+}
+
+// SetDrills sets the drills the player can choose from.
+func (m *Menu) SetDrills(drills []drill.Drill) {
+	m.drills = drills
+}
+
+// Drill returns the drill chosen.
+func (m *Menu) Drill() drill.Drill {
+	return m.drill
+}
+
+// ShowTraining shows the drills the player can choose from, the drill with the name chosen.
+func (m *Menu) ShowTraining(name string) {
+	s := newTrainingState(m)
+	s.drill = max(slices.IndexFunc(m.drills, func(d drill.Drill) bool { return d.Name == name }), 0)
+
+	m.ChangeState(s)
+}
+
+// Tournament returns the tournament run on this device, nil when none is.
+func (m *Menu) Tournament() *tournament.Tournament {
+	return m.tournament
+}
+
+// SetTournament sets the tournament run on this device, nil when none is.
+func (m *Menu) SetTournament(t *tournament.Tournament) {
+	m.tournament = t
+}
+
+// TournamentMatch returns the tournament match chosen to be played or watched,
+// false when the match isn't part of the tournament.
+func (m *Menu) TournamentMatch() (bracket.Match, bool) {
+	if m.tournamentMatch == nil {
+		return bracket.Match{}, false
+	}
+
+	return *m.tournamentMatch, true
+}
+
+// League returns the league played on this device, nil when none is.
+func (m *Menu) League() *league.League {
+	return m.league
+}
+
+// SetLeague sets the league played on this device, nil when none is.
+func (m *Menu) SetLeague(l *league.League) {
+	m.league = l
+}
+
+// LeagueFixture returns the league fixture chosen to be played, false when the match
+// isn't part of the league.
+func (m *Menu) LeagueFixture() (roundrobin.Fixture, bool) {
+	if m.leagueFixture == nil {
+		return roundrobin.Fixture{}, false
+	}
+
+	return *m.leagueFixture, true
+}
+
+// MatchRules returns the rules of the match chosen: the match rules, tournament matches
+// and league fixtures being played in singles. Drills are played in singles without power-ups
+// nor a clock, the balls keeping up to the speed they're served at.
+func (m *Menu) MatchRules() rules.Rules {
+	r := m.rules
+	if m.gameMode == Training {
+		r.Format, r.Arcade, r.TimeLimit = rules.Singles, false, 0
+		r.MaxBallSpeed = max(r.MaxBallSpeed, m.drill.Speed.Max)
+	}
+
+	if m.tournamentMatch != nil || m.leagueFixture != nil {
+		r.Format = rules.Singles
+	}
+
+	return r
+}
+
+// Handicap returns the handicap of the player at the place i of the lineup of the match chosen.
+// Tournament matches and league fixtures are played without handicaps.
+func (m *Menu) Handicap(i int) player.Handicap {
+	if m.tournamentMatch != nil || m.leagueFixture != nil {
+		return player.NoHandicap
+	}
+
+	return m.settings.Handicap(i)
+}
+
+// ShowTournament shows the bracket of the tournament, or its setup when none is run.
+func (m *Menu) ShowTournament() {
+	m.ChangeState(newTournamentState(m))
+}
+
+// ShowLeague shows the standings of the league, or its setup when none is played.
+func (m *Menu) ShowLeague() {
+	m.ChangeState(newLeagueState(m))
+}
+
 // PlayerName returns the given player name.
 // This is only used in the multiplayer game mode.
 func (m *Menu) PlayerName() string {
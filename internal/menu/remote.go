@@ -0,0 +1,42 @@
+package menu
+
+import (
+	"context"
+	"sync"
+)
+
+// remote holds data fetched from the server in the background, so the menu keeps responding
+// while waiting for it.
+type remote[T any] struct {
+	mu      sync.Mutex
+	data    T
+	loading bool
+	err     error
+}
+
+// fetch fetches the data in the background, replacing the data fetched before once done.
+func (r *remote[T]) fetch(fetch func(ctx context.Context) (T, error)) {
+	r.mu.Lock()
+	r.loading, r.err = true, nil
+	r.mu.Unlock()
+
+	go func() {
+		data, err := fetch(context.Background())
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.loading, r.err = false, err
+		if err == nil {
+			r.data = data
+		}
+	}()
+}
+
+// get returns the data last fetched, whether it's being fetched and the error of the last fetch.
+func (r *remote[T]) get() (T, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.data, r.loading, r.err
+}
@@ -0,0 +1,97 @@
+package menu
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	// rosterStartY is where the names of a roster are listed, in rosterColumns columns.
+	rosterStartY      = 300.0
+	rosterLineSpacing = 16.0
+	rosterColumns     = 4
+)
+
+// roster is a list of names entered one at a time, such as the entrants of a tournament
+// or the players of a league.
+type roster struct {
+	names []string
+	// max is the largest number of names.
+	max     int
+	message string
+}
+
+// input returns the row of a menu where new names are typed, following the rules of player names,
+// and added on Enter.
+func (r *roster) input(label string, face text.Face) *ui.TextInput {
+	input := ui.NewTextInput(label, face, "", maxNameLength)
+	input.Accept = validNameChar
+	input.OnChange = func(string) {
+		r.message = ""
+	}
+	input.OnSubmit = func(name string) {
+		if name == "" {
+			return
+		}
+
+		input.Value = ""
+		r.add(name)
+	}
+
+	return input
+}
+
+// removeLast removes the last name entered.
+func (r *roster) removeLast() {
+	r.message = ""
+
+	if len(r.names) > 0 {
+		r.names = r.names[:len(r.names)-1]
+	}
+}
+
+// add adds a name, refusing the names already entered and the names past the largest number.
+func (r *roster) add(name string) {
+	switch {
+	case len(r.names) >= r.max:
+		r.message = fmt.Sprintf("No more than %d names", r.max)
+	case slices.ContainsFunc(r.names, func(n string) bool { return strings.EqualFold(n, name) }):
+		r.message = name + " already entered"
+	default:
+		r.message = ""
+		r.names = append(r.names, name)
+	}
+}
+
+// draw draws the message, or the hint when there's none, above the names in columns.
+func (r *roster) draw(screen *ebiten.Image, face text.Face, hint string) {
+	message := r.message
+	if message == "" {
+		message = hint
+	}
+
+	ui.DrawCentered(screen, message, face, rosterStartY-30, theme.Active().Text)
+
+	width := float64(screen.Bounds().Dx()) / rosterColumns
+	rows := (r.max + rosterColumns - 1) / rosterColumns
+
+	for i, name := range r.names {
+		(&ui.Text{
+			Value:    fmt.Sprintf("%d. %s", i+1, name),
+			FontFace: face,
+			Position: geometry.Vector{
+				X: float64(i/rows)*width + 30,
+				Y: rosterStartY + float64(i%rows)*rosterLineSpacing,
+			},
+			Color: theme.Active().Text,
+		}).Draw(screen)
+	}
+}
@@ -0,0 +1,142 @@
+package menu
+
+import (
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/settings"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
+)
+
+const (
+	playerNameStr    = "Player name"
+	onlineAccountStr = "Online account"
+	levelStr         = "Default level"
+	showMetricsStr   = "Show metrics"
+	effectsStr       = "Sound effects"
+	effectsVolumeStr = "Effects volume"
+	musicVolumeStr   = "Music volume"
+	muteStr          = "Mute"
+	resetSettingsStr = "Reset settings"
+
+	preferencesStartY      = 130.0
+	preferencesLineSpacing = 25.0
+
+	// volumeSteps is the number of steps between silence and the full volume.
+	volumeSteps = 10
+)
+
+// settingsState is the state where the player can edit the settings saved between sessions.
+type settingsState struct {
+	*baseState
+}
+
+var _ state = (*settingsState)(nil)
+
+// newSettingsState creates a new settingsState.
+func newSettingsState(menu *Menu) *settingsState {
+	s := &settingsState{baseState: &baseState{menu: menu}}
+
+	face := menu.face(20)
+	userSettings := menu.settings
+	open := func(next func() state) func() {
+		return func() {
+			s.setMessage("")
+			menu.ChangeState(next())
+		}
+	}
+	toggle := func(label string, value *bool) ui.Widget {
+		return ui.NewToggle(label, face, func() bool { return *value }, func(on bool) {
+			*value = on
+			s.changed()
+		})
+	}
+	volume := func(label string, value *float64) ui.Widget {
+		slider := ui.NewSlider(label, face, func() float64 { return *value }, func(v float64) {
+			*value = v
+			s.changed()
+		})
+		slider.Steps = volumeSteps
+
+		return slider
+	}
+
+	s.list = s.preferences(preferencesStartY, preferencesLineSpacing, "",
+		ui.NewChoice(playerNameStr, face, s.playerName, func(int) {
+			menu.ChangeState(newEditNameState(menu))
+		}),
+		toggle(onlineAccountStr, &userSettings.OnlineAccount),
+		ui.NewChoice(levelStr, face, func() string { return userSettings.Level.String() }, func(dir int) {
+			userSettings.Level = cycle([]level.Level{level.Easy, level.Medium, level.Hard}, userSettings.Level, dir)
+			menu.level = userSettings.Level
+			s.changed()
+		}),
+		toggle(showMetricsStr, &userSettings.ShowMetrics),
+		ui.NewOption(displayStr, face, open(func() state { return newDisplayState(menu) })),
+		toggle(effectsStr, &userSettings.Audio.Effects),
+		volume(effectsVolumeStr, &userSettings.Audio.EffectsVolume),
+		volume(musicVolumeStr, &userSettings.Audio.MusicVolume),
+		toggle(muteStr, &userSettings.Audio.Muted),
+		ui.NewOption(visualEffectsStr, face, open(func() state { return newEffectsState(menu) })),
+		ui.NewOption(controlsStr, face, open(func() state { return newControlsState(menu) })),
+		ui.NewOption(resetSettingsStr, face, func() {
+			s.showDialog("Restore the default settings?", s.reset)
+		}),
+		ui.NewOption(backStr, face, s.back),
+	)
+
+	return s
+}
+
+// Update updates the state.
+func (s *settingsState) Update() {
+	s.update(s.back)
+}
+
+// playerName returns the name of the player, a dash when there's none.
+func (s *settingsState) playerName() string {
+	if s.menu.settings.PlayerName == "" {
+		return "-"
+	}
+
+	return s.menu.settings.PlayerName
+}
+
+// changed applies the settings just changed and saves them.
+func (s *settingsState) changed() {
+	s.menu.audio.SetMix(s.menu.settings.Audio)
+	s.menu.audio.Play(audio.MenuMove)
+
+	s.saved(s.menu.saveSettings())
+}
+
+// reset restores the default settings and controls, keeping the size of the window.
+func (s *settingsState) reset() {
+	window := s.menu.settings.Window
+
+	*s.menu.settings = *settings.Default()
+	s.menu.settings.Window = window
+
+	s.menu.display.Apply(s.menu.settings.Display)
+	s.menu.controls.Reset()
+	s.menu.audio.SetMix(s.menu.settings.Audio)
+	s.menu.level = s.menu.settings.Level
+	s.menu.playerName = ""
+
+	if !s.menu.saveSettings() {
+		s.setMessage("Failed to save settings")
+		return
+	}
+
+	s.setMessage("Default settings restored")
+}
+
+// back returns to the main menu.
+func (s *settingsState) back() {
+	s.setMessage("")
+	s.menu.ChangeState(newMainMenuState(s.menu))
+}
+
+// String returns the state name.
+func (*settingsState) String() string {
+	return "settingsState"
+}
@@ -8,10 +8,13 @@ import (
 
 	"github.com/gandarez/pong-multiplayer-go/internal/network"
 	"github.com/gandarez/pong-multiplayer-go/internal/ui"
-	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	// sessionsStartY is the Y position of the first session in the list.
+	sessionsStartY = 200.0
+	// sessionsSpacing is the vertical space between two sessions in the list.
+	sessionsSpacing = 40.0
 )
 
 // sessionInfo represents the information of a session.
@@ -23,18 +26,18 @@ type sessionInfo struct {
 
 // spectateSessionsState represents the state where the player can spectate active sessions.
 type spectateSessionsState struct {
-	menu          *Menu
-	sessions      []sessionInfo
-	selectedIndex int
-	fetched       bool
-	errorMessage  string
+	*baseState
+	fetched bool
 }
 
+var _ state = (*spectateSessionsState)(nil)
+
 // newSpectateSessionsState creates a new spectateSessionsState.
 func newSpectateSessionsState(menu *Menu) *spectateSessionsState {
-	return &spectateSessionsState{
-		menu: menu,
-	}
+	s := &spectateSessionsState{baseState: &baseState{menu: menu}}
+	s.showMessage("Fetching sessions...")
+
+	return s
 }
 
 // Update updates the state.
@@ -43,47 +46,16 @@ func (s *spectateSessionsState) Update() {
 		s.fetchSessions()
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
-		if s.selectedIndex < len(s.sessions)-1 {
-			s.selectedIndex++
-		}
-	}
-
-	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
-		if s.selectedIndex > 0 {
-			s.selectedIndex--
-		}
-	}
-
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && len(s.sessions) > 0 {
-		selectedSession := s.sessions[s.selectedIndex]
-		s.menu.SessionID = selectedSession.ID
-		s.menu.ChangeState(newSpectatorConnectingState(s.menu))
-	}
-
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+	s.update(func() {
 		s.menu.ChangeState(newMainMenuState(s.menu))
-	}
+	})
 }
 
-// Draw draws the state.
-func (s *spectateSessionsState) Draw(screen *ebiten.Image) {
-	if s.errorMessage != "" {
-		s.drawErrorMessage(screen)
-		return
-	}
-
-	if !s.fetched {
-		s.drawFetchingMessage(screen)
-		return
-	}
-
-	if len(s.sessions) == 0 {
-		s.drawNoSessionsMessage(screen)
-		return
-	}
-
-	s.drawSessionsList(screen)
+// watch starts watching the session.
+func (s *spectateSessionsState) watch(session sessionInfo) {
+	s.menu.SessionID = session.ID
+	s.menu.ReplayID = ""
+	s.menu.ChangeState(newSpectatorConnectingState(s.menu))
 }
 
 // String returns the string representation of the state.
@@ -91,111 +63,36 @@ func (*spectateSessionsState) String() string {
 	return "spectateSessionsState"
 }
 
+// fetchSessions fetches the active sessions and lists them.
 func (s *spectateSessionsState) fetchSessions() {
-	sessions, err := fetchSessions()
-	if err != nil {
-		s.errorMessage = "Failed to fetch sessions"
-		return
-	}
-
-	s.sessions = sessions
 	s.fetched = true
-}
 
-func (s *spectateSessionsState) drawFetchingMessage(screen *ebiten.Image) {
-	textFace, err := s.menu.font.Face("ui", 20)
+	sessions, err := fetchSessions()
 	if err != nil {
-		slog.Error("Failed to create text face", slog.Any("error", err))
+		s.showMessage("Failed to fetch sessions")
 		return
 	}
 
-	message := "Fetching sessions..."
-	width, _ := text.Measure(message, textFace, 1)
-	uiText := ui.Text{
-		Value:    message,
-		FontFace: textFace,
-		Position: geometry.Vector{
-			X: (float64(s.menu.screenWidth) - width) / 2,
-			Y: 250.0,
-		},
-		Color: ui.DefaultColor,
-	}
-	uiText.Draw(screen)
-}
-
-func (s *spectateSessionsState) drawNoSessionsMessage(screen *ebiten.Image) {
-	textFace, err := s.menu.font.Face("ui", 20)
-	if err != nil {
-		slog.Error("failed to create text face", slog.Any("error", err))
+	if len(sessions) == 0 {
+		s.showMessage("No active sessions")
 		return
 	}
 
-	message := "No active sessions"
-	width, _ := text.Measure(message, textFace, 1)
-	uiText := ui.Text{
-		Value:    message,
-		FontFace: textFace,
-		Position: geometry.Vector{
-			X: (float64(s.menu.screenWidth) - width) / 2,
-			Y: 250.0,
-		},
-		Color: ui.DefaultColor,
-	}
-	uiText.Draw(screen)
-}
+	face := s.menu.face(20)
+	items := make([]ui.Widget, 0, len(sessions))
 
-func (s *spectateSessionsState) drawErrorMessage(screen *ebiten.Image) {
-	textFace, err := s.menu.font.Face("ui", 20)
-	if err != nil {
-		slog.Error("failed to create text face", slog.Any("error", err))
-		return
+	for _, session := range sessions {
+		items = append(items, ui.NewOption(fmt.Sprintf("%s X %s", session.Player1, session.Player2), face, func() {
+			s.watch(session)
+		}))
 	}
 
-	message := s.errorMessage
-	width, _ := text.Measure(message, textFace, 1)
-	uiText := ui.Text{
-		Value:    message,
-		FontFace: textFace,
-		Position: geometry.Vector{
-			X: (float64(s.menu.screenWidth) - width) / 2,
-			Y: 250.0,
-		},
-		Color: ui.DefaultColor,
-	}
-	uiText.Draw(screen)
+	s.list = s.menu.newList(sessionsStartY, sessionsSpacing, items...)
 }
 
-func (s *spectateSessionsState) drawSessionsList(screen *ebiten.Image) {
-	textFace, err := s.menu.font.Face("ui", 20)
-	if err != nil {
-		slog.Error("failed to create text face", slog.Any("error", err))
-		return
-	}
-
-	y := 200.0
-
-	for i, session := range s.sessions {
-		sessionTitle := fmt.Sprintf("%s X %s", session.Player1, session.Player2)
-		width, _ := text.Measure(sessionTitle, textFace, 1)
-
-		color := ui.DefaultColor
-		if i == s.selectedIndex {
-			color = ui.HighlightColor
-		}
-
-		uiText := ui.Text{
-			Value:    sessionTitle,
-			FontFace: textFace,
-			Position: geometry.Vector{
-				X: (float64(s.menu.screenWidth) - width) / 2,
-				Y: y,
-			},
-			Color: color,
-		}
-		uiText.Draw(screen)
-
-		y += 40.0
-	}
+// showMessage shows the message instead of the sessions.
+func (s *spectateSessionsState) showMessage(message string) {
+	s.list = s.menu.newList(250, sessionsSpacing, ui.NewLabel(message, s.menu.face(20)))
 }
 
 func fetchSessions() ([]sessionInfo, error) {
@@ -1,11 +1,10 @@
 package menu
 
 import (
-	"github.com/gandarez/pong-multiplayer-go/internal/font"
-	"github.com/gandarez/pong-multiplayer-go/internal/ui"
-	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
 )
 
 // spectatorConnectingState is the state that represents the connection to a game as a spectator.
@@ -29,32 +28,10 @@ func (s *spectatorConnectingState) Update() {
 
 // Draw draws the state.
 func (s *spectatorConnectingState) Draw(screen *ebiten.Image) {
-	s.drawConnectingMessage(screen, s.menu.font, float64(s.menu.screenWidth))
+	ui.DrawCentered(screen, "Connecting...", s.menu.face(20), 250, theme.Active().Text)
 }
 
 // String returns the string representation of the state.
 func (*spectatorConnectingState) String() string {
 	return "spectatorConnectingState"
 }
-
-func (*spectatorConnectingState) drawConnectingMessage(screen *ebiten.Image, font *font.Font, screenWidth float64) {
-	textFace, err := font.Face("ui", 20)
-	if err != nil {
-		panic(err)
-	}
-
-	message := "Connecting..."
-	width, _ := text.Measure(message, textFace, 1)
-
-	uiText := ui.Text{
-		Value:    message,
-		FontFace: textFace,
-		Position: geometry.Vector{
-			X: (screenWidth - width) / 2,
-			Y: 250.0,
-		},
-		Color: ui.DefaultColor,
-	}
-
-	uiText.Draw(screen)
-}
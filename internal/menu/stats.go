@@ -0,0 +1,193 @@
+package menu
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/profile"
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+)
+
+const (
+	statsStr   = "Stats"
+	profileStr = "Profile"
+	exportStr  = "Export JSON"
+	onlineStr  = "Online matches"
+
+	// exportFileName is the name of the file the profiles are exported to.
+	exportFileName = "pongo-stats.json"
+
+	statsStartY      = 250.0
+	statsLineSpacing = 16.0
+)
+
+// statsState is the state where the player can browse the lifetime statistics of each profile.
+type statsState struct {
+	*baseState
+	// profile is the name of the profile being shown, lines are its statistics.
+	profile string
+	message string
+	lines   *ui.List
+}
+
+var _ state = (*statsState)(nil)
+
+// newStatsState creates a new statsState showing the profile of the current player.
+func newStatsState(menu *Menu) *statsState {
+	s := &statsState{
+		baseState: &baseState{menu: menu},
+		profile:   menu.playerName,
+	}
+
+	face := menu.face(20)
+
+	s.list = menu.newList(preferencesStartY, preferencesLineSpacing,
+		ui.NewChoice(profileStr, face, s.profileName, s.browse),
+		ui.NewOption(onlineStr, face, s.history),
+		ui.NewOption(exportStr, face, s.export),
+		ui.NewOption(backStr, face, s.back),
+	)
+	s.lines = ui.NewList(statsStartY, statsLineSpacing)
+
+	return s
+}
+
+// Update updates the state.
+func (s *statsState) Update() {
+	in := s.update(s.back)
+
+	var stats []string
+
+	p := s.current()
+
+	switch {
+	case s.message != "":
+		stats = []string{s.message}
+	case p == nil:
+		stats = []string{"No matches played yet"}
+	default:
+		stats = statsLines(p)
+	}
+
+	s.lines.Items = labels(s.menu.face(14), stats...)
+	s.lines.Update(&ui.Input{Scroll: in.Scroll})
+}
+
+// profileName returns the name of the profile being shown, a dash when there's none.
+func (s *statsState) profileName() string {
+	if p := s.current(); p != nil {
+		return p.Name
+	}
+
+	return "-"
+}
+
+// browse shows the profile dir steps away from the current one, in alphabetical order.
+func (s *statsState) browse(dir int) {
+	names := s.names()
+	if len(names) == 0 {
+		return
+	}
+
+	s.profile = cycle(names, s.profile, dir)
+	s.message = ""
+	s.menu.audio.Play(audio.MenuMove)
+}
+
+// names returns the names of the profiles.
+func (s *statsState) names() []string {
+	if s.menu.profiles == nil {
+		return nil
+	}
+
+	return s.menu.profiles.Names()
+}
+
+// current returns the profile being shown, or nil when no profile has matches yet.
+func (s *statsState) current() *profile.Profile {
+	names := s.names()
+	if len(names) == 0 {
+		return nil
+	}
+
+	if !slices.Contains(names, s.profile) {
+		s.profile = names[0]
+	}
+
+	return s.menu.profiles.Profiles[s.profile]
+}
+
+// export saves every profile as JSON for the player to keep or share.
+func (s *statsState) export() {
+	if s.menu.profiles == nil {
+		return
+	}
+
+	data, err := s.menu.profiles.Export()
+	if err != nil {
+		slog.Error("failed to export profiles", slog.Any("error", err))
+		s.message = "Failed to export stats"
+
+		return
+	}
+
+	where, err := storage.Export(exportFileName, data)
+	if err != nil {
+		slog.Error("failed to export profiles", slog.Any("error", err))
+		s.message = "Failed to export stats"
+
+		return
+	}
+
+	s.message = "Exported to " + where
+}
+
+// history lists the online matches of the profile being shown.
+func (s *statsState) history() {
+	if s.current() == nil {
+		return
+	}
+
+	s.menu.ChangeState(newHistoryState(s.menu, s.profile, s))
+}
+
+// back returns to the main menu.
+func (s *statsState) back() {
+	s.message = ""
+	s.menu.ChangeState(newMainMenuState(s.menu))
+}
+
+// statsLines returns the statistics of the profile as displayed to players.
+func statsLines(p *profile.Profile) []string {
+	total := p.Total()
+
+	lines := []string{
+		fmt.Sprintf("Matches: %d   Wins: %d   Losses: %d", total.Played, total.Wins, total.Losses),
+	}
+
+	for _, r := range p.Records {
+		lines = append(lines, fmt.Sprintf("%s %s: %d played, %d won, %d lost", r.Mode, r.Level, r.Played, r.Wins, r.Losses))
+	}
+
+	return append(lines,
+		fmt.Sprintf("Longest rally: %d hits", p.LongestRally),
+		fmt.Sprintf("Fastest ball: %.f", p.FastestBall),
+		fmt.Sprintf("Average point: %.1fs", p.AveragePoint().Seconds()),
+	)
+}
+
+// Draw draws the state.
+func (s *statsState) Draw(screen *ebiten.Image) {
+	s.baseState.Draw(screen)
+	s.lines.Draw(screen)
+}
+
+// String returns the state name.
+func (*statsState) String() string {
+	return "statsState"
+}
@@ -0,0 +1,250 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/tournament"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	abandonStr       = "Abandon tournament"
+	newTournamentStr = "New tournament"
+	playNextStr      = "Play next: "
+	watchNextStr     = "Watch next: "
+
+	// sessionsPollInterval is how often the sessions are fetched while waiting for the entrants
+	// of an online match to start it.
+	sessionsPollInterval = 2 * time.Second
+
+	// bracketTitleY is where the name of the stage shown is drawn, above its bracket.
+	bracketTitleY = 215.0
+)
+
+// tournamentState is the state showing the bracket of the tournament run on this device,
+// a stage at a time, from where its next match is played or watched.
+type tournamentState struct {
+	*baseState
+	// stage is the index of the stage of the bracket shown.
+	stage int
+	// next is the ID of the next match the options were built for, -1 once the tournament is over.
+	next int
+	// waiting is true while looking for the session of the next online match.
+	waiting  bool
+	polledAt time.Time
+	sessions remote[[]sessionInfo]
+}
+
+var _ state = (*tournamentState)(nil)
+
+// newTournamentState creates a new tournamentState, or the setup of a new tournament
+// when none is run.
+func newTournamentState(menu *Menu) state {
+	if menu.tournament == nil {
+		return newTournamentSetupState(menu)
+	}
+
+	s := &tournamentState{baseState: &baseState{menu: menu}}
+	s.build(menu.tournament)
+
+	return s
+}
+
+// Update updates the state.
+func (s *tournamentState) Update() {
+	t := s.menu.tournament
+	if t == nil {
+		s.menu.ChangeState(newTournamentSetupState(s.menu))
+		return
+	}
+
+	// the options change as the tournament goes on
+	if next, ok := t.Bracket.Next(); (ok && next.ID != s.next) || (!ok && s.next >= 0) {
+		s.build(t)
+	}
+
+	in := s.update(func() {
+		s.waiting = false
+		s.menu.ChangeState(newMainMenuState(s.menu))
+	})
+
+	if s.dialog == nil {
+		switch {
+		case in.Left:
+			s.turn(-1)
+		case in.Right:
+			s.turn(1)
+		}
+	}
+
+	if s.waiting {
+		s.watchWhenStarted(t)
+	}
+}
+
+// build builds the options of the tournament: playing or watching its next match,
+// abandoning it and going back.
+func (s *tournamentState) build(t *tournament.Tournament) {
+	face := s.menu.face(20)
+	back := ui.NewOption(backStr, face, func() {
+		s.waiting = false
+		s.menu.ChangeState(newMainMenuState(s.menu))
+	})
+
+	next, ok := t.Bracket.Next()
+	if !ok {
+		s.next = -1
+		s.list = s.menu.newList(preferencesStartY, preferencesLineSpacing,
+			ui.NewOption(newTournamentStr, face, s.abandon), back)
+
+		return
+	}
+
+	s.next = next.ID
+
+	match := fmt.Sprintf("%s vs %s", next.Entrants[0], next.Entrants[1])
+
+	first := ui.NewOption(playNextStr+match, face, func() { s.play(t) })
+	if t.Online {
+		first = ui.NewOption(watchNextStr+match, face, func() { s.waiting = !s.waiting })
+	}
+
+	s.list = s.menu.newList(preferencesStartY, preferencesLineSpacing,
+		first,
+		ui.NewOption(abandonStr, face, func() {
+			s.showDialog("Abandon the tournament?", s.abandon)
+		}),
+		back,
+	)
+}
+
+// turn shows the stage dir stages away from the one shown.
+func (s *tournamentState) turn(dir int) {
+	stages := s.menu.tournament.Bracket.Stages()
+
+	stage := min(max(s.stage+dir, 0), len(stages)-1)
+	if stage != s.stage {
+		s.stage = stage
+		s.menu.audio.Play(audio.MenuMove)
+	}
+}
+
+// play plays the next match of the tournament on this device, between its entrants.
+func (s *tournamentState) play(t *tournament.Tournament) {
+	next, ok := t.Bracket.Next()
+	if !ok {
+		return
+	}
+
+	s.menu.tournamentMatch = &next
+	s.menu.gameMode = TwoPlayers
+	s.menu.readyToPlay = true
+}
+
+// watchWhenStarted looks for the session of the next online match, watching it once the entrants
+// started it.
+func (s *tournamentState) watchWhenStarted(t *tournament.Tournament) {
+	next, ok := t.Bracket.Next()
+	if !ok {
+		s.waiting = false
+		return
+	}
+
+	sessions, loading, _ := s.sessions.get()
+
+	for _, session := range sessions {
+		if !next.Between(session.Player1, session.Player2) {
+			continue
+		}
+
+		s.waiting = false
+		s.sessions = remote[[]sessionInfo]{}
+
+		s.menu.SessionID = session.ID
+		s.menu.ReplayID = ""
+		s.menu.tournamentMatch = &next
+		s.menu.ChangeState(newSpectatorConnectingState(s.menu))
+
+		return
+	}
+
+	if !loading && time.Since(s.polledAt) > sessionsPollInterval {
+		s.polledAt = time.Now()
+		s.sessions.fetch(func(context.Context) ([]sessionInfo, error) {
+			return fetchSessions()
+		})
+	}
+}
+
+// abandon forgets the tournament and goes back to the setup of a new one.
+func (s *tournamentState) abandon() {
+	if err := tournament.Clear(); err != nil {
+		slog.Error("failed to clear tournament", slog.Any("error", err))
+	}
+
+	s.menu.tournament = nil
+	s.waiting = false
+	s.menu.ChangeState(newTournamentSetupState(s.menu))
+}
+
+// Draw draws the state.
+func (s *tournamentState) Draw(screen *ebiten.Image) {
+	t := s.menu.tournament
+	if t == nil {
+		return
+	}
+
+	bracketFace := s.menu.face(12)
+	y := preferencesStartY + float64(len(s.list.Items))*preferencesLineSpacing
+
+	status := fmt.Sprintf("%s, %s matches", t.Bracket.Format, strings.ToLower(localOrOnline(t.Online)))
+
+	switch champion, over := t.Bracket.Champion(); {
+	case over:
+		status = champion + " won the tournament!"
+	case s.waiting:
+		status = "Waiting for the players to start their online match..."
+	}
+
+	ui.DrawCentered(screen, status, bracketFace, y+5, theme.Active().Text)
+
+	stages := t.Bracket.Stages()
+	stage := stages[min(s.stage, len(stages)-1)]
+
+	title := stage.String()
+	if len(stages) > 1 {
+		title = fmt.Sprintf("< %s >", stage)
+	}
+
+	ui.DrawCentered(screen, title, bracketFace, bracketTitleY, theme.Active().Highlight)
+
+	nextID := -1
+	if next, ok := t.Bracket.Next(); ok {
+		nextID = next.ID
+	}
+
+	area := geometry.Rect{
+		X:      20,
+		Y:      bracketTitleY + 25,
+		Width:  s.menu.screenWidth() - 40,
+		Height: s.menu.screenHeight() - bracketTitleY - 40,
+	}
+
+	ui.DrawBracket(screen, bracketFace, t.Bracket, stage, area, nextID)
+
+	s.baseState.Draw(screen)
+}
+
+// String returns the state name.
+func (*tournamentState) String() string {
+	return "tournamentState"
+}
@@ -0,0 +1,119 @@
+package menu
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/tournament"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/bracket"
+)
+
+const (
+	tournamentStr    = "Tournament"
+	bracketStr       = "Bracket"
+	matchesStr       = "Matches"
+	addEntrantStr    = "Add entrant"
+	removeEntrantStr = "Remove last entrant"
+	startStr         = "Start"
+	localStr         = "Local"
+	onlineMatchesStr = "Online"
+)
+
+// tournamentSetupState is the state where the players enter the entrants of a new tournament,
+// from the best seed, and choose its bracket and where its matches are played.
+type tournamentSetupState struct {
+	*baseState
+	format bracket.Format
+	online bool
+	// entrants are the names entered, from the best seed.
+	entrants roster
+}
+
+var _ state = (*tournamentSetupState)(nil)
+
+// newTournamentSetupState creates a new tournamentSetupState.
+func newTournamentSetupState(menu *Menu) *tournamentSetupState {
+	s := &tournamentSetupState{
+		baseState: &baseState{menu: menu},
+		entrants:  roster{max: bracket.MaxEntrants},
+	}
+
+	face := menu.face(20)
+
+	// both settings have two values, so either way toggles them
+	online := ui.NewToggle(matchesStr, face, func() bool { return s.online }, func(on bool) {
+		s.online = on
+		menu.audio.Play(audio.MenuMove)
+	})
+	online.Format = localOrOnline
+
+	s.list = menu.newList(preferencesStartY, preferencesLineSpacing,
+		ui.NewChoice(bracketStr, face, func() string { return s.format.String() }, func(int) {
+			s.format = cycle([]bracket.Format{bracket.SingleElimination, bracket.DoubleElimination}, s.format, 1)
+			menu.audio.Play(audio.MenuMove)
+		}),
+		online,
+		s.entrants.input(addEntrantStr, face),
+		ui.NewOption(removeEntrantStr, face, s.entrants.removeLast),
+		ui.NewOption(startStr, face, s.start),
+		ui.NewOption(backStr, face, s.back),
+	)
+
+	return s
+}
+
+// Update updates the state.
+func (s *tournamentSetupState) Update() {
+	s.update(s.back)
+}
+
+// back returns to the main menu.
+func (s *tournamentSetupState) back() {
+	s.menu.ChangeState(newMainMenuState(s.menu))
+}
+
+// start starts the tournament and shows its bracket.
+func (s *tournamentSetupState) start() {
+	t, err := tournament.New(s.format, slices.Clone(s.entrants.names), s.online)
+	if err != nil {
+		slog.Error("failed to create tournament", slog.Any("error", err))
+		s.entrants.message = "Enter at least two entrants"
+
+		return
+	}
+
+	if err := t.Save(); err != nil {
+		slog.Error("failed to save tournament", slog.Any("error", err))
+	}
+
+	t.Publish()
+
+	s.menu.tournament = t
+	s.menu.ChangeState(newTournamentState(s.menu))
+}
+
+// Draw draws the state.
+func (s *tournamentSetupState) Draw(screen *ebiten.Image) {
+	s.entrants.draw(screen, s.menu.face(14), fmt.Sprintf("%d entrants, the best seed first", len(s.entrants.names)))
+
+	s.baseState.Draw(screen)
+}
+
+// localOrOnline names where the matches of a tournament are played.
+func localOrOnline(online bool) string {
+	if online {
+		return onlineMatchesStr
+	}
+
+	return localStr
+}
+
+// String returns the state name.
+func (*tournamentSetupState) String() string {
+	return "tournamentSetupState"
+}
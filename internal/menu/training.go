@@ -0,0 +1,154 @@
+package menu
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/drill"
+)
+
+const (
+	trainingStr = "Training"
+	drillStr    = "Drill"
+
+	// trainingInfoY is where the description and the results of the drill chosen are drawn.
+	trainingInfoY = 230.0
+	// accuracyChartBars is the number of sessions drawn in the accuracy chart, the latest ones.
+	accuracyChartBars   = 20
+	accuracyChartHeight = 90.0
+	accuracyChartWidth  = 400.0
+)
+
+// trainingState is the state where the player chooses a training drill, seeing how their
+// accuracy went in the latest sessions.
+type trainingState struct {
+	*baseState
+	// drill is the index of the drill chosen.
+	drill int
+}
+
+var _ state = (*trainingState)(nil)
+
+// newTrainingState creates a new trainingState.
+func newTrainingState(menu *Menu) *trainingState {
+	s := &trainingState{baseState: &baseState{menu: menu}}
+
+	face := menu.face(20)
+
+	s.list = menu.newList(preferencesStartY, preferencesLineSpacing,
+		ui.NewChoice(drillStr, face, func() string { return menu.drills[s.drill].Name }, s.browse),
+		ui.NewOption(startStr, face, s.start),
+		ui.NewOption(backStr, face, s.back),
+	)
+
+	return s
+}
+
+// Update updates the state.
+func (s *trainingState) Update() {
+	s.update(s.back)
+}
+
+// back returns to the local mode menu.
+func (s *trainingState) back() {
+	s.menu.ChangeState(newLocalModeState(s.menu))
+}
+
+// browse chooses the drill dir steps away from the current one.
+func (s *trainingState) browse(dir int) {
+	drills := s.menu.drills
+	if len(drills) == 0 {
+		return
+	}
+
+	s.drill = (s.drill + dir + len(drills)) % len(drills)
+	s.menu.audio.Play(audio.MenuMove)
+}
+
+// start plays the drill chosen.
+func (s *trainingState) start() {
+	if len(s.menu.drills) == 0 {
+		return
+	}
+
+	s.menu.drill = s.menu.drills[s.drill]
+	s.menu.gameMode = Training
+	s.menu.readyToPlay = true
+}
+
+// Draw draws the state.
+func (s *trainingState) Draw(screen *ebiten.Image) {
+	textFace := s.menu.face(20)
+	infoFace := s.menu.face(14)
+
+	if len(s.menu.drills) == 0 {
+		ui.DrawCentered(screen, "No drills found", textFace, preferencesStartY, theme.Active().Highlight)
+		return
+	}
+
+	d := s.menu.drills[s.drill]
+
+	s.baseState.Draw(screen)
+
+	ui.DrawCentered(screen, d.Description, infoFace, trainingInfoY, theme.Active().Text)
+	ui.DrawCentered(screen, fmt.Sprintf("%d balls, one every %gs", d.Balls, d.Interval), infoFace, trainingInfoY+20,
+		theme.Active().Text)
+
+	s.drawHistory(screen, d)
+}
+
+// drawHistory draws the results of the drill: the best and the latest accuracy, and a bar
+// for the accuracy of each of the latest sessions.
+func (s *trainingState) drawHistory(screen *ebiten.Image, d drill.Drill) {
+	face := s.menu.face(14)
+
+	if s.menu.training == nil {
+		return
+	}
+
+	sessions := s.menu.training.Sessions(d.Name)
+
+	best, played := s.menu.training.Best(d.Name)
+	if !played {
+		ui.DrawCentered(screen, "Not played yet", face, trainingInfoY+60, theme.Active().Text)
+		return
+	}
+
+	last := sessions[len(sessions)-1]
+	summary := fmt.Sprintf("%d sessions, best accuracy %.0f%%, last %.0f%%", len(sessions), best.Accuracy()*100,
+		last.Accuracy()*100)
+	ui.DrawCentered(screen, summary, face, trainingInfoY+60, theme.Active().Text)
+
+	sessions = sessions[max(len(sessions)-accuracyChartBars, 0):]
+
+	left := (s.menu.screenWidth() - accuracyChartWidth) / 2
+	bottom := s.menu.screenHeight() - 40
+	slot := accuracyChartWidth / accuracyChartBars
+
+	vector.StrokeLine(screen, float32(left), float32(bottom), float32(left+accuracyChartWidth), float32(bottom), 1,
+		theme.Active().Text, false)
+
+	for i, session := range sessions {
+		clr := theme.Active().Text
+		if i == len(sessions)-1 {
+			clr = theme.Active().Highlight
+		}
+
+		height := max(accuracyChartHeight*session.Accuracy(), 1)
+
+		vector.DrawFilledRect(screen, float32(left+float64(i)*slot+2), float32(bottom-height), float32(slot-4),
+			float32(height), clr, false)
+	}
+
+	ui.DrawCentered(screen, "Accuracy of the latest sessions", face, bottom+8, theme.Active().Text)
+}
+
+// String returns the state name.
+func (*trainingState) String() string {
+	return "trainingState"
+}
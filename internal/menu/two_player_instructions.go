@@ -3,18 +3,24 @@ package menu
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
 	"github.com/gandarez/pong-multiplayer-go/internal/ui"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
 const (
 	instructionsTitle = "Controls"
 	lineHeight        = 30
+	// compactLineHeight is the line height used when the controls of four players are displayed.
+	compactLineHeight = 22
 )
 
 // Control defines controls for each player.
@@ -23,41 +29,110 @@ type Control struct {
 	key    string
 }
 
-// twoPlayersInstructionsState is the state where the controls for two players are displayed.
+// playerControls are the controls of a local player.
+type playerControls struct {
+	name     string
+	controls []Control
+}
+
+// twoPlayersInstructionsState is the state where the controls of the local players are displayed.
 type twoPlayersInstructionsState struct {
-	menu       *Menu
-	p1Controls []Control
-	p2Controls []Control
+	menu *Menu
 }
 
 // newTwoPlayersInstructionsState creates a new twoPlayersInstructionsState.
 func newTwoPlayersInstructionsState(menu *Menu) *twoPlayersInstructionsState {
 	return &twoPlayersInstructionsState{
 		menu: menu,
-		p1Controls: []Control{
-			{action: "Up", key: "Q"},
-			{action: "Down", key: "A"},
-		},
-		p2Controls: []Control{
-			{action: "Up", key: "Up Arrow"},
-			{action: "Down", key: "Down Arrow"},
-		},
 	}
 }
 
+// localControls returns the controls of each local player sharing the keyboard in the selected format,
+// following their current bindings.
+func (s *twoPlayersInstructionsState) localControls() []playerControls {
+	format := s.menu.rules.Format
+	players := make([]playerControls, 0, len(format.Sides())*format.Lanes())
+
+	for range format.Lanes() {
+		for _, side := range format.Sides() {
+			i := len(players)
+			up := s.menu.controls.Binding(input.PlayerUp(i)).Key
+			down := s.menu.controls.Binding(input.PlayerDown(i)).Key
+
+			name := fmt.Sprintf("Player %d", i+1)
+			if format != rules.Singles {
+				name = fmt.Sprintf("%s - %s", name, side)
+			}
+
+			backward, forward := "Up", "Down"
+			if side.Horizontal() {
+				backward, forward = "Left", "Right"
+			}
+
+			players = append(players, playerControls{
+				name: name,
+				controls: []Control{
+					{action: backward, key: input.KeyName(up)},
+					{action: forward, key: input.KeyName(down)},
+				},
+			})
+		}
+	}
+
+	return players
+}
+
 // Update handles the logic for the TwoPlayersInstructionsState.
 func (s *twoPlayersInstructionsState) Update() {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+	s.assignGamepads()
+
+	_, start := s.menu.controls.Gamepads().JustPressed(ebiten.StandardGamepadButtonCenterRight)
+	_, tapped := s.menu.tap()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || start || tapped {
 		// proceed to level selection after showing the instructions.
 		s.menu.ChangeState(newLevelSelectionState(s.menu))
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+	if s.menu.backPressed() {
 		// go back to the LocalModeState.
 		s.menu.ChangeState(newLocalModeState(s.menu))
 	}
 }
 
+// assignGamepads assigns a gamepad to the first player without one when its A button is
+// pressed and unassigns it when its B button is pressed.
+func (s *twoPlayersInstructionsState) assignGamepads() {
+	gamepads := s.menu.controls.Gamepads()
+
+	if id, ok := gamepads.JustPressed(ebiten.StandardGamepadButtonRightBottom); ok {
+		if _, assigned := gamepads.PlayerOf(id); !assigned {
+			for player := range s.localControls() {
+				if _, taken := gamepads.Assigned(player); !taken {
+					gamepads.Assign(player, id)
+					break
+				}
+			}
+		}
+	}
+
+	if id, ok := gamepads.JustPressed(ebiten.StandardGamepadButtonRightRight); ok {
+		if player, assigned := gamepads.PlayerOf(id); assigned {
+			gamepads.Unassign(player)
+		}
+	}
+}
+
+// gamepadName returns the name shown for the gamepad assigned to the player, if any.
+func (s *twoPlayersInstructionsState) gamepadName(player int) (string, bool) {
+	id, ok := s.menu.controls.Gamepads().Assigned(player)
+	if !ok {
+		return "", false
+	}
+
+	return fmt.Sprintf("Gamepad %d", slices.Index(s.menu.controls.Gamepads().Connected(), id)+1), true
+}
+
 // Draw renders the instructions on the screen.
 func (s *twoPlayersInstructionsState) Draw(screen *ebiten.Image) {
 	// draw the title
@@ -85,17 +160,23 @@ func (s *twoPlayersInstructionsState) drawTitle(screen *ebiten.Image) (geometry.
 		return geometry.Vector{}, err
 	}
 
+	// move the title up to make room for the controls of four players
+	y := 200.
+	if len(s.localControls()) > 2 {
+		y = 170
+	}
+
 	titleWidth, _ := text.Measure(instructionsTitle, titleFace, 1)
 	titlePosition := geometry.Vector{
-		X: (float64(s.menu.screenWidth) - titleWidth) / 2,
-		Y: 200,
+		X: (s.menu.screenWidth() - titleWidth) / 2,
+		Y: y,
 	}
 
 	uiText := ui.Text{
 		Value:    instructionsTitle,
 		FontFace: titleFace,
 		Position: titlePosition,
-		Color:    ui.DefaultColor,
+		Color:    theme.Active().Text,
 	}
 	uiText.Draw(screen)
 
@@ -104,26 +185,40 @@ func (s *twoPlayersInstructionsState) drawTitle(screen *ebiten.Image) (geometry.
 
 func (s *twoPlayersInstructionsState) drawControls(screen *ebiten.Image, titlePosition geometry.Vector) error {
 	// define positions for columns
-	columnWidth := float64(s.menu.screenWidth) / 2
+	columnWidth := s.menu.screenWidth() / 2
 	leftColumnX := columnWidth / 2
 	rightColumnX := columnWidth + leftColumnX
 
-	controlsFace, err := s.menu.font.Face("ui", 20)
+	players := s.localControls()
+
+	// use a smaller font and spacing to fit the controls of four players
+	fontSize, spacing, nameSpacing := 20., float64(lineHeight), 40.
+	if len(players) > 2 {
+		fontSize, spacing, nameSpacing = 16, compactLineHeight, 30
+	}
+
+	controlsFace, err := s.menu.font.Face("ui", fontSize)
 	if err != nil {
 		return err
 	}
 
-	// draw player 1 name
-	playerNameY := titlePosition.Y + 60
-	s.drawPlayerName(screen, controlsFace, "Player 1", leftColumnX, playerNameY)
+	// two players per row
+	for i, p := range players {
+		x := leftColumnX
+		if i%2 == 1 {
+			x = rightColumnX
+		}
 
-	// draw player 2 name
-	s.drawPlayerName(screen, controlsFace, "Player 2", rightColumnX, playerNameY)
+		name := p.name
+		if gamepad, ok := s.gamepadName(i); ok {
+			name = fmt.Sprintf("%s (%s)", name, gamepad)
+		}
 
-	// draw controls for each player
-	startY := playerNameY + 40
-	s.drawPlayerControls(screen, controlsFace, s.p1Controls, leftColumnX, startY)
-	s.drawPlayerControls(screen, controlsFace, s.p2Controls, rightColumnX, startY)
+		// draw player name and its controls below
+		playerNameY := titlePosition.Y + 60 + float64(i/2)*(nameSpacing+float64(len(p.controls))*spacing+10)
+		s.drawPlayerName(screen, controlsFace, name, x, playerNameY)
+		s.drawPlayerControls(screen, controlsFace, p.controls, x, playerNameY+nameSpacing, spacing)
+	}
 
 	return nil
 }
@@ -144,7 +239,7 @@ func (*twoPlayersInstructionsState) drawPlayerName(
 		Value:    playerName,
 		FontFace: controlsFace,
 		Position: playerNamePosition,
-		Color:    ui.HighlightColor,
+		Color:    theme.Active().Highlight,
 	}
 	uiText.Draw(screen)
 }
@@ -153,10 +248,10 @@ func (*twoPlayersInstructionsState) drawPlayerControls(
 	screen *ebiten.Image,
 	controlsFace text.Face,
 	controls []Control,
-	startX, startY float64,
+	startX, startY, spacing float64,
 ) {
 	for i, control := range controls {
-		y := startY + float64(i)*lineHeight
+		y := startY + float64(i)*spacing
 		action := control.action
 		key := control.key
 		controlText := fmt.Sprintf("%s: [%s]", action, key)
@@ -170,7 +265,7 @@ func (*twoPlayersInstructionsState) drawPlayerControls(
 			Value:    controlText,
 			FontFace: controlsFace,
 			Position: controlPosition,
-			Color:    ui.DefaultColor,
+			Color:    theme.Active().Text,
 		}
 		uiText.Draw(screen)
 	}
@@ -183,16 +278,18 @@ func (s *twoPlayersInstructionsState) drawMenuOptions(screen *ebiten.Image) erro
 	}
 
 	// define separate texts
-	enterText := "Press Enter to continue"
+	enterText := "Press Enter or tap to continue"
 	escText := "Press Esc to go back"
+	gamepadText := "Press A on a gamepad to join, B to leave"
 
 	// measure the width of each text
 	enterTextWidth, _ := text.Measure(enterText, instructionsFace, 1)
 	escTextWidth, _ := text.Measure(escText, instructionsFace, 1)
+	gamepadTextWidth, _ := text.Measure(gamepadText, instructionsFace, 1)
 
 	// calculate text center positions
-	centerX := float64(s.menu.screenWidth) / 2
-	baseY := float64(s.menu.screenHeight) - 80
+	centerX := s.menu.screenWidth() / 2
+	baseY := s.menu.screenHeight() - 80
 	enterTextPosition := geometry.Vector{
 		X: centerX - (enterTextWidth / 2),
 		Y: baseY,
@@ -203,12 +300,17 @@ func (s *twoPlayersInstructionsState) drawMenuOptions(screen *ebiten.Image) erro
 		Y: baseY + 30,
 	}
 
+	gamepadTextPosition := geometry.Vector{
+		X: centerX - (gamepadTextWidth / 2),
+		Y: baseY + 60,
+	}
+
 	// draw "Press Enter" text
 	uiText := ui.Text{
 		Value:    enterText,
 		FontFace: instructionsFace,
 		Position: enterTextPosition,
-		Color:    ui.DefaultColor,
+		Color:    theme.Active().Text,
 	}
 	uiText.Draw(screen)
 
@@ -217,7 +319,16 @@ func (s *twoPlayersInstructionsState) drawMenuOptions(screen *ebiten.Image) erro
 		Value:    escText,
 		FontFace: instructionsFace,
 		Position: escTextPosition,
-		Color:    ui.DefaultColor,
+		Color:    theme.Active().Text,
+	}
+	uiText.Draw(screen)
+
+	// draw gamepad assignment hint
+	uiText = ui.Text{
+		Value:    gamepadText,
+		FontFace: instructionsFace,
+		Position: gamepadTextPosition,
+		Color:    theme.Active().Text,
 	}
 	uiText.Draw(screen)
 
@@ -0,0 +1,50 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// Challenge is a challenge issued by the server, signed by the identity of the player joining
+// a match so it's accepted once.
+type Challenge struct {
+	Nonce string `json:"nonce"`
+}
+
+// FetchChallenge asks the server for a challenge to sign before joining a match.
+func FetchChallenge(ctx context.Context) (string, error) {
+	u := fmt.Sprintf("https://%s/challenge", BaseURL)
+
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create challenge request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch challenge: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error("failed to close response body", slog.Any("error", err))
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch challenge: non-200 status code: %d", resp.StatusCode)
+	}
+
+	var challenge Challenge
+	if err := json.NewDecoder(resp.Body).Decode(&challenge); err != nil {
+		return "", fmt.Errorf("failed to parse challenge: %w", err)
+	}
+
+	return challenge.Nonce, nil
+}
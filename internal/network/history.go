@@ -0,0 +1,118 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// PageSize is the number of entries of a page of the leaderboard or of a match history.
+const PageSize = 10
+
+type (
+	// LeaderboardPage is a page of the players ranked by rating, the best first.
+	LeaderboardPage struct {
+		Players []RankedPlayer `json:"players"`
+		// Page is the number of the page, from 1, and Pages the number of pages.
+		Page  int `json:"page"`
+		Pages int `json:"pages"`
+	}
+
+	// RankedPlayer is a player of the leaderboard.
+	RankedPlayer struct {
+		Rank   int     `json:"rank"`
+		Name   string  `json:"name"`
+		Rating float64 `json:"rating"`
+		// Deviation is how uncertain the rating is.
+		Deviation float64 `json:"deviation"`
+	}
+
+	// HistoryPage is a page of the matches of a player, the latest first.
+	HistoryPage struct {
+		Matches []MatchRecord `json:"matches"`
+		// Page is the number of the page, from 1, and Pages the number of pages.
+		Page  int `json:"page"`
+		Pages int `json:"pages"`
+	}
+
+	// MatchRecord is an online match that's over.
+	MatchRecord struct {
+		ID      string        `json:"id"`
+		Date    time.Time     `json:"date"`
+		Players []MatchPlayer `json:"players"`
+		// Replay is the ID of the replay of the match, empty when it wasn't stored.
+		Replay string `json:"replay,omitempty"`
+	}
+
+	// MatchPlayer is a player of a match and its result.
+	MatchPlayer struct {
+		Name   string        `json:"name"`
+		Side   geometry.Side `json:"side"`
+		Score  int           `json:"score"`
+		Winner bool          `json:"winner"`
+		// RatingChange is how much the match changed the rating of the player, zero for guests.
+		RatingChange float64 `json:"rating_change,omitempty"`
+	}
+)
+
+// FetchLeaderboard fetches a page of the leaderboard, from 1.
+func FetchLeaderboard(ctx context.Context, page int) (LeaderboardPage, error) {
+	var leaderboard LeaderboardPage
+
+	u := fmt.Sprintf("https://%s/leaderboard?page=%d", BaseURL, page)
+	if err := fetchJSON(ctx, u, &leaderboard); err != nil {
+		return LeaderboardPage{}, fmt.Errorf("failed to fetch leaderboard: %w", err)
+	}
+
+	return leaderboard, nil
+}
+
+// FetchHistory fetches a page of the matches of a player, from 1.
+func FetchHistory(ctx context.Context, name string, page int) (HistoryPage, error) {
+	var history HistoryPage
+
+	u := fmt.Sprintf("https://%s/players/%s/matches?page=%d", BaseURL, url.PathEscape(name), page)
+	if err := fetchJSON(ctx, u, &history); err != nil {
+		return HistoryPage{}, fmt.Errorf("failed to fetch history of %q: %w", name, err)
+	}
+
+	return history, nil
+}
+
+// fetchJSON fetches the JSON document at the URL into v.
+func fetchJSON(ctx context.Context, u string, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error("failed to close response body", slog.Any("error", err))
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("non-200 status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
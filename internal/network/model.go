@@ -1,6 +1,12 @@
 package network
 
-import "github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+import (
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/powerup"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
 
 type (
 	// GameState represents the state of the game when it is sent over the network.
@@ -8,6 +14,23 @@ type (
 		Ball           BallState   `json:"ball"`
 		CurrentPlayer  PlayerState `json:"current"`
 		OpponentPlayer PlayerState `json:"opponent"`
+		// Balls are the extra balls in play in arcade matches.
+		Balls []BallState `json:"balls,omitempty"`
+		// PowerUps are the power-ups waiting to be collected in arcade matches.
+		PowerUps []powerup.PowerUp `json:"power_ups,omitempty"`
+		// Players are all the players of doubles and four-way matches.
+		// Teammates share the score of their side.
+		Players []PlayerState `json:"players,omitempty"`
+		// Clock is the clock of timed matches, nil when the match isn't timed or the server
+		// doesn't keep one.
+		Clock *ClockState `json:"clock,omitempty"`
+	}
+
+	// ClockState represents the clock of a timed match when it is sent over the network.
+	ClockState struct {
+		// Remaining is the time left in the match, in seconds.
+		Remaining   float64 `json:"remaining"`
+		SuddenDeath bool    `json:"sudden_death"`
 	}
 
 	// BallState represents the state of the ball when it is sent over the network.
@@ -19,22 +42,48 @@ type (
 
 	// PlayerState represents the state of a player when it is sent over the network.
 	PlayerState struct {
-		Name      string        `json:"name"`
+		Name string `json:"name"`
+		// PositionY is the position of the paddle along the axis it moves on,
+		// which is the X axis for the top and bottom sides.
 		PositionY float64       `json:"position_y"`
 		Side      geometry.Side `json:"side"`
-		Score     int8          `json:"score"`
-		Ping      int           `json:"ping"`
-		Winner    bool          `json:"winner"`
+		// Lane is the half of the goal defended by the player in doubles matches, 0 for the upper one.
+		Lane   int  `json:"lane,omitempty"`
+		Score  int8 `json:"score"`
+		Ping   int  `json:"ping"`
+		Winner bool `json:"winner"`
+		// BouncerHeight is the height of the paddle, it changes with power-ups in arcade matches.
+		BouncerHeight float64 `json:"bouncer_height,omitempty"`
+		// Handicap is the handicap the server granted the player, which sets the size and the speed
+		// of their paddle.
+		Handicap player.Handicap `json:"handicap,omitempty"`
+		// Shield is true while a shield protects the goal of the player in arcade matches.
+		Shield bool `json:"shield,omitempty"`
+		// Verified is true when the player proved they own the account holding their name.
+		Verified bool `json:"verified,omitempty"`
+		// Rating is the skill rating of the player and RatingChange how much the result changed it,
+		// sent once the match is over. Both are zero for guests and servers without ratings.
+		Rating       float64 `json:"rating,omitempty"`
+		RatingChange float64 `json:"rating_change,omitempty"`
 	}
 
 	// GameInfo contains the information of a multiplayer game that's sent to the server.
 	GameInfo struct {
-		PlayerName       string `json:"player_name"`
-		Level            int    `json:"level"`
-		ScreenWidth      int    `json:"screen_width"`
-		ScreenHeight     int    `json:"screen_height"`
-		MaxScore         int    `json:"max_score"`
-		FieldBorderWidth int    `json:"field_border_width"`
+		PlayerName string `json:"player_name"`
+		Level      int    `json:"level"`
+		// FieldWidth and FieldHeight are the size of the field, in field units. Their JSON names date
+		// from when the screen was as big as the field.
+		FieldWidth       int         `json:"screen_width"`
+		FieldHeight      int         `json:"screen_height"`
+		MaxScore         int         `json:"max_score"`
+		FieldBorderWidth int         `json:"field_border_width"`
+		Rules            rules.Rules `json:"rules"`
+		// Identity proves the player owns the account holding their name, nil to play as a guest.
+		Identity *account.Identity `json:"identity,omitempty"`
+		// Handicap is the handicap the player asks for. Servers may refuse it, e.g. in rated matches,
+		// say which one they granted in the ready message and derive the paddle from it with
+		// Handicap.Paddle, never trusting a paddle sent by the player.
+		Handicap player.Handicap `json:"handicap,omitempty"`
 	}
 
 	// ReadyMessage represents the message sent from the server when the game is ready to start.
@@ -45,11 +94,26 @@ type (
 		OpponentName string        `json:"opponent_name"`
 		Side         geometry.Side `json:"side"`
 		OpponentSide geometry.Side `json:"opponent_side"`
+		// Lane is the half of the goal defended by the player in doubles matches.
+		Lane int `json:"lane,omitempty"`
+		// Handicap and OpponentHandicap are the handicaps the server granted the players.
+		Handicap         player.Handicap `json:"handicap,omitempty"`
+		OpponentHandicap player.Handicap `json:"opponent_handicap,omitempty"`
+		// Players are all the players of doubles and four-way matches, including the current one.
+		Players []PlayerState `json:"players,omitempty"`
+		// Error tells why the server refused the player, e.g. when their name is reserved
+		// by another account. Ready is false then.
+		Error string `json:"error,omitempty"`
 	}
 
-	// PlayerInput represents the keyboard/touch input of the player when it is sent over the network.
+	// PlayerInput represents the keyboard/touch/gamepad input of the player when it is sent over the network.
 	PlayerInput struct {
-		Up   bool `json:"up"`
-		Down bool `json:"down"`
+		Up    bool `json:"up"`
+		Down  bool `json:"down"`
+		Left  bool `json:"left,omitempty"`
+		Right bool `json:"right,omitempty"`
+		// Axis is the analog control of the paddle, between -1 and 1, sent by gamepads.
+		// When it's not zero it takes precedence over the digital controls.
+		Axis float64 `json:"axis,omitempty"`
 	}
 )
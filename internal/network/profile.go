@@ -0,0 +1,55 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+)
+
+// ErrNoAccount is returned when fetching the profile of a name no account holds.
+var ErrNoAccount = errors.New("no account holds the name")
+
+// FetchProfile fetches the public profile of the account holding the name of a player.
+func FetchProfile(ctx context.Context, name string) (account.Account, error) {
+	u := fmt.Sprintf("https://%s/profiles/%s", BaseURL, url.PathEscape(name))
+
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return account.Account{}, fmt.Errorf("failed to create profile request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return account.Account{}, fmt.Errorf("failed to fetch profile of %q: %w", name, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error("failed to close response body", slog.Any("error", err))
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return account.Account{}, ErrNoAccount
+	default:
+		return account.Account{}, fmt.Errorf("failed to fetch profile of %q: non-200 status code: %d", name, resp.StatusCode)
+	}
+
+	var profile account.Account
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return account.Account{}, fmt.Errorf("failed to parse profile of %q: %w", name, err)
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,69 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+)
+
+// ErrNotQueued is returned when fetching the status of a player the server isn't pairing,
+// e.g. because their game server doesn't use its queue.
+var ErrNotQueued = errors.New("player not in the queue")
+
+// QueueStatus is the status of a player in the queue of the server pairing players by rating.
+type QueueStatus struct {
+	// Queued is true while the player waits for an opponent.
+	Queued bool `json:"queued"`
+	// Rating is the rating the player is paired by, the rating of a new player for guests.
+	Rating float64 `json:"rating,omitempty"`
+	// Wait is how long the player has been waiting, in seconds.
+	Wait float64 `json:"wait,omitempty"`
+	// Window is the largest rating difference accepted for the opponent, zero once any opponent
+	// is accepted.
+	Window float64 `json:"window,omitempty"`
+	// Opponent is the name of the opponent the player was paired with, empty while waiting.
+	Opponent string `json:"opponent,omitempty"`
+}
+
+// FetchQueueStatus fetches the status of the player with the name in the queue of the server.
+func FetchQueueStatus(ctx context.Context, name string) (QueueStatus, error) {
+	u := fmt.Sprintf("https://%s/queue/%s", BaseURL, url.PathEscape(name))
+
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return QueueStatus{}, fmt.Errorf("failed to create queue request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return QueueStatus{}, fmt.Errorf("failed to fetch queue status of %q: %w", name, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error("failed to close response body", slog.Any("error", err))
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return QueueStatus{}, ErrNotQueued
+	default:
+		return QueueStatus{}, fmt.Errorf("failed to fetch queue status of %q: non-200 status code: %d", name, resp.StatusCode)
+	}
+
+	var status QueueStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return QueueStatus{}, fmt.Errorf("failed to parse queue status of %q: %w", name, err)
+	}
+
+	return status, nil
+}
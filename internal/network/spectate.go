@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/url"
 
 	"github.com/coder/websocket"
 	"github.com/coder/websocket/wsjson"
@@ -44,3 +45,23 @@ func (c *Client) ConnectAsSpectator(sessionID string) error {
 
 	return nil
 }
+
+// ConnectToReplay connects to the server to watch the replay of a match, streamed
+// as the game states of the match.
+func (c *Client) ConnectToReplay(replayID string) error {
+	u := fmt.Sprintf("wss://%s/replays/%s", c.serverURL, url.PathEscape(replayID))
+
+	ctx, cancel := context.WithTimeout(c.ctx, writeTimeout)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to websocket at %q: %w", u, err)
+	}
+
+	c.conn = conn
+
+	slog.Info("websocket connection established to watch replay", slog.String("url", u))
+
+	return nil
+}
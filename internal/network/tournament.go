@@ -0,0 +1,91 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/bracket"
+)
+
+type (
+	// TournamentUpdate is sent to the account server to publish the bracket of a tournament
+	// for spectators.
+	TournamentUpdate struct {
+		// Token is chosen by the device running the tournament, the ID of the tournament being
+		// derived from it with TournamentID.
+		Token   string           `json:"token"`
+		Bracket *bracket.Bracket `json:"bracket"`
+	}
+
+	// LiveTournament is a tournament published to the account server.
+	LiveTournament struct {
+		ID      string           `json:"id"`
+		Bracket *bracket.Bracket `json:"bracket"`
+		Updated time.Time        `json:"updated"`
+	}
+)
+
+// TournamentID returns the ID of the tournament published with the token, so only the device
+// holding the token can publish under the ID.
+func TournamentID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// PublishTournament publishes the bracket of the tournament with the ID.
+func PublishTournament(ctx context.Context, id string, update TournamentUpdate) error {
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to encode tournament: %w", err)
+	}
+
+	u := fmt.Sprintf("https://%s/tournaments/%s", BaseURL, url.PathEscape(id))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create tournament request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish tournament: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error("failed to close response body", slog.Any("error", err))
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to publish tournament: non-200 status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// FetchTournaments fetches the tournaments being played that the player entered.
+func FetchTournaments(ctx context.Context, player string) ([]LiveTournament, error) {
+	var tournaments []LiveTournament
+
+	u := fmt.Sprintf("https://%s/tournaments?player=%s", BaseURL, url.QueryEscape(player))
+	if err := fetchJSON(ctx, u, &tournaments); err != nil {
+		return nil, fmt.Errorf("failed to fetch tournaments of %q: %w", player, err)
+	}
+
+	return tournaments, nil
+}
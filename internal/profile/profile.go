@@ -0,0 +1,220 @@
+// Package profile keeps the lifetime statistics of the players of this device, one profile per name.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
+)
+
+// fileName is the name of the file keeping the profiles.
+const fileName = "profiles.json"
+
+// ErrUnreadable is returned when saving profiles loaded in place of a profiles file that couldn't
+// be read nor backed up, so the file isn't overwritten.
+var ErrUnreadable = errors.New("profiles file can't be read nor backed up, not overwriting it")
+
+// Version is the version of the profiles schema.
+const Version = 1
+
+// GuestName is the name of the profile used when the player didn't enter a name.
+const GuestName = "Guest"
+
+// Mode is the kind of match a record is kept for.
+type Mode string
+
+const (
+	// VsCPU is a match against the computer.
+	VsCPU Mode = "cpu"
+	// Local is a match between players sharing this device.
+	Local Mode = "local"
+	// Online is a match against players connected to a server.
+	Online Mode = "online"
+)
+
+// String returns the name of the mode as displayed to players.
+func (m Mode) String() string {
+	switch m {
+	case VsCPU:
+		return "vs CPU"
+	case Local:
+		return "Local"
+	case Online:
+		return "Online"
+	default:
+		return string(m)
+	}
+}
+
+type (
+	// Store holds the profiles of every player of this device.
+	Store struct {
+		Version  int                 `json:"version"`
+		Profiles map[string]*Profile `json:"profiles"`
+		// keep keeps the profiles file unreadable by this version of the game from being overwritten
+		// when it couldn't be backed up.
+		keep bool
+	}
+
+	// Profile holds the lifetime statistics of a player.
+	Profile struct {
+		Name string `json:"name"`
+		// Records are the matches played by mode and level.
+		Records []Record `json:"records"`
+		// LongestRally is the largest number of paddle hits in a single point.
+		LongestRally int `json:"longest_rally"`
+		// FastestBall is the highest speed of the ball leaving a paddle, in units per second.
+		FastestBall float64 `json:"fastest_ball"`
+		// Points and PointsTime are the number of points played and their total duration,
+		// giving the average length of a point.
+		Points     int           `json:"points"`
+		PointsTime time.Duration `json:"points_time"`
+	}
+
+	// Record counts the matches played in a mode and level.
+	Record struct {
+		Mode   Mode        `json:"mode"`
+		Level  level.Level `json:"level"`
+		Played int         `json:"played"`
+		Wins   int         `json:"wins"`
+		Losses int         `json:"losses"`
+	}
+)
+
+// Load loads the saved profiles. An empty store is returned when nothing was saved yet or,
+// along with an error, when the saved profiles can't be read. Profiles that can't be parsed
+// are backed up first, so saving new ones doesn't lose them.
+func Load() (*Store, error) {
+	store := newStore()
+
+	data, err := storage.Read(fileName)
+	if errors.Is(err, storage.ErrNotFound) {
+		return store, nil
+	}
+
+	if err != nil {
+		return store, fmt.Errorf("failed to load profiles: %w", err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return backup(data, fmt.Errorf("failed to parse profiles: %w", err))
+	}
+
+	if store.Profiles == nil {
+		store.Profiles = make(map[string]*Profile)
+	}
+
+	return store, nil
+}
+
+// backup keeps a copy of the profiles that can't be parsed and returns an empty store with
+// the parsing error. The store won't be saved if the copy can't be made.
+func backup(data []byte, parseErr error) (*Store, error) {
+	store := newStore()
+
+	name, err := storage.Backup(fileName, data)
+	if err != nil {
+		store.keep = true
+
+		return store, errors.Join(parseErr, err)
+	}
+
+	return store, fmt.Errorf("%w, kept a copy in %s", parseErr, name)
+}
+
+// newStore creates a store without profiles.
+func newStore() *Store {
+	return &Store{Version: Version, Profiles: make(map[string]*Profile)}
+}
+
+// Save saves the profiles.
+func (s *Store) Save() error {
+	if s.keep {
+		return ErrUnreadable
+	}
+
+	data, err := s.Export()
+	if err != nil {
+		return err
+	}
+
+	if err := storage.Write(fileName, data); err != nil {
+		return fmt.Errorf("failed to save profiles: %w", err)
+	}
+
+	return nil
+}
+
+// Export returns the profiles encoded as JSON.
+func (s *Store) Export() ([]byte, error) {
+	s.Version = Version
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode profiles: %w", err)
+	}
+
+	return data, nil
+}
+
+// Profile returns the profile of the player with the given name, creating it if needed.
+// An empty name is the guest's.
+func (s *Store) Profile(name string) *Profile {
+	if name == "" {
+		name = GuestName
+	}
+
+	p, ok := s.Profiles[name]
+	if !ok {
+		p = &Profile{Name: name}
+		s.Profiles[name] = p
+	}
+
+	return p
+}
+
+// Names returns the names of the profiles in alphabetical order.
+func (s *Store) Names() []string {
+	return slices.Sorted(maps.Keys(s.Profiles))
+}
+
+// Record returns the record of the mode and level, creating it if needed.
+func (p *Profile) Record(mode Mode, lvl level.Level) *Record {
+	for i, r := range p.Records {
+		if r.Mode == mode && r.Level == lvl {
+			return &p.Records[i]
+		}
+	}
+
+	p.Records = append(p.Records, Record{Mode: mode, Level: lvl})
+
+	return &p.Records[len(p.Records)-1]
+}
+
+// Total returns the matches played in every mode and level.
+func (p *Profile) Total() Record {
+	var total Record
+
+	for _, r := range p.Records {
+		total.Played += r.Played
+		total.Wins += r.Wins
+		total.Losses += r.Losses
+	}
+
+	return total
+}
+
+// AveragePoint returns the average length of a point, zero before the first point.
+func (p *Profile) AveragePoint() time.Duration {
+	if p.Points == 0 {
+		return 0
+	}
+
+	return p.PointsTime / time.Duration(p.Points)
+}
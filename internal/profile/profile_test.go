@@ -0,0 +1,52 @@
+package profile
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/internal/storage/storagetest"
+)
+
+func TestLoad_Unreadable(t *testing.T) {
+	storagetest.UseTempDir(t)
+
+	unreadable := []byte(`{"version": 1, "profiles": {"Ada": `)
+
+	if err := storage.Write(fileName, unreadable); err != nil {
+		t.Fatalf("failed to write profiles file: %v", err)
+	}
+
+	store, err := Load()
+	if err == nil {
+		t.Fatal("unreadable profiles didn't return an error")
+	}
+
+	if len(store.Names()) != 0 {
+		t.Errorf("profiles = %v, want none", store.Names())
+	}
+
+	store.Profile("Grace")
+
+	if err := store.Save(); err != nil {
+		t.Fatalf("failed to save profiles: %v", err)
+	}
+
+	backup, err := storage.Read(fileName + ".bak")
+	if err != nil {
+		t.Fatalf("failed to read the backup: %v", err)
+	}
+
+	if string(backup) != string(unreadable) {
+		t.Errorf("backup = %q, want %q", backup, unreadable)
+	}
+}
+
+func TestSave_NotBackedUp(t *testing.T) {
+	store := newStore()
+	store.keep = true
+
+	if err := store.Save(); !errors.Is(err, ErrUnreadable) {
+		t.Errorf("error = %v, want %v", err, ErrUnreadable)
+	}
+}
@@ -0,0 +1,123 @@
+package profile
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Player is a player of this device whose profile is updated with the match.
+type Player struct {
+	Name string
+	// Side is the side of the player, whose team wins or loses the match.
+	Side geometry.Side
+}
+
+// Tracker updates the profiles of the players of this device from the events of the match
+// being played, saving them when the match is over.
+type Tracker struct {
+	store *Store
+	// active is true while a tracked match is being played.
+	active  bool
+	players []Player
+	mode    Mode
+	level   level.Level
+	// clock returns the time played in the match, used to measure the length of the points.
+	clock      func() time.Duration
+	pointStart time.Duration
+	rally      int
+	// longestRally, fastestBall, points and pointsTime are the statistics of the match.
+	longestRally int
+	fastestBall  float64
+	points       int
+	pointsTime   time.Duration
+}
+
+// NewTracker creates a new Tracker updating the profiles of the store.
+func NewTracker(store *Store) *Tracker {
+	return &Tracker{store: store}
+}
+
+// Subscribe tracks the hits, goals and end of the matches published on the bus.
+func (t *Tracker) Subscribe(bus *event.Bus) {
+	event.On(bus, t.paddleHit)
+	event.On(bus, t.goal)
+	event.On(bus, t.matchOver)
+}
+
+// Start tracks a match played in mode and lvl by the players of this device.
+// clock returns the time played in the match.
+func (t *Tracker) Start(mode Mode, lvl level.Level, clock func() time.Duration, players ...Player) {
+	*t = Tracker{
+		store:   t.store,
+		active:  true,
+		players: players,
+		mode:    mode,
+		level:   lvl,
+		clock:   clock,
+	}
+}
+
+// Stop stops tracking the match, which is left out of the profile, e.g. when the player quits it.
+func (t *Tracker) Stop() {
+	t.active = false
+}
+
+// paddleHit counts the hits of the rally and keeps the fastest ball.
+func (t *Tracker) paddleHit(hit event.PaddleHit) {
+	if !t.active {
+		return
+	}
+
+	t.rally++
+	t.fastestBall = max(t.fastestBall, hit.Speed)
+}
+
+// goal ends the point, keeping its length and its rally.
+func (t *Tracker) goal(event.Goal) {
+	if !t.active {
+		return
+	}
+
+	now := t.clock()
+
+	t.points++
+	t.pointsTime += now - t.pointStart
+	t.pointStart = now
+	t.longestRally = max(t.longestRally, t.rally)
+	t.rally = 0
+}
+
+// matchOver adds the match to the profiles of the players and saves them.
+func (t *Tracker) matchOver(over event.MatchOver) {
+	if !t.active {
+		return
+	}
+
+	t.active = false
+
+	for _, player := range t.players {
+		p := t.store.Profile(player.Name)
+
+		record := p.Record(t.mode, t.level)
+		record.Played++
+
+		if over.Winner == player.Side {
+			record.Wins++
+		} else {
+			record.Losses++
+		}
+
+		p.LongestRally = max(p.LongestRally, t.longestRally, t.rally)
+		p.FastestBall = max(p.FastestBall, t.fastestBall)
+		p.Points += t.points
+		p.PointsTime += t.pointsTime
+	}
+
+	if err := t.store.Save(); err != nil {
+		slog.Error("failed to save profiles", slog.Any("error", err))
+	}
+}
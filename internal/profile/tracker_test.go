@@ -0,0 +1,60 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/storage/storagetest"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+func TestTracker_Players(t *testing.T) {
+	storagetest.UseTempDir(t)
+
+	store := newStore()
+	tracker := NewTracker(store)
+	bus := event.NewBus()
+	tracker.Subscribe(bus)
+
+	tracker.Start(Local, level.Hard, func() time.Duration { return 0 },
+		Player{Name: "Ada", Side: geometry.Left},
+		Player{Name: "Grace", Side: geometry.Right},
+	)
+
+	bus.Publish(event.PaddleHit{Side: geometry.Left, Speed: 300})
+	bus.Publish(event.PaddleHit{Side: geometry.Right, Speed: 420})
+	bus.Publish(event.Goal{Side: geometry.Left, Scorer: geometry.Right})
+	bus.Publish(event.MatchOver{Winner: geometry.Right})
+
+	tests := map[string]Record{
+		"Ada":   {Mode: Local, Level: level.Hard, Played: 1, Losses: 1},
+		"Grace": {Mode: Local, Level: level.Hard, Played: 1, Wins: 1},
+	}
+
+	for name, want := range tests {
+		p := store.Profile(name)
+
+		if got := *p.Record(Local, level.Hard); got != want {
+			t.Errorf("%s record = %+v, want %+v", name, got, want)
+		}
+
+		if p.LongestRally != 2 || p.FastestBall != 420 || p.Points != 1 {
+			t.Errorf("%s stats = %+v", name, p)
+		}
+	}
+
+	if names := store.Names(); len(names) != 2 {
+		t.Errorf("profiles = %v, want only the players of the match", names)
+	}
+
+	// matches that are stopped aren't recorded
+	tracker.Start(VsCPU, level.Easy, func() time.Duration { return 0 }, Player{Name: "Ada", Side: geometry.Left})
+	tracker.Stop()
+	bus.Publish(event.MatchOver{Winner: geometry.Left})
+
+	if got := store.Profile("Ada").Record(VsCPU, level.Easy).Played; got != 0 {
+		t.Errorf("stopped match recorded %d times", got)
+	}
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+)
+
+// History keeps the online matches that are over. It's safe for concurrent use.
+type History struct {
+	mu sync.Mutex
+	// path is the file the matches are saved to, empty to keep them in memory.
+	path string
+	// matches are the matches, the oldest first.
+	matches []network.MatchRecord
+}
+
+// NewHistory creates a history saving the matches to the file at path, loading the ones
+// already saved. An empty path keeps the matches in memory.
+func NewHistory(path string) (*History, error) {
+	h := &History{path: path}
+
+	if path == "" {
+		return h, nil
+	}
+
+	data, err := os.ReadFile(path) // nolint:gosec
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load matches: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &h.matches); err != nil {
+		return nil, fmt.Errorf("failed to parse matches: %w", err)
+	}
+
+	return h, nil
+}
+
+// Add adds a match and saves the history.
+func (h *History) Add(match network.MatchRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.matches = append(h.matches, match)
+
+	if h.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(h.matches, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode matches: %w", err)
+	}
+
+	if err := os.WriteFile(h.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save matches: %w", err)
+	}
+
+	return nil
+}
+
+// Player returns limit matches of the player from offset, the latest first,
+// along with the number of matches they played.
+func (h *History) Player(name string, offset, limit int) ([]network.MatchRecord, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var played []network.MatchRecord
+
+	for _, m := range slices.Backward(h.matches) {
+		if slices.ContainsFunc(m.Players, func(p network.MatchPlayer) bool { return strings.EqualFold(p.Name, name) }) {
+			played = append(played, m)
+		}
+	}
+
+	offset = min(max(offset, 0), len(played))
+
+	return played[offset:min(offset+max(limit, 0), len(played))], len(played)
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/pkg/matchmaking"
+	"github.com/gandarez/pong-multiplayer-go/pkg/rating"
+)
+
+// pairingTTL is how long the opponent of a paired player is kept for their game server to fetch it.
+const pairingTTL = time.Minute
+
+type (
+	// Queue holds the players waiting for an online match and pairs them with matchmaking.Queue,
+	// preferring close ratings and accepting wider differences the longer they wait. Players are
+	// paired whenever the queue is read. Names are matched regardless of case.
+	// It's safe for concurrent use.
+	Queue struct {
+		mu      sync.Mutex
+		waiting matchmaking.Queue[string]
+		// players are the players waiting, by key.
+		players map[string]queued
+		// pairings are the players paired recently, by key.
+		pairings map[string]pairing
+	}
+
+	// queued is a player waiting in the queue.
+	queued struct {
+		name   string
+		rating rating.Rating
+	}
+
+	// pairing is the opponent a player was paired with.
+	pairing struct {
+		opponent string
+		paired   time.Time
+	}
+)
+
+// NewQueue creates an empty Queue.
+func NewQueue() *Queue {
+	return &Queue{
+		players:  make(map[string]queued),
+		pairings: make(map[string]pairing),
+	}
+}
+
+// Join adds a player with the given rating to the queue, or updates their rating if they're
+// already in it. A player paired before joins again.
+func (q *Queue) Join(name string, r rating.Rating, now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	k := queueKey(name)
+
+	delete(q.pairings, k)
+	q.waiting.Join(k, r, now)
+	q.players[k] = queued{name: name, rating: r}
+}
+
+// Leave removes a player from the queue, e.g. when they disconnect, forgetting their opponent
+// if they were paired.
+func (q *Queue) Leave(name string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	k := queueKey(name)
+
+	q.waiting.Leave(k)
+	delete(q.players, k)
+	delete(q.pairings, k)
+}
+
+// Status pairs the players that can be paired at now and returns the status of the player.
+// It returns false if the player is neither waiting nor paired.
+func (q *Queue) Status(name string, now time.Time) (network.QueueStatus, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pair(now)
+
+	k := queueKey(name)
+
+	if p, ok := q.pairings[k]; ok {
+		return network.QueueStatus{Opponent: p.opponent}, true
+	}
+
+	player, ok := q.players[k]
+	if !ok {
+		return network.QueueStatus{}, false
+	}
+
+	wait := q.waiting.Wait(k, now)
+
+	status := network.QueueStatus{
+		Queued: true,
+		Rating: player.rating.Rating,
+		Wait:   wait.Seconds(),
+	}
+
+	if window := matchmaking.Window(wait); !math.IsInf(window, 1) {
+		status.Window = window
+	}
+
+	return status, true
+}
+
+// pair pairs the players that can be paired at now, forgetting the pairings older than pairingTTL.
+func (q *Queue) pair(now time.Time) {
+	for k, p := range q.pairings {
+		if now.Sub(p.paired) > pairingTTL {
+			delete(q.pairings, k)
+		}
+	}
+
+	for _, p := range q.waiting.Pairs(now) {
+		first, second := q.players[p.First], q.players[p.Second]
+
+		q.pairings[p.First] = pairing{opponent: second.name, paired: now}
+		q.pairings[p.Second] = pairing{opponent: first.name, paired: now}
+
+		delete(q.players, p.First)
+		delete(q.players, p.Second)
+	}
+}
+
+// queueKey returns the key of a name in the queue, names differing only by case being the same.
+func queueKey(name string) string {
+	return strings.ToLower(name)
+}
@@ -0,0 +1,102 @@
+package server_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/server"
+	"github.com/gandarez/pong-multiplayer-go/pkg/matchmaking"
+	"github.com/gandarez/pong-multiplayer-go/pkg/rating"
+)
+
+// rated returns the rating of a player with the given rating and the default deviation.
+func rated(r float64) rating.Rating {
+	return rating.Rating{Rating: r, Deviation: rating.DefaultDeviation, Volatility: rating.DefaultVolatility}
+}
+
+func TestQueue_Status_WindowWidens(t *testing.T) {
+	q := server.NewQueue()
+	start := time.Now()
+
+	q.Join("Ada", rated(1500), start)
+	q.Join("Bob", rated(1750), start)
+
+	// 250 points apart: paired once the window grew by 150 points
+	for _, wait := range []time.Duration{0, 10 * time.Second} {
+		status, ok := q.Status("Ada", start.Add(wait))
+		if !ok {
+			t.Fatalf("after %v: Ada isn't in the queue", wait)
+		}
+
+		want := network.QueueStatus{
+			Queued: true,
+			Rating: 1500,
+			Wait:   wait.Seconds(),
+			Window: matchmaking.Window(wait),
+		}
+		if status != want {
+			t.Errorf("after %v: status = %+v, want %+v", wait, status, want)
+		}
+	}
+
+	paired := start.Add(15 * time.Second)
+
+	for name, opponent := range map[string]string{"ada": "Bob", "Bob": "Ada"} {
+		if status, ok := q.Status(name, paired); !ok || status != (network.QueueStatus{Opponent: opponent}) {
+			t.Errorf("status of %s = %+v, %t, want paired with %s", name, status, ok, opponent)
+		}
+	}
+
+	// the opponent is kept for a while only
+	if status, ok := q.Status("Ada", paired.Add(2*time.Minute)); ok {
+		t.Errorf("status = %+v, want Ada forgotten", status)
+	}
+}
+
+func TestQueue_Status_ClosestRating(t *testing.T) {
+	q := server.NewQueue()
+	now := time.Now()
+
+	q.Join("Ada", rated(1500), now)
+	q.Join("Bob", rated(1590), now)
+	q.Join("Cyd", rated(1520), now)
+
+	if status, _ := q.Status("Ada", now); status.Opponent != "Cyd" {
+		t.Errorf("opponent of Ada = %q, want Cyd", status.Opponent)
+	}
+
+	// nobody is close enough yet for Bob
+	if status, _ := q.Status("Bob", now); !status.Queued {
+		t.Errorf("status of Bob = %+v, want them waiting", status)
+	}
+}
+
+func TestQueue_Status_AnyOpponent(t *testing.T) {
+	q := server.NewQueue()
+	now := time.Now()
+
+	q.Join("Ada", rated(1500), now)
+
+	status, _ := q.Status("Ada", now.Add(matchmaking.MaxWait))
+	if !status.Queued || status.Window != 0 {
+		t.Errorf("status = %+v, want any opponent accepted", status)
+	}
+}
+
+func TestQueue_Leave(t *testing.T) {
+	q := server.NewQueue()
+	now := time.Now()
+
+	q.Join("Ada", rated(1500), now)
+	q.Leave("ADA")
+	q.Join("Bob", rated(1500), now)
+
+	if status, ok := q.Status("Ada", now); ok {
+		t.Errorf("status of Ada = %+v, want them out of the queue", status)
+	}
+
+	if status, _ := q.Status("Bob", now); !status.Queued {
+		t.Errorf("status of Bob = %+v, want them waiting", status)
+	}
+}
@@ -0,0 +1,392 @@
+// Package server is the reference account server. It verifies the identities of the players
+// joining a game server, rates them after their matches and serves the public profiles
+// of the accounts, the leaderboard, the history of the matches and the brackets of the tournaments
+// being played.
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+	"github.com/gandarez/pong-multiplayer-go/pkg/rating"
+)
+
+const (
+	// maxTournamentSize is the largest body accepted when publishing a tournament, far more than
+	// the bracket of MaxEntrants entrants.
+	maxTournamentSize = 64 << 10
+	// maxVerifySize is the largest body accepted when verifying a player, far more than a name
+	// and its identity.
+	maxVerifySize = 4 << 10
+	// maxResultsSize is the largest body accepted when recording the results of a match, far more
+	// than the four players of a four-way match.
+	maxResultsSize = 16 << 10
+	// maxQueueSize is the largest body accepted when a player joins the queue, far more than a name.
+	maxQueueSize = 1 << 10
+)
+
+type (
+	// Server handles the account requests.
+	Server struct {
+		registry *account.Registry
+		history  *History
+		// tournaments are the brackets published for spectators.
+		tournaments *Tournaments
+		// queue pairs the players waiting for a match by rating.
+		queue *Queue
+		// host is the name of the game server the identities are signed for.
+		host string
+		// secret is the token game servers send to report results and queue players, empty to refuse them.
+		secret string
+		mux    *http.ServeMux
+	}
+
+	// VerifyRequest is sent by game servers to check the player joining under a name.
+	VerifyRequest struct {
+		Name string `json:"name"`
+		// Identity is the identity sent by the player in the multiplayer handshake, nil for guests.
+		// Its challenge, issued by this server, is accepted once.
+		Identity *account.Identity `json:"identity,omitempty"`
+	}
+
+	// VerifyResponse tells game servers whether the player can join under the name.
+	VerifyResponse struct {
+		// Verified is true when the player owns the account holding the name,
+		// false for guests using a name nobody reserved.
+		Verified bool `json:"verified"`
+		// Rating is the rating of the player, used to pair players with close ratings,
+		// the rating of a new player for guests.
+		Rating rating.Rating `json:"rating"`
+		// Error tells why the player can't join, to be forwarded in the ready message.
+		Error string `json:"error,omitempty"`
+	}
+
+	// QueueRequest is sent by game servers to queue a verified player for a match.
+	QueueRequest struct {
+		Name string `json:"name"`
+	}
+
+	// ResultsRequest is sent by game servers once an online match is over.
+	ResultsRequest struct {
+		// Players are the players of the match, with their final score.
+		Players []network.MatchPlayer `json:"players"`
+		// Replay is the ID of the replay of the match the game server stored, if any.
+		Replay string `json:"replay,omitempty"`
+	}
+)
+
+// New creates a new Server keeping the accounts in the registry and the matches in the history,
+// for identities signed for host. Game servers report results and queue players with the secret
+// as a bearer token, an empty secret refusing them.
+func New(registry *account.Registry, history *History, host, secret string) *Server {
+	s := &Server{
+		registry:    registry,
+		history:     history,
+		tournaments: NewTournaments(),
+		queue:       NewQueue(),
+		host:        host,
+		secret:      secret,
+		mux:         http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("POST /challenge", s.challenge)
+	s.mux.HandleFunc("POST /verify", s.verify)
+	s.mux.HandleFunc("POST /results", s.results)
+	s.mux.HandleFunc("POST /queue", s.joinQueue)
+	s.mux.HandleFunc("GET /queue/{name}", s.queueStatus)
+	s.mux.HandleFunc("DELETE /queue/{name}", s.leaveQueue)
+	s.mux.HandleFunc("GET /profiles/{name}", s.profile)
+	s.mux.HandleFunc("GET /leaderboard", s.leaderboard)
+	s.mux.HandleFunc("GET /players/{name}/matches", s.matches)
+	s.mux.HandleFunc("PUT /tournaments/{id}", s.publishTournament)
+	s.mux.HandleFunc("GET /tournaments", s.playerTournaments)
+
+	return s
+}
+
+// ServeHTTP handles a request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// challenge issues a challenge for a player about to join a match, signed by their identity.
+func (s *Server) challenge(w http.ResponseWriter, _ *http.Request) {
+	nonce, err := s.registry.Challenge(time.Now())
+
+	switch {
+	case errors.Is(err, account.ErrTooManyChallenges):
+		http.Error(w, "too many challenges, try again later", http.StatusServiceUnavailable)
+	case err != nil:
+		slog.Error("failed to issue challenge", slog.Any("error", err))
+		http.Error(w, "failed to issue challenge", http.StatusInternalServerError)
+	default:
+		writeJSON(w, http.StatusOK, network.Challenge{Nonce: nonce})
+	}
+}
+
+// verify checks the identity of a player, reserving their name the first time it's used.
+func (s *Server) verify(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxVerifySize)
+
+	var req VerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
+		http.Error(w, "bad verify request", http.StatusBadRequest)
+		return
+	}
+
+	verified, err := s.registry.Authenticate(req.Name, s.host, req.Identity, time.Now())
+
+	switch {
+	case errors.Is(err, account.ErrNameReserved):
+		writeJSON(w, http.StatusForbidden, VerifyResponse{Error: "The name " + req.Name + " is reserved by another account"})
+	case errors.Is(err, account.ErrExpired):
+		writeJSON(w, http.StatusForbidden, VerifyResponse{Error: "Your identity expired, check the clock of your device"})
+	case errors.Is(err, account.ErrUnknownChallenge):
+		writeJSON(w, http.StatusForbidden, VerifyResponse{Error: "Your identity was already used, join again"})
+	case errors.Is(err, account.ErrInvalidSignature):
+		writeJSON(w, http.StatusForbidden, VerifyResponse{Error: "Your identity couldn't be verified"})
+	case err != nil:
+		slog.Error("failed to verify player", slog.String("name", req.Name), slog.Any("error", err))
+		http.Error(w, "failed to verify player", http.StatusInternalServerError)
+	default:
+		slog.Info("player verified", slog.String("name", req.Name), slog.Bool("verified", verified))
+		writeJSON(w, http.StatusOK, VerifyResponse{Verified: verified, Rating: s.registry.Rating(req.Name)})
+	}
+}
+
+// results updates the ratings of the players of a match, answering their changes by name.
+func (s *Server) results(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxResultsSize)
+
+	var req ResultsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad results request", http.StatusBadRequest)
+		return
+	}
+
+	var winners, losers []string
+
+	for _, p := range req.Players {
+		if p.Winner {
+			winners = append(winners, p.Name)
+		} else {
+			losers = append(losers, p.Name)
+		}
+	}
+
+	if len(winners) == 0 || len(losers) == 0 {
+		http.Error(w, "bad results request", http.StatusBadRequest)
+		return
+	}
+
+	changes, err := s.registry.RecordMatch(winners, losers)
+	if err != nil {
+		slog.Error("failed to record match", slog.Any("error", err))
+		http.Error(w, "failed to record match", http.StatusInternalServerError)
+
+		return
+	}
+
+	for i, p := range req.Players {
+		if change, ok := changes[p.Name]; ok {
+			req.Players[i].RatingChange = change.Change
+		}
+	}
+
+	match := network.MatchRecord{
+		ID:      matchID(),
+		Date:    time.Now().UTC(),
+		Players: req.Players,
+		Replay:  req.Replay,
+	}
+
+	if err := s.history.Add(match); err != nil {
+		slog.Error("failed to add match to history", slog.Any("error", err))
+	}
+
+	writeJSON(w, http.StatusOK, changes)
+}
+
+// joinQueue queues a verified player for a match, paired by their rating.
+func (s *Server) joinQueue(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxQueueSize)
+
+	var req QueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
+		http.Error(w, "bad queue request", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+
+	s.queue.Join(req.Name, s.registry.Rating(req.Name), now)
+
+	status, _ := s.queue.Status(req.Name, now)
+	writeJSON(w, http.StatusOK, status)
+}
+
+// queueStatus serves the status of a player in the queue: the opponents looked for while they
+// wait, then the opponent they were paired with.
+func (s *Server) queueStatus(w http.ResponseWriter, r *http.Request) {
+	status, ok := s.queue.Status(r.PathValue("name"), time.Now())
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
+
+// leaveQueue removes a player from the queue, e.g. when they disconnect.
+func (s *Server) leaveQueue(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	s.queue.Leave(r.PathValue("name"))
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// authorized returns true if the request is sent by a game server, with the secret as a bearer token.
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	return s.secret != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.secret)) == 1
+}
+
+// leaderboard serves a page of the players ranked by rating.
+func (s *Server) leaderboard(w http.ResponseWriter, r *http.Request) {
+	page := pageOf(r)
+
+	accounts, total := s.registry.Leaderboard((page-1)*network.PageSize, network.PageSize)
+
+	players := make([]network.RankedPlayer, 0, len(accounts))
+	for i, a := range accounts {
+		players = append(players, network.RankedPlayer{
+			Rank:      (page-1)*network.PageSize + i + 1,
+			Name:      a.Name,
+			Rating:    a.Rating.Rating,
+			Deviation: a.Rating.Deviation,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, network.LeaderboardPage{Players: players, Page: page, Pages: pages(total)})
+}
+
+// matches serves a page of the matches of a player.
+func (s *Server) matches(w http.ResponseWriter, r *http.Request) {
+	page := pageOf(r)
+
+	matches, total := s.history.Player(r.PathValue("name"), (page-1)*network.PageSize, network.PageSize)
+	if matches == nil {
+		matches = []network.MatchRecord{}
+	}
+
+	writeJSON(w, http.StatusOK, network.HistoryPage{Matches: matches, Page: page, Pages: pages(total)})
+}
+
+// publishTournament publishes the bracket of a tournament for spectators.
+func (s *Server) publishTournament(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxTournamentSize)
+
+	var update network.TournamentUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil || update.Token == "" || update.Bracket == nil {
+		http.Error(w, "bad tournament", http.StatusBadRequest)
+		return
+	}
+
+	// spectators draw the bracket, so one they can't draw is refused
+	if err := update.Bracket.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err := s.tournaments.Publish(r.PathValue("id"), update.Token, update.Bracket, time.Now())
+
+	switch {
+	case errors.Is(err, ErrWrongToken):
+		http.Error(w, "wrong tournament token", http.StatusForbidden)
+		return
+	case errors.Is(err, ErrTooManyTournaments):
+		http.Error(w, "too many tournaments", http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		slog.Error("failed to publish tournament", slog.Any("error", err))
+		http.Error(w, "failed to publish tournament", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// playerTournaments serves the tournaments being played that the player in the query entered.
+func (s *Server) playerTournaments(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.tournaments.Player(r.URL.Query().Get("player")))
+}
+
+// matchID returns a random ID for a match.
+func matchID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// pageOf returns the page asked for in the query of the request, the first one by default.
+func pageOf(r *http.Request) int {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		return 1
+	}
+
+	return page
+}
+
+// pages returns the number of pages listing total entries, at least one.
+func pages(total int) int {
+	return max((total+network.PageSize-1)/network.PageSize, 1)
+}
+
+// profile serves the public profile of the account holding a name.
+func (s *Server) profile(w http.ResponseWriter, r *http.Request) {
+	a, ok := s.registry.Account(r.PathValue("name"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, a)
+}
+
+// writeJSON writes the value as the JSON body of the response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to write response", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,630 @@
+package server_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/server"
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+	"github.com/gandarez/pong-multiplayer-go/pkg/rating"
+)
+
+const (
+	host   = "localhost"
+	secret = "results secret"
+)
+
+func TestServer_Verify(t *testing.T) {
+	owner, other := newKey(t), newKey(t)
+
+	tests := map[string]struct {
+		// body returns the body of the request, the identity signing a challenge of srv.
+		body     func(srv *server.Server) any
+		status   int
+		verified bool
+		// rejected is true when the response tells the player why they can't join.
+		rejected bool
+	}{
+		"owner": {
+			body: func(srv *server.Server) any {
+				return server.VerifyRequest{Name: "Ada", Identity: identity(t, srv, owner, "Ada", time.Now())}
+			},
+			status:   http.StatusOK,
+			verified: true,
+		},
+		"guest with a free name": {
+			body:   func(*server.Server) any { return server.VerifyRequest{Name: "Bob"} },
+			status: http.StatusOK,
+		},
+		"guest with a reserved name": {
+			body:     func(*server.Server) any { return server.VerifyRequest{Name: "ada"} },
+			status:   http.StatusForbidden,
+			rejected: true,
+		},
+		"reserved by another key": {
+			body: func(srv *server.Server) any {
+				return server.VerifyRequest{Name: "Ada", Identity: identity(t, srv, other, "Ada", time.Now())}
+			},
+			status:   http.StatusForbidden,
+			rejected: true,
+		},
+		"expired": {
+			body: func(srv *server.Server) any {
+				at := time.Now().Add(-account.MaxClockSkew - time.Minute)
+				return server.VerifyRequest{Name: "Ada", Identity: identity(t, srv, owner, "Ada", at)}
+			},
+			status:   http.StatusForbidden,
+			rejected: true,
+		},
+		"signed for another server": {
+			body: func(*server.Server) any {
+				id := account.Sign(owner, "Ada", "evil.test", "nonce", time.Now())
+				return server.VerifyRequest{Name: "Ada", Identity: id}
+			},
+			status:   http.StatusForbidden,
+			rejected: true,
+		},
+		"challenge not issued": {
+			body: func(*server.Server) any {
+				id := account.Sign(owner, "Ada", host, "nonce", time.Now())
+				return server.VerifyRequest{Name: "Ada", Identity: id}
+			},
+			status:   http.StatusForbidden,
+			rejected: true,
+		},
+		"no name": {
+			body:   func(*server.Server) any { return server.VerifyRequest{} },
+			status: http.StatusBadRequest,
+		},
+		"not JSON": {
+			body:   func(*server.Server) any { return "{" },
+			status: http.StatusBadRequest,
+		},
+		"too big": {
+			body: func(*server.Server) any {
+				return server.VerifyRequest{Name: strings.Repeat("Ada", 10_000)}
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv, _ := newAccountServer(t, "")
+
+			reserve(t, srv, owner, "Ada")
+
+			rec := serve(t, srv, http.MethodPost, "/verify", "", test.body(srv))
+			if rec.Code != test.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, test.status, rec.Body)
+			}
+
+			if test.status == http.StatusBadRequest {
+				return
+			}
+
+			resp := decode[server.VerifyResponse](t, rec)
+
+			if resp.Verified != test.verified {
+				t.Errorf("verified = %t, want %t", resp.Verified, test.verified)
+			}
+
+			if (resp.Error != "") != test.rejected {
+				t.Errorf("error = %q, want one: %t", resp.Error, test.rejected)
+			}
+
+			if test.status == http.StatusOK && resp.Rating != rating.Default() {
+				t.Errorf("rating = %+v, want the rating of a new player", resp.Rating)
+			}
+		})
+	}
+}
+
+func TestServer_Verify_Replayed(t *testing.T) {
+	srv, _ := newAccountServer(t, "")
+	req := server.VerifyRequest{Name: "Ada", Identity: identity(t, srv, newKey(t), "Ada", time.Now())}
+
+	if rec := serve(t, srv, http.MethodPost, "/verify", "", req); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	rec := serve(t, srv, http.MethodPost, "/verify", "", req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status replaying the identity = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	if resp := decode[server.VerifyResponse](t, rec); resp.Verified || resp.Error == "" {
+		t.Errorf("response = %+v, want the player rejected", resp)
+	}
+}
+
+func TestServer_Results(t *testing.T) {
+	players := []network.MatchPlayer{
+		{Name: "Ada", Side: geometry.Left, Score: 10, Winner: true},
+		{Name: "Bob", Side: geometry.Right, Score: 4},
+	}
+
+	tests := map[string]struct {
+		// secret is the secret the server was started with.
+		secret string
+		token  string
+		body   any
+		status int
+	}{
+		"recorded": {
+			secret: secret,
+			token:  secret,
+			body:   server.ResultsRequest{Players: players, Replay: "replay"},
+			status: http.StatusOK,
+		},
+		"no token": {
+			secret: secret,
+			body:   server.ResultsRequest{Players: players},
+			status: http.StatusUnauthorized,
+		},
+		"wrong token": {
+			secret: secret,
+			token:  "guess",
+			body:   server.ResultsRequest{Players: players},
+			status: http.StatusUnauthorized,
+		},
+		"server refusing results": {
+			body:   server.ResultsRequest{Players: players},
+			status: http.StatusUnauthorized,
+		},
+		"no loser": {
+			secret: secret,
+			token:  secret,
+			body:   server.ResultsRequest{Players: players[:1]},
+			status: http.StatusBadRequest,
+		},
+		"not JSON": {
+			secret: secret,
+			token:  secret,
+			body:   "{",
+			status: http.StatusBadRequest,
+		},
+		"too big": {
+			secret: secret,
+			token:  secret,
+			body:   server.ResultsRequest{Players: players, Replay: strings.Repeat("r", 20_000)},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv, registry := newAccountServer(t, test.secret)
+
+			reserve(t, srv, newKey(t), "Ada")
+
+			rec := serve(t, srv, http.MethodPost, "/results", test.token, test.body)
+			if rec.Code != test.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, test.status, rec.Body)
+			}
+
+			history := decode[network.HistoryPage](t, serve(t, srv, http.MethodGet, "/players/Bob/matches", "", nil))
+
+			if test.status != http.StatusOK {
+				if registry.Rating("Ada") != rating.Default() || len(history.Matches) != 0 {
+					t.Errorf("rating = %+v, matches = %+v, want nothing recorded", registry.Rating("Ada"), history.Matches)
+				}
+
+				return
+			}
+
+			// guests aren't rated
+			changes := decode[map[string]account.RatingChange](t, rec)
+			if len(changes) != 1 || changes["Ada"].Change <= 0 || changes["Ada"].Rating != registry.Rating("Ada") {
+				t.Errorf("changes = %+v, want the gain of Ada", changes)
+			}
+
+			if len(history.Matches) != 1 {
+				t.Fatalf("matches = %+v, want the match recorded", history.Matches)
+			}
+
+			match := history.Matches[0]
+			if match.Replay != "replay" || len(match.Players) != 2 || match.Players[0].RatingChange != changes["Ada"].Change {
+				t.Errorf("match = %+v, want the players with the rating change of Ada and the replay", match)
+			}
+		})
+	}
+}
+
+func TestServer_Queue(t *testing.T) {
+	srv, _ := newAccountServer(t, secret)
+
+	// Ada is rated far above a new player after winning a few matches
+	reserve(t, srv, newKey(t), "Ada")
+
+	for range 3 {
+		results := server.ResultsRequest{Players: []network.MatchPlayer{{Name: "Ada", Winner: true}, {Name: "guest"}}}
+
+		if rec := serve(t, srv, http.MethodPost, "/results", secret, results); rec.Code != http.StatusOK {
+			t.Fatalf("status recording results = %d, want %d", rec.Code, http.StatusOK)
+		}
+	}
+
+	for _, name := range []string{"Ada", "Bob", "Cyd"} {
+		rec := serve(t, srv, http.MethodPost, "/queue", secret, server.QueueRequest{Name: name})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status queueing %s = %d, want %d", name, rec.Code, http.StatusOK)
+		}
+
+		if status := decode[network.QueueStatus](t, rec); !status.Queued && status.Opponent == "" {
+			t.Errorf("status of %s = %+v, want them queued or paired", name, status)
+		}
+	}
+
+	// the guests, rated alike, are paired together while Ada waits for a closer opponent
+	tests := map[string]network.QueueStatus{
+		"bob": {Opponent: "Cyd"},
+		"Cyd": {Opponent: "Bob"},
+	}
+
+	for name, want := range tests {
+		rec := serve(t, srv, http.MethodGet, "/queue/"+name, "", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status of %s = %d, want %d", name, rec.Code, http.StatusOK)
+		}
+
+		if status := decode[network.QueueStatus](t, rec); status != want {
+			t.Errorf("status of %s = %+v, want %+v", name, status, want)
+		}
+	}
+
+	status := decode[network.QueueStatus](t, serve(t, srv, http.MethodGet, "/queue/Ada", "", nil))
+	if !status.Queued || status.Rating <= rating.Default().Rating || status.Window == 0 {
+		t.Errorf("status of Ada = %+v, want Ada waiting with their rating", status)
+	}
+
+	if rec := serve(t, srv, http.MethodDelete, "/queue/Ada", secret, nil); rec.Code != http.StatusOK {
+		t.Fatalf("status leaving = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	if rec := serve(t, srv, http.MethodGet, "/queue/Ada", "", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("status once Ada left = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestServer_Queue_Refused(t *testing.T) {
+	tests := map[string]struct {
+		secret string
+		method string
+		target string
+		token  string
+		body   any
+		status int
+	}{
+		"joining without token": {
+			secret: secret,
+			method: http.MethodPost,
+			target: "/queue",
+			body:   server.QueueRequest{Name: "Ada"},
+			status: http.StatusUnauthorized,
+		},
+		"joining a server refusing game servers": {
+			method: http.MethodPost,
+			target: "/queue",
+			token:  secret,
+			body:   server.QueueRequest{Name: "Ada"},
+			status: http.StatusUnauthorized,
+		},
+		"joining without name": {
+			secret: secret,
+			method: http.MethodPost,
+			target: "/queue",
+			token:  secret,
+			body:   server.QueueRequest{},
+			status: http.StatusBadRequest,
+		},
+		"joining with a name too long": {
+			secret: secret,
+			method: http.MethodPost,
+			target: "/queue",
+			token:  secret,
+			body:   server.QueueRequest{Name: strings.Repeat("Ada", 1000)},
+			status: http.StatusBadRequest,
+		},
+		"leaving with a wrong token": {
+			secret: secret,
+			method: http.MethodDelete,
+			target: "/queue/Ada",
+			token:  "guess",
+			status: http.StatusUnauthorized,
+		},
+		"status of a player not queued": {
+			secret: secret,
+			method: http.MethodGet,
+			target: "/queue/Bob",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv, _ := newAccountServer(t, test.secret)
+
+			if rec := serve(t, srv, test.method, test.target, test.token, test.body); rec.Code != test.status {
+				t.Errorf("status = %d, want %d", rec.Code, test.status)
+			}
+		})
+	}
+}
+
+func TestServer_Profile(t *testing.T) {
+	srv, _ := newAccountServer(t, "")
+	key := newKey(t)
+
+	reserve(t, srv, key, "Ada")
+
+	rec := serve(t, srv, http.MethodGet, "/profiles/ada", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	public, _ := key.Public().(ed25519.PublicKey)
+
+	profile := decode[account.Account](t, rec)
+	if profile.Name != "Ada" || profile.Fingerprint != account.Fingerprint(public) || profile.Rating != rating.Default() {
+		t.Errorf("profile = %+v, want the account of Ada", profile)
+	}
+
+	if rec := serve(t, srv, http.MethodGet, "/profiles/Bob", "", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("status of a name nobody reserved = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestServer_Leaderboard(t *testing.T) {
+	srv, _ := newAccountServer(t, secret)
+
+	for i := range network.PageSize + 2 {
+		reserve(t, srv, newKey(t), fmt.Sprintf("player%02d", i))
+	}
+
+	// the last player climbs to the top
+	results := server.ResultsRequest{Players: []network.MatchPlayer{
+		{Name: "player11", Winner: true},
+		{Name: "player00"},
+	}}
+
+	if rec := serve(t, srv, http.MethodPost, "/results", secret, results); rec.Code != http.StatusOK {
+		t.Fatalf("status recording results = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	tests := map[string]struct {
+		query   string
+		page    int
+		players []string
+		// rank is the rank of the first player of the page.
+		rank int
+	}{
+		"first page": {
+			page:    1,
+			players: []string{"player11", "player01", "player02", "player03", "player04"},
+			rank:    1,
+		},
+		"second page": {
+			query:   "?page=2",
+			page:    2,
+			players: []string{"player10", "player00"},
+			rank:    11,
+		},
+		"past the last page": {
+			query: "?page=5",
+			page:  5,
+		},
+		"bad page": {
+			query:   "?page=zero",
+			page:    1,
+			players: []string{"player11"},
+			rank:    1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := serve(t, srv, http.MethodGet, "/leaderboard"+test.query, "", nil)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			leaderboard := decode[network.LeaderboardPage](t, rec)
+
+			if leaderboard.Page != test.page || leaderboard.Pages != 2 {
+				t.Errorf("page = %d of %d, want %d of 2", leaderboard.Page, leaderboard.Pages, test.page)
+			}
+
+			if len(test.players) == 0 && len(leaderboard.Players) != 0 {
+				t.Errorf("players = %+v, want none", leaderboard.Players)
+			}
+
+			for i, want := range test.players {
+				if i >= len(leaderboard.Players) {
+					t.Fatalf("players = %+v, want %v first", leaderboard.Players, test.players)
+				}
+
+				if p := leaderboard.Players[i]; p.Name != want || p.Rank != test.rank+i {
+					t.Errorf("player %d = %+v, want %s ranked %d", i, p, want, test.rank+i)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_Matches(t *testing.T) {
+	srv, _ := newAccountServer(t, secret)
+
+	for i := range network.PageSize + 1 {
+		results := server.ResultsRequest{
+			Players: []network.MatchPlayer{{Name: "Ada", Winner: true}, {Name: "Bob"}},
+			Replay:  fmt.Sprint(i),
+		}
+
+		if rec := serve(t, srv, http.MethodPost, "/results", secret, results); rec.Code != http.StatusOK {
+			t.Fatalf("status recording results = %d, want %d", rec.Code, http.StatusOK)
+		}
+	}
+
+	tests := map[string]struct {
+		target string
+		page   int
+		pages  int
+		// replays are the replays of the matches served, the latest first.
+		replays []string
+	}{
+		"first page": {
+			target:  "/players/Ada/matches",
+			page:    1,
+			pages:   2,
+			replays: []string{"10", "9", "8", "7", "6", "5", "4", "3", "2", "1"},
+		},
+		"second page, another case": {
+			target:  "/players/bob/matches?page=2",
+			page:    2,
+			pages:   2,
+			replays: []string{"0"},
+		},
+		"no match": {
+			target: "/players/Cyd/matches",
+			page:   1,
+			pages:  1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := serve(t, srv, http.MethodGet, test.target, "", nil)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			history := decode[network.HistoryPage](t, rec)
+
+			if history.Page != test.page || history.Pages != test.pages {
+				t.Errorf("page = %d of %d, want %d of %d", history.Page, history.Pages, test.page, test.pages)
+			}
+
+			// clients range over the matches, so they're never null
+			if history.Matches == nil {
+				t.Fatal("matches = null, want a list")
+			}
+
+			replays := make([]string, 0, len(history.Matches))
+			for _, m := range history.Matches {
+				replays = append(replays, m.Replay)
+			}
+
+			if fmt.Sprint(replays) != fmt.Sprint(test.replays) {
+				t.Errorf("replays = %v, want %v", replays, test.replays)
+			}
+		})
+	}
+}
+
+// newAccountServer returns a server keeping everything in memory, accepting results reported with
+// the secret, along with its registry.
+func newAccountServer(t *testing.T, secret string) (*server.Server, *account.Registry) {
+	t.Helper()
+
+	registry, err := account.NewRegistry("")
+	if err != nil {
+		t.Fatalf("failed to create registry: %v", err)
+	}
+
+	history, err := server.NewHistory("")
+	if err != nil {
+		t.Fatalf("failed to create history: %v", err)
+	}
+
+	return server.New(registry, history, host, secret), registry
+}
+
+// newKey returns a new private key.
+func newKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return key
+}
+
+// identity returns the identity of the player with the key joining under name at the given time,
+// signing a challenge issued by the server.
+func identity(t *testing.T, srv *server.Server, key ed25519.PrivateKey, name string, at time.Time) *account.Identity {
+	t.Helper()
+
+	rec := serve(t, srv, http.MethodPost, "/challenge", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status issuing challenge = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	return account.Sign(key, name, host, decode[network.Challenge](t, rec).Nonce, at)
+}
+
+// reserve reserves the name for the key.
+func reserve(t *testing.T, srv *server.Server, key ed25519.PrivateKey, name string) {
+	t.Helper()
+
+	req := server.VerifyRequest{Name: name, Identity: identity(t, srv, key, name, time.Now())}
+
+	if rec := serve(t, srv, http.MethodPost, "/verify", "", req); rec.Code != http.StatusOK {
+		t.Fatalf("status reserving %s = %d, want %d: %s", name, rec.Code, http.StatusOK, rec.Body)
+	}
+}
+
+// serve serves a request with the body encoded as JSON, a string being sent as is, and the token
+// as a bearer token if any.
+func serve(t *testing.T, srv *server.Server, method, target, token string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var r io.Reader
+
+	switch b := body.(type) {
+	case nil:
+	case string:
+		r = strings.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("failed to encode request: %v", err)
+		}
+
+		r = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, target, r)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// decode decodes the JSON body of the response.
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var v T
+	if err := json.NewDecoder(rec.Body).Decode(&v); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	return v
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"cmp"
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/pkg/bracket"
+)
+
+const (
+	// tournamentTTL is how long a tournament is kept after its last update.
+	tournamentTTL = 24 * time.Hour
+	// maxTournaments is the largest number of tournaments kept at once.
+	maxTournaments = 1000
+)
+
+var (
+	// ErrWrongToken is returned when publishing a tournament with a token its ID wasn't derived from.
+	ErrWrongToken = errors.New("wrong tournament token")
+	// ErrTooManyTournaments is returned when publishing a new tournament while maxTournaments are kept.
+	ErrTooManyTournaments = errors.New("too many tournaments")
+)
+
+// Tournaments keeps the tournaments published for spectators, in memory.
+// It's safe for concurrent use.
+type Tournaments struct {
+	mu          sync.Mutex
+	tournaments map[string]network.LiveTournament
+}
+
+// NewTournaments creates an empty Tournaments.
+func NewTournaments() *Tournaments {
+	return &Tournaments{tournaments: make(map[string]network.LiveTournament)}
+}
+
+// Publish publishes the bracket of the tournament with the ID, derived from the token, forgetting
+// the tournaments that weren't updated for tournamentTTL. The bracket must be valid.
+func (t *Tournaments) Publish(id, token string, b *bracket.Bracket, now time.Time) error {
+	// the ID is derived from the token, so an ID can't be taken by anyone but the device running it
+	if token == "" || subtle.ConstantTimeCompare([]byte(network.TournamentID(token)), []byte(id)) != 1 {
+		return ErrWrongToken
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, live := range t.tournaments {
+		if now.Sub(live.Updated) > tournamentTTL {
+			delete(t.tournaments, key)
+		}
+	}
+
+	if _, ok := t.tournaments[id]; !ok && len(t.tournaments) >= maxTournaments {
+		return ErrTooManyTournaments
+	}
+
+	t.tournaments[id] = network.LiveTournament{ID: id, Bracket: b, Updated: now}
+
+	return nil
+}
+
+// Player returns the tournaments that aren't over with the player as an entrant, the latest updated first.
+func (t *Tournaments) Player(name string) []network.LiveTournament {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	found := []network.LiveTournament{}
+
+	for _, live := range t.tournaments {
+		if _, over := live.Bracket.Champion(); over || !live.Bracket.Includes(name) {
+			continue
+		}
+
+		found = append(found, live)
+	}
+
+	slices.SortFunc(found, func(a, b network.LiveTournament) int {
+		return cmp.Compare(b.Updated.UnixNano(), a.Updated.UnixNano())
+	})
+
+	return found
+}
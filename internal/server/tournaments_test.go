@@ -0,0 +1,152 @@
+package server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/server"
+	"github.com/gandarez/pong-multiplayer-go/pkg/bracket"
+)
+
+func TestServer_PublishTournament(t *testing.T) {
+	const token = "0123456789abcdef"
+
+	id := network.TournamentID(token)
+
+	tests := map[string]struct {
+		id     string
+		body   func(b *bracket.Bracket) any
+		status int
+	}{
+		"published": {
+			id:     id,
+			body:   func(b *bracket.Bracket) any { return network.TournamentUpdate{Token: token, Bracket: b} },
+			status: http.StatusOK,
+		},
+		"ID of another token": {
+			id:     network.TournamentID("another token"),
+			body:   func(b *bracket.Bracket) any { return network.TournamentUpdate{Token: token, Bracket: b} },
+			status: http.StatusForbidden,
+		},
+		"chosen ID": {
+			id:     "mine",
+			body:   func(b *bracket.Bracket) any { return network.TournamentUpdate{Token: token, Bracket: b} },
+			status: http.StatusForbidden,
+		},
+		"no token": {
+			id:     id,
+			body:   func(b *bracket.Bracket) any { return network.TournamentUpdate{Bracket: b} },
+			status: http.StatusBadRequest,
+		},
+		"no bracket": {
+			id:     id,
+			body:   func(*bracket.Bracket) any { return network.TournamentUpdate{Token: token} },
+			status: http.StatusBadRequest,
+		},
+		"invalid bracket": {
+			id: id,
+			body: func(b *bracket.Bracket) any {
+				b.Matches[3].Round = -1
+				return network.TournamentUpdate{Token: token, Bracket: b}
+			},
+			status: http.StatusBadRequest,
+		},
+		"too large": {
+			id: id,
+			body: func(b *bracket.Bracket) any {
+				return network.TournamentUpdate{Token: strings.Repeat("x", 1<<20), Bracket: b}
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := bracket.New(bracket.DoubleElimination, []string{"Ada", "Bob", "Cyd", "Dan"})
+			if err != nil {
+				t.Fatalf("failed to create bracket: %v", err)
+			}
+
+			srv, _ := newAccountServer(t, "")
+
+			if status := publish(t, srv, test.id, test.body(b)); status != test.status {
+				t.Errorf("status = %d, want %d", status, test.status)
+			}
+
+			tournaments := playerTournaments(t, srv, "bob")
+
+			if published := len(tournaments) == 1; published != (test.status == http.StatusOK) {
+				t.Errorf("%d tournaments published", len(tournaments))
+			}
+		})
+	}
+}
+
+func TestServer_PublishTournament_Update(t *testing.T) {
+	srv, _ := newAccountServer(t, "")
+
+	b, err := bracket.New(bracket.SingleElimination, []string{"Ada", "Bob"})
+	if err != nil {
+		t.Fatalf("failed to create bracket: %v", err)
+	}
+
+	const token = "0123456789abcdef"
+
+	update := network.TournamentUpdate{Token: token, Bracket: b}
+
+	if status := publish(t, srv, network.TournamentID(token), update); status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+
+	if tournaments := playerTournaments(t, srv, "Ada"); len(tournaments) != 1 {
+		t.Fatalf("tournaments = %+v, want the published one", tournaments)
+	}
+
+	if err := b.Record(0, "Bob"); err != nil {
+		t.Fatalf("failed to record match: %v", err)
+	}
+
+	if status := publish(t, srv, network.TournamentID(token), update); status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+
+	// tournaments that are over aren't served anymore
+	if tournaments := playerTournaments(t, srv, "Ada"); len(tournaments) != 0 {
+		t.Errorf("tournaments = %+v, want none", tournaments)
+	}
+}
+
+// publish publishes the body as the tournament with the ID and returns the status of the response.
+func publish(t *testing.T, srv *server.Server, id string, body any) int {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to encode tournament: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/tournaments/"+id, bytes.NewReader(data)))
+
+	return rec.Code
+}
+
+// playerTournaments returns the tournaments served for the player.
+func playerTournaments(t *testing.T, srv *server.Server, player string) []network.LiveTournament {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tournaments?player="+player, nil))
+
+	var tournaments []network.LiveTournament
+	if err := json.NewDecoder(rec.Body).Decode(&tournaments); err != nil {
+		t.Fatalf("failed to decode tournaments: %v", err)
+	}
+
+	return tournaments
+}
@@ -0,0 +1,266 @@
+// Package settings loads and saves the preferences of the user between sessions.
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/audio"
+	"github.com/gandarez/pong-multiplayer-go/internal/display"
+	"github.com/gandarez/pong-multiplayer-go/internal/fx"
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+)
+
+const (
+	// fileName is the name of the settings file.
+	fileName = "settings.json"
+	// controlsFileName is the file where the bindings were saved before the settings file existed.
+	controlsFileName = "controls.json"
+)
+
+// ErrUnreadable is returned when saving settings loaded in place of a settings file that couldn't
+// be read nor backed up, so the file isn't overwritten.
+var ErrUnreadable = errors.New("settings file can't be read nor backed up, not overwriting it")
+
+// Version is the version of the settings schema. It must be increased, along with a new
+// migration, whenever a field is renamed or changes meaning.
+const Version = 1
+
+// migrations upgrade the settings saved by older versions of the game. migrations[v] upgrades
+// a document from version v to version v+1, the fields being decoded as raw JSON values.
+// nolint:gochecknoglobals
+var migrations = map[int]func(doc map[string]json.RawMessage) error{}
+
+type (
+	// Settings are the preferences of the user.
+	Settings struct {
+		Version int `json:"version"`
+		// PlayerName is the name used in multiplayer matches.
+		PlayerName string `json:"player_name,omitempty"`
+		// OnlineAccount proves the player owns their name in multiplayer matches with the
+		// identity kept on this device, reserving it on the server. Off, the default, they join as a guest.
+		OnlineAccount bool `json:"online_account"`
+		// Level is the level selected by default.
+		Level level.Level `json:"level"`
+		// Handicaps are the handicaps of the players of local matches, by their place in the lineup.
+		// The first one is also the handicap asked for in multiplayer matches.
+		Handicaps []player.Handicap `json:"handicaps,omitempty"`
+		// ShowMetrics shows the performance metrics during matches.
+		ShowMetrics bool `json:"show_metrics"`
+		// Window is the size of the game window, zero to use the default size.
+		Window Window `json:"window"`
+		// Display is whether the game is fullscreen and how its screen is scaled to the window.
+		Display display.Settings `json:"display"`
+		// Audio holds the sound effects and music preferences.
+		Audio audio.Mix `json:"audio"`
+		// Effects turns the rumble, camera and particle effects on or off.
+		Effects fx.Settings `json:"effects"`
+		// Controls are the bindings of the actions, by action name. Actions missing
+		// or unknown to this version of the game are ignored.
+		Controls map[string]input.Binding `json:"controls,omitempty"`
+		// keep keeps the settings file unreadable by this version of the game from being overwritten
+		// when it couldn't be backed up.
+		keep bool
+	}
+
+	// Window is the size of the game window.
+	Window struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	}
+)
+
+// Default returns the default settings.
+func Default() *Settings {
+	return &Settings{
+		Version: Version,
+		Level:   level.Medium,
+		Audio:   audio.DefaultMix(),
+		Effects: fx.DefaultSettings(),
+	}
+}
+
+// Load loads the saved settings, migrating them from older versions when needed.
+// The default settings are returned when nothing was saved yet or, along with an error,
+// when the saved settings can't be read. Settings that can't be parsed are backed up first,
+// so saving the default settings doesn't lose them.
+func Load() (*Settings, error) {
+	data, err := load()
+	if errors.Is(err, storage.ErrNotFound) {
+		return Default(), nil
+	}
+
+	if err != nil {
+		return Default(), fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	s, err := Parse(data)
+	if err != nil {
+		return backup(data, err)
+	}
+
+	return s, nil
+}
+
+// backup keeps a copy of the settings that can't be parsed and returns the default settings
+// with the parsing error. The default settings won't be saved if the copy can't be made.
+func backup(data []byte, parseErr error) (*Settings, error) {
+	s := Default()
+
+	name, err := storage.Backup(fileName, data)
+	if err != nil {
+		s.keep = true
+
+		return s, errors.Join(parseErr, err)
+	}
+
+	return s, fmt.Errorf("%w, kept a copy in %s", parseErr, name)
+}
+
+// Parse decodes settings saved by any version of the game, migrating them to the current one.
+func Parse(data []byte) (*Settings, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	var version int
+	if raw, ok := doc["version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, fmt.Errorf("failed to parse settings version: %w", err)
+		}
+	}
+
+	if version > Version {
+		slog.Warn("settings saved by a newer version of the game, unknown fields are ignored",
+			slog.Int("version", version))
+	}
+
+	for ; version < Version; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			continue
+		}
+
+		if err := migrate(doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate settings from version %d: %w", version, err)
+		}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode migrated settings: %w", err)
+	}
+
+	s := Default()
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	s.Version = Version
+
+	if s.Level < level.Easy || s.Level > level.Hard {
+		s.Level = level.Medium
+	}
+
+	s.Audio = s.Audio.Clamp()
+	s.Display = s.Display.Valid()
+
+	for i, h := range s.Handicaps {
+		if !slices.Contains(player.Handicaps(), h) {
+			s.Handicaps[i] = player.NoHandicap
+		}
+	}
+
+	return s, nil
+}
+
+// Handicap returns the handicap of the player at the place i of the lineup of local matches.
+func (s *Settings) Handicap(i int) player.Handicap {
+	if i < 0 || i >= len(s.Handicaps) {
+		return player.NoHandicap
+	}
+
+	return s.Handicaps[i]
+}
+
+// SetHandicap sets the handicap of the player at the place i of the lineup of local matches.
+func (s *Settings) SetHandicap(i int, h player.Handicap) {
+	for len(s.Handicaps) <= i {
+		s.Handicaps = append(s.Handicaps, player.NoHandicap)
+	}
+
+	s.Handicaps[i] = h
+}
+
+// load reads the settings file. Before it existed only the bindings were saved, in their own
+// file, which is then read as the controls of a settings document without version.
+func load() ([]byte, error) {
+	data, err := storage.Read(fileName)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return data, err
+	}
+
+	controls, err := storage.Read(controlsFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]json.RawMessage{"controls": controls})
+}
+
+// Bindings returns the saved bindings of the actions known to this version of the game.
+func (s *Settings) Bindings() map[input.Action]input.Binding {
+	bindings := make(map[input.Action]input.Binding, len(s.Controls))
+
+	for name, binding := range s.Controls {
+		var action input.Action
+		if err := action.UnmarshalText([]byte(name)); err != nil {
+			continue
+		}
+
+		bindings[action] = binding
+	}
+
+	return bindings
+}
+
+// SetBindings sets the bindings of the actions to be saved.
+func (s *Settings) SetBindings(bindings map[input.Action]input.Binding) {
+	s.Controls = make(map[string]input.Binding, len(bindings))
+
+	for action, binding := range bindings {
+		name, err := action.MarshalText()
+		if err != nil {
+			continue
+		}
+
+		s.Controls[string(name)] = binding
+	}
+}
+
+// Save saves the settings.
+func (s *Settings) Save() error {
+	if s.keep {
+		return ErrUnreadable
+	}
+
+	s.Version = Version
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+
+	if err := storage.Write(fileName, data); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+
+	return nil
+}
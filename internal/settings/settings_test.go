@@ -0,0 +1,222 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/input"
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/internal/storage/storagetest"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+)
+
+// controlsV0 is a controls file saved before the settings file existed.
+const controlsV0 = `{
+  "move_up": {"key": "W", "button": -1},
+  "pause": {"key": "P", "button": 9}
+}`
+
+func TestParse_Version0(t *testing.T) {
+	s, err := Parse([]byte(`{"player_name": "Ada", "level": 2, "controls": ` + controlsV0 + `}`))
+	if err != nil {
+		t.Fatalf("failed to parse settings: %v", err)
+	}
+
+	if s.Version != Version {
+		t.Errorf("version = %d, want %d", s.Version, Version)
+	}
+
+	if s.PlayerName != "Ada" || s.Level != level.Hard {
+		t.Errorf("player name and level = %q, %v, want Ada, Hard", s.PlayerName, s.Level)
+	}
+
+	// fields missing from old files keep their default value
+	if s.Audio != Default().Audio || s.Effects != Default().Effects {
+		t.Errorf("audio and effects = %+v, %+v, want the defaults", s.Audio, s.Effects)
+	}
+
+	// players that never chose join as guests
+	if s.OnlineAccount {
+		t.Error("online account is on, want it off until the player turns it on")
+	}
+
+	bindings := s.Bindings()
+	if got := bindings[input.MoveUp]; got != (input.Binding{Key: ebiten.KeyW, Button: input.NoButton}) {
+		t.Errorf("move up binding = %+v", got)
+	}
+
+	if got := bindings[input.Pause]; got != (input.Binding{Key: ebiten.KeyP, Button: 9}) {
+		t.Errorf("pause binding = %+v", got)
+	}
+}
+
+func TestParse_Migrations(t *testing.T) {
+	previous := migrations
+	t.Cleanup(func() { migrations = previous })
+
+	var ran []int
+
+	migrations = map[int]func(doc map[string]json.RawMessage) error{
+		0: func(doc map[string]json.RawMessage) error {
+			ran = append(ran, 0)
+			doc["player_name"] = doc["name"]
+			delete(doc, "name")
+
+			return nil
+		},
+	}
+
+	s, err := Parse([]byte(`{"name": "Ada"}`))
+	if err != nil {
+		t.Fatalf("failed to parse settings: %v", err)
+	}
+
+	if s.PlayerName != "Ada" {
+		t.Errorf("player name = %q, want the migrated Ada", s.PlayerName)
+	}
+
+	if len(ran) != 1 {
+		t.Errorf("migration ran %d times, want once", len(ran))
+	}
+
+	// documents already at the current version aren't migrated again
+	ran = nil
+
+	if _, err := Parse([]byte(`{"version": 1, "player_name": "Ada"}`)); err != nil {
+		t.Fatalf("failed to parse settings: %v", err)
+	}
+
+	if len(ran) != 0 {
+		t.Errorf("migration ran on current settings")
+	}
+
+	migrations[0] = func(map[string]json.RawMessage) error { return errors.New("broken") }
+
+	if _, err := Parse([]byte(`{}`)); err == nil {
+		t.Error("failed migration didn't return an error")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not json":       `{"player_name": `,
+		"not an object":  `[1, 2]`,
+		"wrong version":  `{"version": "one"}`,
+		"wrong controls": `{"controls": 3}`,
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(data)); err == nil {
+				t.Error("invalid settings didn't return an error")
+			}
+		})
+	}
+}
+
+func TestParse_OutOfRange(t *testing.T) {
+	s, err := Parse([]byte(`{"version": 1, "level": 7, "handicaps": [0, 42], "display": {"theme": "Neon"}}`))
+	if err != nil {
+		t.Fatalf("failed to parse settings: %v", err)
+	}
+
+	if s.Level != level.Medium {
+		t.Errorf("level = %v, want Medium", s.Level)
+	}
+
+	if s.Handicap(1) != player.NoHandicap {
+		t.Errorf("handicap = %v, want none", s.Handicap(1))
+	}
+
+	if s.Display.Theme != Default().Display.Valid().Theme {
+		t.Errorf("theme = %q, want the default one", s.Display.Theme)
+	}
+}
+
+func TestLoad_NothingSaved(t *testing.T) {
+	storagetest.UseTempDir(t)
+
+	s, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load settings: %v", err)
+	}
+
+	if s.PlayerName != "" || s.Level != level.Medium {
+		t.Errorf("settings = %+v, want the defaults", s)
+	}
+}
+
+func TestLoad_ControlsFile(t *testing.T) {
+	storagetest.UseTempDir(t)
+
+	if err := storage.Write(controlsFileName, []byte(controlsV0)); err != nil {
+		t.Fatalf("failed to write controls file: %v", err)
+	}
+
+	s, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load settings: %v", err)
+	}
+
+	if got := s.Bindings()[input.Pause]; got != (input.Binding{Key: ebiten.KeyP, Button: 9}) {
+		t.Errorf("pause binding = %+v", got)
+	}
+
+	if err := s.Save(); err != nil {
+		t.Fatalf("failed to save settings: %v", err)
+	}
+
+	saved, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load saved settings: %v", err)
+	}
+
+	if saved.Version != Version || saved.Bindings()[input.Pause] != s.Bindings()[input.Pause] {
+		t.Errorf("saved settings = %+v, want the migrated controls", saved)
+	}
+}
+
+func TestLoad_Unreadable(t *testing.T) {
+	storagetest.UseTempDir(t)
+
+	unreadable := []byte(`{"player_name": "Ada", `)
+
+	if err := storage.Write(fileName, unreadable); err != nil {
+		t.Fatalf("failed to write settings file: %v", err)
+	}
+
+	s, err := Load()
+	if err == nil {
+		t.Fatal("unreadable settings didn't return an error")
+	}
+
+	if s.PlayerName != "" {
+		t.Errorf("settings = %+v, want the defaults", s)
+	}
+
+	if err := s.Save(); err != nil {
+		t.Fatalf("failed to save settings: %v", err)
+	}
+
+	backup, err := storage.Read(fileName + ".bak")
+	if err != nil {
+		t.Fatalf("failed to read the backup: %v", err)
+	}
+
+	if string(backup) != string(unreadable) {
+		t.Errorf("backup = %q, want %q", backup, unreadable)
+	}
+}
+
+func TestSave_NotBackedUp(t *testing.T) {
+	s := Default()
+	s.keep = true
+
+	if err := s.Save(); !errors.Is(err, ErrUnreadable) {
+		t.Errorf("error = %v, want %v", err, ErrUnreadable)
+	}
+}
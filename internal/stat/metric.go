@@ -2,20 +2,17 @@ package stat
 
 import (
 	"fmt"
-	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 
 	"github.com/gandarez/pong-multiplayer-go/internal/font"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
 	"github.com/gandarez/pong-multiplayer-go/internal/ui"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
-// nolint:gochecknoglobals
-var defaultColor = color.RGBA{0, 0, 0, 255}
-
 // Metric represents the game metric.
 type Metric struct {
 	screenWidth int
@@ -56,7 +53,7 @@ func (m *Metric) Draw(screen *ebiten.Image, bounces int, angle float64, lvl leve
 			X: 5,
 			Y: 0,
 		},
-		Color: defaultColor,
+		Color: theme.Active().Background,
 	}
 
 	uiText.Draw(screen)
@@ -78,7 +75,7 @@ func (m *Metric) DrawNetworkInfo(screen *ebiten.Image, pingCurrentPlayer, pingOp
 			X: float64(m.screenWidth) - width - 5,
 			Y: 0,
 		},
-		Color: defaultColor,
+		Color: theme.Active().Background,
 	}
 
 	uiText.Draw(screen)
@@ -0,0 +1,64 @@
+//go:build !js
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Dir returns the directory where the game keeps the files of the user.
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "pongo"), nil
+}
+
+// Read reads the file with the given name.
+func Read(name string) ([]byte, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name)) // nolint:gosec
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return data, err
+}
+
+// Write writes the file with the given name, creating its directory if needed.
+func Write(name string, data []byte) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil { // nolint:gosec
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, name), data, 0o600)
+}
+
+// Export writes a file the user can share, returning where it can be found.
+func Export(name string, data []byte) (string, error) {
+	if err := Write(name, data); err != nil {
+		return "", fmt.Errorf("failed to export %q: %w", name, err)
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, name), nil
+}
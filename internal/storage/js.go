@@ -0,0 +1,89 @@
+//go:build js
+
+package storage
+
+import (
+	"errors"
+	"strings"
+	"syscall/js"
+)
+
+// keyPrefix prefixes the names of the files in the local storage of the browser.
+const keyPrefix = "pongo."
+
+// Dir returns an error as browsers don't give access to the file system.
+func Dir() (string, error) {
+	return "", errors.New("no user config directory in the browser")
+}
+
+// key returns the key of the file in the local storage, its name without the extension.
+func key(name string) string {
+	return keyPrefix + strings.TrimSuffix(name, ".json")
+}
+
+// Read reads the file with the given name from the local storage of the browser.
+func Read(name string) ([]byte, error) {
+	storage, err := localStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	item := storage.Call("getItem", key(name))
+	if item.IsNull() {
+		return nil, ErrNotFound
+	}
+
+	return []byte(item.String()), nil
+}
+
+// Write writes the file with the given name to the local storage of the browser.
+func Write(name string, data []byte) (err error) {
+	storage, err := localStorage()
+	if err != nil {
+		return err
+	}
+
+	// setItem throws when the storage is full or disabled
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("failed to write to local storage")
+		}
+	}()
+
+	storage.Call("setItem", key(name), string(data))
+
+	return nil
+}
+
+// Export downloads a file the user can share, returning where it can be found.
+func Export(name string, data []byte) (string, error) {
+	document := js.Global().Get("document")
+	if document.IsUndefined() {
+		return "", errors.New("no document to download from")
+	}
+
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+
+	blob := js.Global().Get("Blob").New([]any{array}, map[string]any{"type": "application/json"})
+	url := js.Global().Get("URL").Call("createObjectURL", blob)
+
+	link := document.Call("createElement", "a")
+	link.Set("href", url)
+	link.Set("download", name)
+	link.Call("click")
+
+	js.Global().Get("URL").Call("revokeObjectURL", url)
+
+	return "your downloads", nil
+}
+
+// localStorage returns the local storage of the browser, which may be disabled.
+func localStorage() (js.Value, error) {
+	storage := js.Global().Get("localStorage")
+	if storage.IsUndefined() || storage.IsNull() {
+		return js.Value{}, errors.New("local storage not available")
+	}
+
+	return storage, nil
+}
@@ -0,0 +1,25 @@
+// Package storage keeps the files of the user between sessions: in the user config
+// directory on desktop, in the local storage of the browser in the web build.
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned when reading a file that wasn't written yet.
+var ErrNotFound = errors.New("file not found")
+
+// backupSuffix is added to the name of a file to name the copy kept by Backup.
+const backupSuffix = ".bak"
+
+// Backup keeps a copy of the content of a file that can't be read, so it isn't lost when the file
+// is written again. It returns the name of the copy.
+func Backup(name string, data []byte) (string, error) {
+	backup := name + backupSuffix
+	if err := Write(backup, data); err != nil {
+		return "", fmt.Errorf("failed to back up %q: %w", name, err)
+	}
+
+	return backup, nil
+}
@@ -0,0 +1,22 @@
+// Package storagetest provides helpers for the tests of the packages keeping files with storage.
+package storagetest
+
+import (
+	"runtime"
+	"testing"
+)
+
+// UseTempDir keeps the files written by the test in a temporary directory. It skips the test in
+// the browser, whose local storage is shared by the tests.
+func UseTempDir(t *testing.T) {
+	t.Helper()
+
+	if runtime.GOOS == "js" {
+		t.Skip("the local storage of the browser is shared by the tests")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+}
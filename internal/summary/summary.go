@@ -0,0 +1,202 @@
+// Package summary records what happens point by point in a match to sum it up once it's over.
+package summary
+
+import (
+	"maps"
+	"math"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// ShotBins is the number of directions the shots are sorted into, from the widest angle
+// towards one end of the field to the widest angle towards the other end.
+const ShotBins = 5
+
+// maxShotAngle is the widest angle of a shot from straight ahead, in degrees.
+const maxShotAngle = 75.0
+
+type (
+	// Summary sums up a match.
+	Summary struct {
+		// Points are the points played, in order.
+		Points []Point
+		// Hits are the paddle hits of each side.
+		Hits map[geometry.Side]int
+		// Shots count the paddle hits of each side by direction, see ShotBins.
+		Shots        map[geometry.Side]*[ShotBins]int
+		LongestRally int
+		// MaxSpeed is the highest speed of the ball leaving a paddle, in units per second.
+		MaxSpeed   float64
+		speedTotal float64
+		speedCount int
+		// Winner is the side that won the match, geometry.Undefined if it wasn't finished.
+		Winner geometry.Side
+	}
+
+	// Point is a point of the match.
+	Point struct {
+		// Scorer is the side given the point, geometry.Undefined when nobody scored.
+		Scorer geometry.Side
+		// Conceded is the side that conceded the goal, geometry.Undefined when it isn't known.
+		Conceded geometry.Side
+		// Scores are the scores of each side after the point.
+		Scores map[geometry.Side]int
+		// Rally is the number of paddle hits in the point.
+		Rally    int
+		Duration time.Duration
+	}
+)
+
+// AverageSpeed returns the average speed of the ball leaving a paddle, zero without hits.
+func (s *Summary) AverageSpeed() float64 {
+	if s.speedCount == 0 {
+		return 0
+	}
+
+	return s.speedTotal / float64(s.speedCount)
+}
+
+// Score returns the final score of the side.
+func (s *Summary) Score(side geometry.Side) int {
+	if len(s.Points) == 0 {
+		return 0
+	}
+
+	return s.Points[len(s.Points)-1].Scores[side]
+}
+
+// Recorder records the summary of the match being played from its events.
+type Recorder struct {
+	summary *Summary
+	// active is true while a match is being recorded.
+	active bool
+	// clock returns the time played in the match, used to measure the length of the points.
+	clock      func() time.Duration
+	pointStart time.Duration
+	rally      int
+	scores     map[geometry.Side]int
+}
+
+// NewRecorder creates a new Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Subscribe records the hits, goals and end of the matches published on the bus.
+func (r *Recorder) Subscribe(bus *event.Bus) {
+	event.On(bus, r.paddleHit)
+	event.On(bus, r.goal)
+	event.On(bus, r.matchOver)
+}
+
+// Start records a new match. clock returns the time played in the match.
+func (r *Recorder) Start(clock func() time.Duration) {
+	*r = Recorder{
+		summary: &Summary{
+			Hits:  make(map[geometry.Side]int),
+			Shots: make(map[geometry.Side]*[ShotBins]int),
+		},
+		active: true,
+		clock:  clock,
+		scores: make(map[geometry.Side]int),
+	}
+}
+
+// Summary returns the summary of the last match recorded, nil before the first one.
+func (r *Recorder) Summary() *Summary {
+	return r.summary
+}
+
+// paddleHit counts the hit, its speed and its direction.
+func (r *Recorder) paddleHit(hit event.PaddleHit) {
+	if !r.active {
+		return
+	}
+
+	s := r.summary
+
+	r.rally++
+	s.LongestRally = max(s.LongestRally, r.rally)
+
+	if hit.Speed > 0 {
+		s.MaxSpeed = max(s.MaxSpeed, hit.Speed)
+		s.speedTotal += hit.Speed
+		s.speedCount++
+	}
+
+	if hit.Side == geometry.Undefined {
+		return
+	}
+
+	s.Hits[hit.Side]++
+
+	if s.Shots[hit.Side] == nil {
+		s.Shots[hit.Side] = new([ShotBins]int)
+	}
+
+	s.Shots[hit.Side][shotBin(hit.Side, hit.Angle)]++
+}
+
+// goal ends the point.
+func (r *Recorder) goal(goal event.Goal) {
+	if !r.active {
+		return
+	}
+
+	if goal.Scorer != geometry.Undefined {
+		r.scores[goal.Scorer]++
+	}
+
+	now := r.clock()
+
+	r.summary.Points = append(r.summary.Points, Point{
+		Scorer:   goal.Scorer,
+		Conceded: goal.Side,
+		Scores:   maps.Clone(r.scores),
+		Rally:    r.rally,
+		Duration: now - r.pointStart,
+	})
+
+	r.rally = 0
+	r.pointStart = now
+}
+
+// matchOver ends the recording.
+func (r *Recorder) matchOver(over event.MatchOver) {
+	if !r.active {
+		return
+	}
+
+	r.active = false
+	r.summary.Winner = over.Winner
+}
+
+// shotBin returns the direction of a shot leaving the paddle defending side at angle, in degrees:
+// 0 is the widest angle towards the top or left end of the field and ShotBins-1 towards the other end.
+func shotBin(side geometry.Side, angle float64) int {
+	var forward float64
+
+	switch side {
+	case geometry.Right:
+		forward = 180
+	case geometry.Top:
+		forward = 90
+	case geometry.Bottom:
+		forward = -90
+	}
+
+	// the angle from straight ahead, between -180 and 180 degrees
+	relative := math.Mod(math.Mod(angle-forward, 360)+540, 360) - 180
+
+	// angles grow clockwise, towards the bottom of the field for the left side, mirror
+	// them for the sides facing the other way so bins go from the top or left end
+	if side == geometry.Right || side == geometry.Top {
+		relative = -relative
+	}
+
+	relative = min(max(relative, -maxShotAngle), maxShotAngle)
+
+	return min(int((relative+maxShotAngle)/(2*maxShotAngle)*ShotBins), ShotBins-1)
+}
@@ -0,0 +1,146 @@
+// Package theme holds the color palettes the game can be drawn with and the one in use.
+// Drawing code reads its colors from the active palette, so switching palettes recolors
+// the menus and the matches from the next frame.
+package theme
+
+import (
+	"image/color"
+	"slices"
+)
+
+// Palette is a named set of colors the game is drawn with.
+type Palette struct {
+	Name string
+	// Background fills the screen, Text draws the text, the field, the paddles and the ball,
+	// and Highlight draws the focused options and what needs attention.
+	Background color.RGBA
+	Text       color.RGBA
+	Highlight  color.RGBA
+	// Trail is the color of the trail left by the ball.
+	Trail color.RGBA
+	// Players are the colors of the players, by their place in the lineup of the match.
+	Players []color.RGBA
+}
+
+// Classic is the default palette, light gray on black.
+const Classic = "Classic"
+
+// nolint:gochecknoglobals
+var (
+	palettes = []Palette{
+		{
+			Name:       Classic,
+			Background: color.RGBA{0, 0, 0, 255},
+			Text:       color.RGBA{200, 200, 200, 255},
+			Highlight:  color.RGBA{255, 255, 0, 255},
+			Trail:      color.RGBA{100, 100, 100, 255},
+			Players: []color.RGBA{
+				{255, 200, 0, 255},
+				{0, 180, 255, 255},
+				{255, 80, 120, 255},
+				{120, 230, 120, 255},
+			},
+		},
+		{
+			Name:       "CRT green",
+			Background: color.RGBA{0, 14, 4, 255},
+			Text:       color.RGBA{60, 255, 110, 255},
+			Highlight:  color.RGBA{200, 255, 210, 255},
+			Trail:      color.RGBA{20, 120, 50, 255},
+			Players: []color.RGBA{
+				{60, 255, 110, 255},
+				{170, 255, 190, 255},
+				{0, 190, 80, 255},
+				{210, 255, 120, 255},
+			},
+		},
+		{
+			Name:       "High contrast",
+			Background: color.RGBA{0, 0, 0, 255},
+			Text:       color.RGBA{255, 255, 255, 255},
+			Highlight:  color.RGBA{255, 255, 0, 255},
+			Trail:      color.RGBA{170, 170, 170, 255},
+			Players: []color.RGBA{
+				{255, 255, 255, 255},
+				{0, 255, 255, 255},
+				{255, 255, 0, 255},
+				{255, 0, 255, 255},
+			},
+		},
+		{
+			// the colors of the players are told apart with any color vision deficiency
+			Name:       "Colorblind safe",
+			Background: color.RGBA{0, 0, 0, 255},
+			Text:       color.RGBA{230, 230, 230, 255},
+			Highlight:  color.RGBA{240, 228, 66, 255},
+			Trail:      color.RGBA{110, 110, 110, 255},
+			Players: []color.RGBA{
+				{230, 159, 0, 255},
+				{86, 180, 233, 255},
+				{0, 158, 115, 255},
+				{204, 121, 167, 255},
+			},
+		},
+	}
+
+	active = palettes[0]
+)
+
+// Names returns the names of the palettes, in the order they're offered.
+func Names() []string {
+	names := make([]string, 0, len(palettes))
+	for _, p := range palettes {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+// Find returns the palette with the name, false when there's none.
+func Find(name string) (Palette, bool) {
+	i := slices.IndexFunc(palettes, func(p Palette) bool { return p.Name == name })
+	if i < 0 {
+		return Palette{}, false
+	}
+
+	return palettes[i], true
+}
+
+// Set makes the palette with the name the active one, the classic palette when there's none.
+func Set(name string) {
+	p, ok := Find(name)
+	if !ok {
+		p = palettes[0]
+	}
+
+	active = p
+}
+
+// Active returns the palette in use.
+func Active() Palette {
+	return active
+}
+
+// Player returns the color of the player at the place i of the lineup.
+func (p Palette) Player(i int) color.RGBA {
+	if len(p.Players) == 0 {
+		return p.Text
+	}
+
+	return p.Players[i%len(p.Players)]
+}
+
+// Shade returns the background with the opacity alpha, to darken what's drawn under an overlay.
+func (p Palette) Shade(alpha uint8) color.RGBA {
+	return Fade(p.Background, float64(alpha)/255)
+}
+
+// Fade returns the premultiplied color with its opacity scaled by alpha, between 0 and 1.
+func Fade(clr color.RGBA, alpha float64) color.RGBA {
+	return color.RGBA{
+		R: uint8(float64(clr.R) * alpha),
+		G: uint8(float64(clr.G) * alpha),
+		B: uint8(float64(clr.B) * alpha),
+		A: uint8(float64(clr.A) * alpha),
+	}
+}
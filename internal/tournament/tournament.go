@@ -0,0 +1,131 @@
+// Package tournament keeps the tournament run on this device and records the results of its matches.
+package tournament
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/network"
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/pkg/bracket"
+)
+
+// fileName is the name of the file keeping the tournament.
+const fileName = "tournament.json"
+
+// Version is the version of the tournament schema.
+const Version = 1
+
+// Tournament is a tournament run on this device.
+type Tournament struct {
+	Version int `json:"version"`
+	// ID identifies the tournament on the account server and Token proves this device runs it.
+	ID    string `json:"id"`
+	Token string `json:"token"`
+	// Online is true when the entrants play their matches online from their own devices,
+	// this device watching them, and false when they play on this device.
+	Online  bool             `json:"online"`
+	Bracket *bracket.Bracket `json:"bracket"`
+}
+
+// New creates a tournament between the entrants, given from the best seed.
+func New(format bracket.Format, entrants []string, online bool) (*Tournament, error) {
+	b, err := bracket.New(format, entrants)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bracket: %w", err)
+	}
+
+	token := randomHex(16)
+
+	return &Tournament{
+		Version: Version,
+		ID:      network.TournamentID(token),
+		Token:   token,
+		Online:  online,
+		Bracket: b,
+	}, nil
+}
+
+// randomHex returns n random bytes encoded as hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// Load loads the saved tournament, nil when none is being run.
+func Load() (*Tournament, error) {
+	data, err := storage.Read(fileName)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tournament: %w", err)
+	}
+
+	var t *Tournament
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse tournament: %w", err)
+	}
+
+	if t == nil || t.Bracket == nil {
+		return nil, nil
+	}
+
+	if err := t.Bracket.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to load tournament: %w", err)
+	}
+
+	// tournaments saved before their ID was derived from their token get a new one
+	t.ID = network.TournamentID(t.Token)
+
+	return t, nil
+}
+
+// Save saves the tournament, so it can be resumed after the game is closed.
+func (t *Tournament) Save() error {
+	t.Version = Version
+
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode tournament: %w", err)
+	}
+
+	if err := storage.Write(fileName, data); err != nil {
+		return fmt.Errorf("failed to save tournament: %w", err)
+	}
+
+	return nil
+}
+
+// Clear forgets the saved tournament.
+func Clear() error {
+	if err := storage.Write(fileName, []byte("null")); err != nil {
+		return fmt.Errorf("failed to clear tournament: %w", err)
+	}
+
+	return nil
+}
+
+// Publish publishes the bracket of online tournaments to the account server in the background,
+// for spectators to follow it.
+func (t *Tournament) Publish() {
+	if !t.Online {
+		return
+	}
+
+	update := network.TournamentUpdate{Token: t.Token, Bracket: t.Bracket.Clone()}
+
+	go func() {
+		if err := network.PublishTournament(context.Background(), t.ID, update); err != nil {
+			slog.Error("failed to publish tournament", slog.String("id", t.ID), slog.Any("error", err))
+		}
+	}()
+}
@@ -0,0 +1,69 @@
+package tournament
+
+import (
+	"log/slog"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Tracker records the result of the tournament match being played once it's over.
+type Tracker struct {
+	// active is true while a tournament match is being played.
+	active     bool
+	tournament *Tournament
+	match      int
+	// names returns the name of the team defending a side, asked once the match is over
+	// since the players of network matches are only known as their states are received.
+	names func(side geometry.Side) string
+}
+
+// NewTracker creates a new Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Subscribe records the results of the matches ending on the bus.
+func (t *Tracker) Subscribe(bus *event.Bus) {
+	event.On(bus, t.matchOver)
+}
+
+// Start tracks the match with the ID of the tournament. names returns the name of the team
+// defending a side, which must be the name of an entrant for the result to be recorded.
+func (t *Tracker) Start(tournament *Tournament, match int, names func(side geometry.Side) string) {
+	*t = Tracker{
+		active:     true,
+		tournament: tournament,
+		match:      match,
+		names:      names,
+	}
+}
+
+// Stop stops tracking the match, leaving it to be played again, e.g. when the players quit it.
+func (t *Tracker) Stop() {
+	t.active = false
+}
+
+// matchOver records the winner of the match, then saves and publishes the tournament.
+func (t *Tracker) matchOver(over event.MatchOver) {
+	if !t.active {
+		return
+	}
+
+	t.active = false
+
+	winner := t.names(over.Winner)
+
+	if err := t.tournament.Bracket.Record(t.match, winner); err != nil {
+		slog.Error("failed to record tournament match", slog.Int("match", t.match), slog.String("winner", winner),
+			slog.Any("error", err))
+
+		return
+	}
+
+	if err := t.tournament.Save(); err != nil {
+		slog.Error("failed to save tournament", slog.Any("error", err))
+	}
+
+	t.tournament.Publish()
+}
@@ -0,0 +1,113 @@
+// Package training keeps the results of the training drills played on this device,
+// to follow the accuracy of the player over time.
+package training
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/storage"
+	"github.com/gandarez/pong-multiplayer-go/pkg/drill"
+)
+
+// fileName is the name of the file keeping the training history.
+const fileName = "training.json"
+
+// Version is the version of the training history schema.
+const Version = 1
+
+// maxSessions is the number of sessions kept for each drill, the oldest being dropped first.
+const maxSessions = 50
+
+type (
+	// History holds the sessions played of each drill, by drill name.
+	History struct {
+		Version int                  `json:"version"`
+		Drills  map[string][]Session `json:"drills"`
+	}
+
+	// Session is a drill played once.
+	Session struct {
+		Date time.Time `json:"date"`
+		drill.Result
+	}
+)
+
+// Load loads the saved history. An empty history is returned when nothing was saved yet or,
+// along with an error, when the saved history can't be read.
+func Load() (*History, error) {
+	history := newHistory()
+
+	data, err := storage.Read(fileName)
+	if errors.Is(err, storage.ErrNotFound) {
+		return history, nil
+	}
+
+	if err != nil {
+		return history, fmt.Errorf("failed to load training history: %w", err)
+	}
+
+	if err := json.Unmarshal(data, history); err != nil {
+		return newHistory(), fmt.Errorf("failed to parse training history: %w", err)
+	}
+
+	if history.Drills == nil {
+		history.Drills = make(map[string][]Session)
+	}
+
+	return history, nil
+}
+
+// newHistory creates a history without sessions.
+func newHistory() *History {
+	return &History{Version: Version, Drills: make(map[string][]Session)}
+}
+
+// Save saves the history.
+func (h *History) Save() error {
+	h.Version = Version
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode training history: %w", err)
+	}
+
+	if err := storage.Write(fileName, data); err != nil {
+		return fmt.Errorf("failed to save training history: %w", err)
+	}
+
+	return nil
+}
+
+// Add adds the result of a session of the drill played at date.
+func (h *History) Add(name string, result drill.Result, date time.Time) {
+	sessions := append(h.Drills[name], Session{Date: date, Result: result})
+	if len(sessions) > maxSessions {
+		sessions = sessions[len(sessions)-maxSessions:]
+	}
+
+	h.Drills[name] = sessions
+}
+
+// Sessions returns the sessions of the drill, from the oldest.
+func (h *History) Sessions(name string) []Session {
+	return h.Drills[name]
+}
+
+// Best returns the session of the drill with the best accuracy, false when it was never played.
+func (h *History) Best(name string) (Session, bool) {
+	var (
+		best  Session
+		found bool
+	)
+
+	for _, s := range h.Drills[name] {
+		if !found || s.Accuracy() > best.Accuracy() {
+			best, found = s, true
+		}
+	}
+
+	return best, found
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/bracket"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// bracketGap is the space between the columns of a bracket and between its matches.
+const bracketGap = 8
+
+// DrawBracket draws the rounds of the stage of the bracket as columns filling the area,
+// the matches of each round spread evenly down their column. Winners are highlighted and
+// the match with the ID next is framed, -1 framing none.
+func DrawBracket(screen *ebiten.Image, face text.Face, b *bracket.Bracket, stage bracket.Stage, area geometry.Rect,
+	next int) {
+	rounds := b.Rounds(stage)
+	if len(rounds) == 0 {
+		return
+	}
+
+	_, lineHeight := text.Measure("Ag", face, 1)
+	width := area.Width / float64(len(rounds))
+
+	for i, matches := range rounds {
+		x := area.X + float64(i)*width
+
+		(&Text{
+			Value:    roundName(b.Format, stage, i+1, len(rounds)),
+			FontFace: face,
+			Position: geometry.Vector{X: x, Y: area.Y},
+			Color:    theme.Active().Text,
+		}).Draw(screen)
+
+		top := area.Y + lineHeight + bracketGap
+		slot := (area.Height - lineHeight - bracketGap) / float64(len(matches))
+
+		for j, m := range matches {
+			box := geometry.Rect{
+				X:      x,
+				Y:      top + float64(j)*slot + max(slot-2*lineHeight-bracketGap, 0)/2,
+				Width:  width - bracketGap,
+				Height: min(2*lineHeight+4, slot-2),
+			}
+
+			drawBracketMatch(screen, face, m, box, m.ID == next)
+		}
+	}
+}
+
+// drawBracketMatch draws the entrants of a match in the box, on a line each when they fit.
+func drawBracketMatch(screen *ebiten.Image, face text.Face, m bracket.Match, box geometry.Rect, next bool) {
+	frame := theme.Active().Text
+	if next {
+		frame = theme.Active().Highlight
+	}
+
+	vector.StrokeRect(screen, float32(box.X), float32(box.Y), float32(box.Width), float32(box.Height), 1, frame, false)
+
+	_, lineHeight := text.Measure("Ag", face, 1)
+
+	if box.Height < 2*lineHeight {
+		// too many matches to give each entrant a line
+		line := fitText(fmt.Sprintf("%s v %s", entrantLabel(m, 0), entrantLabel(m, 1)), face, box.Width-4)
+		(&Text{
+			Value:    line,
+			FontFace: face,
+			Position: geometry.Vector{X: box.X + 2, Y: box.Y},
+			Color:    theme.Active().Text,
+		}).Draw(screen)
+
+		return
+	}
+
+	for i := range m.Entrants {
+		clr := theme.Active().Text
+		if m.Done && m.Winner != "" && m.Entrants[i] == m.Winner {
+			clr = theme.Active().Highlight
+		}
+
+		(&Text{
+			Value:    fitText(entrantLabel(m, i), face, box.Width-4),
+			FontFace: face,
+			Position: geometry.Vector{X: box.X + 2, Y: box.Y + 2 + float64(i)*lineHeight},
+			Color:    clr,
+		}).Draw(screen)
+	}
+}
+
+// entrantLabel returns how the entrant i of the match is shown: its name, "bye" when it's missing
+// or "?" while it isn't known.
+func entrantLabel(m bracket.Match, i int) string {
+	switch {
+	case m.Entrants[i] != "":
+		return m.Entrants[i]
+	case m.Done:
+		return "bye"
+	default:
+		return "?"
+	}
+}
+
+// roundName returns the name of the round, from 1, of the stage having rounds rounds.
+func roundName(format bracket.Format, stage bracket.Stage, round, rounds int) string {
+	switch {
+	case stage == bracket.GrandFinal && round == 2:
+		return "Reset"
+	case stage == bracket.GrandFinal:
+		return "Grand final"
+	case format == bracket.SingleElimination && round == rounds && round > 1:
+		return "Final"
+	case stage == bracket.Winners && round == rounds && round > 1:
+		return "Winners final"
+	default:
+		return fmt.Sprintf("Round %d", round)
+	}
+}
+
+// fitText shortens the text to fit the width, ending it with a dot when it's cut.
+func fitText(value string, face text.Face, width float64) string {
+	runes := []rune(value)
+
+	for len(runes) > 1 {
+		if w, _ := text.Measure(string(runes), face, 1); w <= width {
+			break
+		}
+
+		runes = append(runes[:len(runes)-2], '.')
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// buttonPadding is the space between the label of a button and its border.
+const buttonPadding = 6
+
+// Button represents a framed text that can be tapped or clicked.
+type Button struct {
+	Label    string
+	Position geometry.Vector
+	// Color is the color of the label, the text color of the theme when it isn't set.
+	Color    color.RGBA
+	FontFace text.Face
+	// Borderless draws the label without its frame, as the options of a list.
+	Borderless bool
+	// OnPress is called when the button is pressed with Confirm or tapped.
+	OnPress func()
+	control
+}
+
+var _ Control = (*Button)(nil)
+
+// NewButton creates a framed button calling onPress when it's pressed.
+func NewButton(label string, face text.Face, onPress func()) *Button {
+	return &Button{
+		Label:    label,
+		FontFace: face,
+		OnPress:  onPress,
+	}
+}
+
+// NewOption creates an option of a list, a button without frame calling onPress when it's chosen.
+func NewOption(label string, face text.Face, onPress func()) *Button {
+	b := NewButton(label, face, onPress)
+	b.Borderless = true
+
+	return b
+}
+
+// NewBackButton creates the button going back to the previous screen, at the top left corner.
+func NewBackButton(face text.Face) *Button {
+	return &Button{
+		Label:    "< Back",
+		FontFace: face,
+		Position: geometry.Vector{X: 10, Y: 10},
+	}
+}
+
+// Bounds returns the area of the screen covered by the button.
+func (b *Button) Bounds() geometry.Rect {
+	width, height := measure(b.Label, b.FontFace)
+
+	return geometry.Rect{
+		X:      b.Position.X,
+		Y:      b.Position.Y,
+		Width:  width + 2*buttonPadding,
+		Height: height + 2*buttonPadding,
+	}
+}
+
+// Contains returns true if the point is on the button.
+func (b *Button) Contains(p geometry.Vector) bool {
+	return b.Bounds().Contains(p)
+}
+
+// Size returns the width and the height of the button.
+func (b *Button) Size() (float64, float64) {
+	bounds := b.Bounds()
+
+	return bounds.Width, bounds.Height
+}
+
+// Place centers the button horizontally in the area, the label of a borderless button
+// at the top of the area.
+func (b *Button) Place(area geometry.Rect) {
+	width, _ := b.Size()
+
+	b.area = area
+	b.Position = centered(area, width)
+
+	if b.Borderless {
+		b.Position.Y -= buttonPadding
+	}
+}
+
+// Handle presses the button on Confirm or when it's tapped.
+func (b *Button) Handle(in *Input) {
+	if (in.Confirm || in.Tapped) && b.OnPress != nil {
+		b.OnPress()
+	}
+}
+
+// Draw draws the button on the screen, highlighted while it has the focus.
+func (b *Button) Draw(screen *ebiten.Image) {
+	bounds := b.Bounds()
+
+	clr := b.Color
+	if clr == (color.RGBA{}) {
+		clr = theme.Active().Text
+	}
+
+	if b.focused {
+		clr = theme.Active().Highlight
+	}
+
+	if !b.Borderless {
+		vector.StrokeRect(
+			screen,
+			float32(bounds.X), float32(bounds.Y),
+			float32(bounds.Width), float32(bounds.Height),
+			1, clr, true,
+		)
+	}
+
+	label := Text{
+		Value:    b.Label,
+		FontFace: b.FontFace,
+		Position: geometry.Vector{X: b.Position.X + buttonPadding, Y: b.Position.Y + buttonPadding},
+		Color:    clr,
+	}
+	label.Draw(screen)
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Choice is an option taking one of several values, drawn as "Label: < value >". Left and a tap
+// on its left half choose the previous value, Right, Confirm and a tap on its right half the next one.
+type Choice struct {
+	Label    string
+	FontFace text.Face
+	// Value returns the name of the current value.
+	Value func() string
+	// Change chooses the value dir steps away from the current one.
+	Change   func(dir int)
+	position geometry.Vector
+	control
+}
+
+var _ Control = (*Choice)(nil)
+
+// NewChoice creates a new Choice.
+func NewChoice(label string, face text.Face, value func() string, change func(dir int)) *Choice {
+	return &Choice{Label: label, FontFace: face, Value: value, Change: change}
+}
+
+// text returns the label and the current value.
+func (c *Choice) text() string {
+	return optionText(c.Label, c.Value())
+}
+
+// Size returns the width and the height of the choice.
+func (c *Choice) Size() (float64, float64) {
+	return measure(c.text(), c.FontFace)
+}
+
+// Place centers the choice horizontally in the area, at its top.
+func (c *Choice) Place(area geometry.Rect) {
+	width, _ := c.Size()
+
+	c.area = area
+	c.position = centered(area, width)
+}
+
+// Handle changes the value in the direction of the input.
+func (c *Choice) Handle(in *Input) {
+	if dir := c.step(in); dir != 0 && c.Change != nil {
+		c.Change(dir)
+	}
+}
+
+// Draw draws the choice, highlighted while it has the focus.
+func (c *Choice) Draw(screen *ebiten.Image) {
+	(&Text{Value: c.text(), FontFace: c.FontFace, Position: c.position, Color: c.textColor()}).Draw(screen)
+}
+
+// optionText returns how an option is drawn with its value.
+func optionText(label, value string) string {
+	return fmt.Sprintf("%s: < %s >", label, value)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 
 	"github.com/gandarez/pong-multiplayer-go/internal/font"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
@@ -26,7 +27,7 @@ func DrawWaitingConnection(screen *ebiten.Image, font *font.Font, screenWidth fl
 			X: (screenWidth - width) / 2,
 			Y: 250.0,
 		},
-		Color: DefaultColor,
+		Color: theme.Active().Text,
 	}
 
 	uiText.Draw(screen)
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	// dialogPadding is the space between the frame of a dialog and its content.
+	dialogPadding = 20
+	// dialogGap is the space between the message and the buttons of a dialog, and between its buttons.
+	dialogGap = 16
+)
+
+// Dialog is a message drawn over the screen with a row of buttons, such as a confirmation.
+// Left and Right move the focus between the buttons, Back cancels the dialog.
+type Dialog struct {
+	Message  string
+	FontFace text.Face
+	Buttons  []*Button
+	// OnCancel is called when the dialog is cancelled with Back.
+	OnCancel func()
+	focus    int
+}
+
+// NewDialog creates a new Dialog, the focus on its first button.
+func NewDialog(face text.Face, message string, buttons ...*Button) *Dialog {
+	d := &Dialog{Message: message, FontFace: face, Buttons: buttons}
+	d.setFocus(0)
+
+	return d
+}
+
+// NewConfirmDialog creates a dialog asking to confirm the message, calling onConfirm on Yes
+// and onCancel on No or Back. The focus is on No so an action isn't confirmed by mistake.
+func NewConfirmDialog(face text.Face, message string, onConfirm, onCancel func()) *Dialog {
+	d := NewDialog(face, message, NewButton("Yes", face, onConfirm), NewButton("No", face, onCancel))
+	d.OnCancel = onCancel
+	d.setFocus(1)
+
+	return d
+}
+
+// setFocus gives the focus to the button i.
+func (d *Dialog) setFocus(i int) {
+	d.focus = i
+
+	for j, b := range d.Buttons {
+		b.SetFocused(j == i)
+	}
+}
+
+// Update handles the input of the frame.
+func (d *Dialog) Update(in *Input) {
+	if in.Back {
+		if d.OnCancel != nil {
+			d.OnCancel()
+		}
+
+		return
+	}
+
+	if len(d.Buttons) == 0 {
+		return
+	}
+
+	switch {
+	case in.Left && d.focus > 0:
+		d.setFocus(d.focus - 1)
+	case in.Right && d.focus < len(d.Buttons)-1:
+		d.setFocus(d.focus + 1)
+	}
+
+	for i, b := range d.Buttons {
+		if in.Hovering && b.Contains(in.Cursor) {
+			d.setFocus(i)
+		}
+
+		if in.Tapped && b.Contains(in.Tap) {
+			b.Handle(&Input{Tapped: true, Tap: in.Tap})
+			return
+		}
+	}
+
+	if in.Confirm {
+		d.Buttons[d.focus].Handle(&Input{Confirm: true})
+	}
+}
+
+// Draw darkens the screen and draws the dialog at its center.
+func (d *Dialog) Draw(screen *ebiten.Image) {
+	bounds := screen.Bounds()
+	vector.DrawFilledRect(screen, 0, 0, float32(bounds.Dx()), float32(bounds.Dy()), theme.Active().Shade(128), false)
+
+	messageWidth, messageHeight := measure(d.Message, d.FontFace)
+
+	var buttonsWidth, buttonsHeight float64
+
+	for i, b := range d.Buttons {
+		width, height := b.Size()
+
+		buttonsWidth += width
+		if i > 0 {
+			buttonsWidth += dialogGap
+		}
+
+		buttonsHeight = max(buttonsHeight, height)
+	}
+
+	box := geometry.Rect{
+		Width:  max(messageWidth, buttonsWidth) + 2*dialogPadding,
+		Height: messageHeight + dialogGap + buttonsHeight + 2*dialogPadding,
+	}
+	box.X = (float64(bounds.Dx()) - box.Width) / 2
+	box.Y = (float64(bounds.Dy()) - box.Height) / 2
+
+	vector.DrawFilledRect(
+		screen,
+		float32(box.X), float32(box.Y),
+		float32(box.Width), float32(box.Height),
+		theme.Active().Background, false,
+	)
+	vector.StrokeRect(
+		screen,
+		float32(box.X), float32(box.Y),
+		float32(box.Width), float32(box.Height),
+		1, theme.Active().Text, false,
+	)
+
+	message := Label{Value: d.Message, FontFace: d.FontFace}
+	message.Place(geometry.Rect{X: box.X, Y: box.Y + dialogPadding, Width: box.Width})
+	message.Draw(screen)
+
+	x := box.X + (box.Width-buttonsWidth)/2
+	y := box.Y + dialogPadding + messageHeight + dialogGap
+
+	for _, b := range d.Buttons {
+		width, height := b.Size()
+
+		b.Place(geometry.Rect{X: x, Y: y, Width: width, Height: height})
+		b.Draw(screen)
+
+		x += width + dialogGap
+	}
+}
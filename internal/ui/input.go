@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Input is what the player did in a frame to navigate the widgets, with the keyboard,
+// a gamepad, the mouse or the touch screen.
+type Input struct {
+	Up, Down, Left, Right bool
+	// Confirm is Enter or the A button of a gamepad, Back is Esc or the B button.
+	Confirm, Back bool
+	// Tap is where the screen was just tapped or clicked, when Tapped.
+	Tap    geometry.Vector
+	Tapped bool
+	// Cursor is where the mouse cursor is while the mouse is used, when Hovering.
+	Cursor   geometry.Vector
+	Hovering bool
+	// Scroll is how far the mouse wheel was turned, positive downwards.
+	Scroll float64
+	// Chars are the characters typed and Erase is true when Backspace was just pressed.
+	Chars []rune
+	Erase bool
+}
+
+// Pointer tells where the screen is tapped or clicked and where the mouse cursor is.
+type Pointer interface {
+	Tap() (geometry.Vector, bool)
+	Cursor() (geometry.Vector, bool)
+}
+
+// ReadInput reads the input of the frame, the taps and the mouse cursor from the pointer.
+func ReadInput(pointer Pointer) *Input {
+	in := &Input{
+		Up:      inpututil.IsKeyJustPressed(ebiten.KeyUp) || gamepadJustPressed(ebiten.StandardGamepadButtonLeftTop),
+		Down:    inpututil.IsKeyJustPressed(ebiten.KeyDown) || gamepadJustPressed(ebiten.StandardGamepadButtonLeftBottom),
+		Left:    inpututil.IsKeyJustPressed(ebiten.KeyLeft) || gamepadJustPressed(ebiten.StandardGamepadButtonLeftLeft),
+		Right:   inpututil.IsKeyJustPressed(ebiten.KeyRight) || gamepadJustPressed(ebiten.StandardGamepadButtonLeftRight),
+		Confirm: inpututil.IsKeyJustPressed(ebiten.KeyEnter) || gamepadJustPressed(ebiten.StandardGamepadButtonRightBottom),
+		Back:    inpututil.IsKeyJustPressed(ebiten.KeyEscape) || gamepadJustPressed(ebiten.StandardGamepadButtonRightRight),
+		Chars:   ebiten.AppendInputChars(nil),
+		Erase:   inpututil.IsKeyJustPressed(ebiten.KeyBackspace),
+	}
+
+	_, in.Scroll = ebiten.Wheel()
+	in.Scroll = -in.Scroll
+
+	in.Tap, in.Tapped = pointer.Tap()
+	in.Cursor, in.Hovering = pointer.Cursor()
+
+	return in
+}
+
+// gamepadJustPressed returns true if the button of any gamepad was just pressed.
+func gamepadJustPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+			return true
+		}
+	}
+
+	return false
+}
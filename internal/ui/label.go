@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Label is a line of text the player can't interact with, such as a title, a hint or a message.
+type Label struct {
+	Value    string
+	FontFace text.Face
+	// Color is the color of the text, the text color of the theme when it isn't set.
+	Color color.RGBA
+	// Highlighted draws the text in the highlight color of the theme instead.
+	Highlighted bool
+	position    geometry.Vector
+}
+
+var _ Widget = (*Label)(nil)
+
+// NewLabel creates a new Label of the default color.
+func NewLabel(value string, face text.Face) *Label {
+	return &Label{Value: value, FontFace: face}
+}
+
+// Size returns the width and the height of the text.
+func (l *Label) Size() (float64, float64) {
+	return measure(l.Value, l.FontFace)
+}
+
+// Place centers the text horizontally in the area, at its top.
+func (l *Label) Place(area geometry.Rect) {
+	width, _ := l.Size()
+	l.position = centered(area, width)
+}
+
+// Draw draws the text where it was placed.
+func (l *Label) Draw(screen *ebiten.Image) {
+	clr := l.Color
+	if l.Highlighted {
+		clr = theme.Active().Highlight
+	} else if clr == (color.RGBA{}) {
+		clr = theme.Active().Text
+	}
+
+	(&Text{Value: l.Value, FontFace: l.FontFace, Position: l.position, Color: clr}).Draw(screen)
+}
+
+// DrawCentered draws a line of text centered horizontally on the screen at y.
+func DrawCentered(screen *ebiten.Image, value string, face text.Face, y float64, clr color.RGBA) {
+	label := Label{Value: value, FontFace: face, Color: clr}
+	label.Place(geometry.Rect{Y: y, Width: float64(screen.Bounds().Dx())})
+	label.Draw(screen)
+}
@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	// listMargin is the space left below a list reaching the bottom of the screen.
+	listMargin = 30
+	// indicatorSize is the size of the square drawn left of the focused row of a list with an indicator.
+	indicatorSize = 15
+	// indicatorGap is the space between the indicator and the widest row of a list.
+	indicatorGap = 30
+	// arrowSize is the size of the arrows drawn when a list has more rows above or below.
+	arrowSize = 6
+)
+
+// List is a vertical list of widgets centered horizontally on the screen. Up and Down move
+// the focus between its controls, skipping the other widgets, and the list scrolls when it
+// doesn't fit between Y and Bottom.
+type List struct {
+	Items []Widget
+	// Y is where the first row is drawn and Spacing the largest space between two rows. The rows
+	// get closer, down to MinSpacing, before the list scrolls.
+	Y, Spacing, MinSpacing float64
+	// Bottom is where the rows stop, listMargin pixels above the bottom of the screen when it isn't set.
+	Bottom float64
+	// Indicator draws a square left of the focused row instead of highlighting it.
+	Indicator bool
+	// OnMove is called when the focus moves or the list scrolls.
+	OnMove func()
+	focus  int
+	offset int
+	// width and bottom are the size of the screen the list was last drawn on.
+	width, bottom float64
+}
+
+// NewList creates a new List, the focus on its first control.
+func NewList(y, spacing float64, items ...Widget) *List {
+	l := &List{Items: items, Y: y, Spacing: spacing, MinSpacing: spacing}
+	l.SetFocus(l.next(-1, 1))
+
+	return l
+}
+
+// Focus returns the index of the focused row.
+func (l *List) Focus() int {
+	return l.focus
+}
+
+// SetFocus gives the focus to the row i, when it's a control, and scrolls to it.
+func (l *List) SetFocus(i int) {
+	if i < 0 || i >= len(l.Items) {
+		return
+	}
+
+	if _, ok := l.Items[i].(Control); !ok {
+		return
+	}
+
+	l.focus = i
+
+	for j, item := range l.Items {
+		if c, ok := item.(Control); ok {
+			c.SetFocused(j == i && !l.Indicator)
+		}
+	}
+
+	l.scrollTo(i)
+}
+
+// next returns the index of the first control after the row from in the direction dir,
+// -1 when there's none.
+func (l *List) next(from, dir int) int {
+	for i := from + dir; i >= 0 && i < len(l.Items); i += dir {
+		if _, ok := l.Items[i].(Control); ok {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// spacing returns the space between two rows.
+func (l *List) spacing() float64 {
+	if len(l.Items) == 0 || l.bottom == 0 {
+		return l.Spacing
+	}
+
+	return min(max((l.bottom-l.Y)/float64(len(l.Items)), l.MinSpacing), l.Spacing)
+}
+
+// visible returns the number of rows drawn at once.
+func (l *List) visible() int {
+	if l.bottom == 0 {
+		return len(l.Items)
+	}
+
+	return min(max(int((l.bottom-l.Y)/l.spacing()), 1), len(l.Items))
+}
+
+// scroll scrolls the list by n rows.
+func (l *List) scroll(n int) {
+	l.offset = min(max(l.offset+n, 0), len(l.Items)-l.visible())
+}
+
+// scrollTo scrolls the list until the row i is drawn.
+func (l *List) scrollTo(i int) {
+	switch visible := l.visible(); {
+	case i < l.offset:
+		l.offset = i
+	case i >= l.offset+visible:
+		l.offset = i - visible + 1
+	}
+}
+
+// rowAt returns the index of the row drawn at the Y position y.
+func (l *List) rowAt(y float64) (int, bool) {
+	i, ok := optionAt(y, l.Y, l.spacing(), l.visible())
+	if !ok {
+		return 0, false
+	}
+
+	return l.offset + i, true
+}
+
+// Update handles the input of the frame.
+func (l *List) Update(in *Input) {
+	if in.Hovering {
+		if i, ok := l.rowAt(in.Cursor.Y); ok && i != l.focus {
+			if _, ok := l.Items[i].(Control); ok {
+				l.SetFocus(i)
+				l.moved()
+			}
+		}
+	}
+
+	if in.Scroll != 0 {
+		offset := l.offset
+		l.scroll(int(math.Copysign(1, in.Scroll)))
+
+		if l.offset != offset {
+			l.moved()
+		}
+	}
+
+	switch {
+	case in.Up:
+		l.move(-1)
+		return
+	case in.Down:
+		l.move(1)
+		return
+	}
+
+	if in.Tapped {
+		i, ok := l.rowAt(in.Tap.Y)
+		if !ok {
+			return
+		}
+
+		if _, ok := l.Items[i].(Control); !ok {
+			return
+		}
+
+		l.SetFocus(i)
+	}
+
+	if c, ok := l.focused(); ok {
+		c.Handle(in)
+	}
+}
+
+// move moves the focus to the next control in the direction dir, staying put at the ends.
+func (l *List) move(dir int) {
+	if i := l.next(l.focus, dir); i >= 0 {
+		l.SetFocus(i)
+		l.moved()
+	}
+}
+
+// moved calls OnMove.
+func (l *List) moved() {
+	if l.OnMove != nil {
+		l.OnMove()
+	}
+}
+
+// focused returns the focused control.
+func (l *List) focused() (Control, bool) {
+	if l.focus < 0 || l.focus >= len(l.Items) {
+		return nil, false
+	}
+
+	c, ok := l.Items[l.focus].(Control)
+
+	return c, ok
+}
+
+// Draw lays the visible rows out on the screen and draws them, with arrows when there are more
+// rows above or below.
+func (l *List) Draw(screen *ebiten.Image) {
+	bounds := screen.Bounds()
+
+	l.width = float64(bounds.Dx())
+
+	l.bottom = l.Bottom
+	if l.bottom == 0 {
+		l.bottom = float64(bounds.Dy()) - listMargin
+	}
+
+	// the screen may have changed since the list was last drawn
+	l.scroll(0)
+	l.scrollTo(l.focus)
+
+	spacing := l.spacing()
+	end := l.offset + l.visible()
+
+	var widest float64
+
+	for j, item := range l.Items[l.offset:end] {
+		width, _ := item.Size()
+		widest = max(widest, width)
+
+		item.Place(geometry.Rect{Y: l.Y + float64(j)*spacing, Width: l.width, Height: spacing})
+		item.Draw(screen)
+	}
+
+	if l.Indicator && l.focus >= l.offset && l.focus < end {
+		vector.DrawFilledRect(
+			screen,
+			float32((l.width-widest)/2-indicatorGap),
+			float32(l.Y+float64(l.focus-l.offset)*spacing+5),
+			indicatorSize, indicatorSize,
+			theme.Active().Text, false,
+		)
+	}
+
+	if l.offset > 0 {
+		l.drawArrow(screen, l.Y-spacing/2, -1)
+	}
+
+	if end < len(l.Items) {
+		l.drawArrow(screen, l.Y+float64(l.visible())*spacing, 1)
+	}
+}
+
+// drawArrow draws an arrow pointing up or down, in the direction dir, centered at y.
+func (l *List) drawArrow(screen *ebiten.Image, y float64, dir int) {
+	x := float32(l.width / 2)
+	tip := float32(y) + float32(dir)*arrowSize/2
+	base := float32(y) - float32(dir)*arrowSize/2
+
+	vector.StrokeLine(screen, x-arrowSize, base, x, tip, 1, theme.Active().Text, true)
+	vector.StrokeLine(screen, x, tip, x+arrowSize, base, 1, theme.Active().Text, true)
+}
+
+// optionAt returns the index of the option of a vertical list found at the Y position y.
+// The first option is drawn at startY and the next ones every spacing pixels, the area of
+// each option starting a quarter of the spacing above its text.
+func optionAt(y, startY, spacing float64, count int) (int, bool) {
+	i := int(math.Floor((y-startY)/spacing + 0.25))
+	if i < 0 || i >= count {
+		return 0, false
+	}
+
+	return i, true
+}
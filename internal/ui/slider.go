@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	// sliderWidth and sliderHeight are the size of the bar of a slider.
+	sliderWidth  = 100
+	sliderHeight = 10
+	// sliderGap is the space between the label, the bar and the percentage of a slider.
+	sliderGap = 8
+)
+
+// Slider is an option taking a value from 0 to 1, drawn as "Label:" followed by a bar and
+// a percentage. Left and Right step the value, a tap on the bar sets it.
+type Slider struct {
+	Label    string
+	FontFace text.Face
+	Value    func() float64
+	Set      func(value float64)
+	// Steps is the number of steps from 0 to 1, 10 when it isn't set.
+	Steps    int
+	position geometry.Vector
+	control
+}
+
+var _ Control = (*Slider)(nil)
+
+// NewSlider creates a new Slider.
+func NewSlider(label string, face text.Face, value func() float64, set func(value float64)) *Slider {
+	return &Slider{Label: label, FontFace: face, Value: value, Set: set}
+}
+
+// parts returns the label and the percentage drawn around the bar.
+func (s *Slider) parts() (string, string) {
+	return s.Label + ":", fmt.Sprintf("%d%%", int(math.Round(s.Value()*100)))
+}
+
+// Size returns the width and the height of the slider.
+func (s *Slider) Size() (float64, float64) {
+	label, _ := s.parts()
+
+	labelWidth, height := measure(label, s.FontFace)
+	// the percentage is given the room of its widest value so the bar doesn't move
+	percentWidth, _ := measure("100%", s.FontFace)
+
+	return labelWidth + sliderWidth + percentWidth + 2*sliderGap, max(height, sliderHeight)
+}
+
+// Place centers the slider horizontally in the area, at its top.
+func (s *Slider) Place(area geometry.Rect) {
+	width, _ := s.Size()
+
+	s.area = area
+	s.position = centered(area, width)
+}
+
+// bar returns the area of the bar.
+func (s *Slider) bar() geometry.Rect {
+	label, _ := s.parts()
+	labelWidth, height := measure(label, s.FontFace)
+
+	return geometry.Rect{
+		X:      s.position.X + labelWidth + sliderGap,
+		Y:      s.position.Y + (height-sliderHeight)/2,
+		Width:  sliderWidth,
+		Height: sliderHeight,
+	}
+}
+
+// Handle sets the value where the bar was tapped, or steps it in the direction of the input.
+func (s *Slider) Handle(in *Input) {
+	if s.Set == nil {
+		return
+	}
+
+	bar := s.bar()
+
+	if in.Tapped && in.Tap.X >= bar.X && in.Tap.X <= bar.MaxX() {
+		s.Set(s.snap((in.Tap.X - bar.X) / bar.Width))
+		return
+	}
+
+	if dir := s.step(in); dir != 0 {
+		steps := s.steps()
+		s.Set(s.snap(s.Value() + float64(dir)/float64(steps)))
+	}
+}
+
+// steps returns the number of steps from 0 to 1.
+func (s *Slider) steps() int {
+	if s.Steps <= 0 {
+		return 10
+	}
+
+	return s.Steps
+}
+
+// snap rounds the value to the nearest step, between 0 and 1.
+func (s *Slider) snap(value float64) float64 {
+	steps := float64(s.steps())
+
+	return min(max(math.Round(value*steps)/steps, 0), 1)
+}
+
+// Draw draws the slider, highlighted while it has the focus.
+func (s *Slider) Draw(screen *ebiten.Image) {
+	label, percent := s.parts()
+	clr := s.textColor()
+	bar := s.bar()
+
+	(&Text{Value: label, FontFace: s.FontFace, Position: s.position, Color: clr}).Draw(screen)
+
+	vector.StrokeRect(
+		screen,
+		float32(bar.X), float32(bar.Y),
+		float32(bar.Width), float32(bar.Height),
+		1, clr, false,
+	)
+	vector.DrawFilledRect(
+		screen,
+		float32(bar.X), float32(bar.Y),
+		float32(bar.Width*min(max(s.Value(), 0), 1)), float32(bar.Height),
+		clr, false,
+	)
+
+	position := geometry.Vector{X: bar.MaxX() + sliderGap, Y: s.position.Y}
+	(&Text{Value: percent, FontFace: s.FontFace, Position: position, Color: clr}).Draw(screen)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 
 	"github.com/gandarez/pong-multiplayer-go/internal/font"
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
@@ -30,7 +31,7 @@ func DrawSplash(screen *ebiten.Image, font *font.Font, screenWidth float64) {
 			X: (screenWidth - width) / 2,
 			Y: 80,
 		},
-		Color: DefaultColor,
+		Color: theme.Active().Text,
 	}
 
 	uiText.Draw(screen)
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+	"github.com/gandarez/pong-multiplayer-go/pkg/roundrobin"
+)
+
+// standingsColumns are the headers of the columns following the names of the players in a standings table.
+var standingsColumns = []string{"P", "W", "D", "L", "GF", "GA", "GD", "Pts"} // nolint:gochecknoglobals
+
+// DrawStandings draws the standings as a table in the area, a line each from the leader down,
+// the names taking what's left of the width by the numbers.
+func DrawStandings(screen *ebiten.Image, face text.Face, standings []roundrobin.Standing, area geometry.Rect) {
+	_, lineHeight := text.Measure("Ag", face, 1)
+	numberWidth, _ := text.Measure("Pts ", face, 1)
+	nameWidth := area.Width - float64(len(standingsColumns))*numberWidth
+
+	row := func(y float64, name string, values []string, clr color.RGBA) {
+		(&Text{
+			Value:    fitText(name, face, nameWidth-4),
+			FontFace: face,
+			Position: geometry.Vector{X: area.X, Y: y},
+			Color:    clr,
+		}).Draw(screen)
+
+		for i, value := range values {
+			(&Text{
+				Value:    value,
+				FontFace: face,
+				Position: geometry.Vector{X: area.X + nameWidth + float64(i)*numberWidth, Y: y},
+				Color:    clr,
+			}).Draw(screen)
+		}
+	}
+
+	row(area.Y, "Player", standingsColumns, theme.Active().Highlight)
+
+	for i, s := range standings {
+		y := area.Y + float64(i+1)*lineHeight
+		if y+lineHeight > area.Y+area.Height {
+			return
+		}
+
+		row(y, strconv.Itoa(i+1)+". "+s.Player, []string{
+			strconv.Itoa(s.Played),
+			strconv.Itoa(s.Won),
+			strconv.Itoa(s.Drawn),
+			strconv.Itoa(s.Lost),
+			strconv.Itoa(s.GoalsFor),
+			strconv.Itoa(s.GoalsAgainst),
+			strconv.Itoa(s.GoalDifference()),
+			strconv.Itoa(s.Points),
+		}, theme.Active().Text)
+	}
+}
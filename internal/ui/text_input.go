@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// cursorBlink is how long the cursor of a text input is shown, then hidden.
+const cursorBlink = 500 * time.Millisecond
+
+// TextInput is a line of text the player types while it has the focus, drawn as "Label: value_".
+// Backspace erases the last character and Confirm submits the value.
+type TextInput struct {
+	Label    string
+	FontFace text.Face
+	Value    string
+	// MaxLength is the largest number of characters of the value, unlimited when it isn't set.
+	MaxLength int
+	// Accept returns true if the character can be added to the value, any character is
+	// accepted when it isn't set.
+	Accept func(value string, r rune) bool
+	// OnChange is called with the value each time it changes.
+	OnChange func(value string)
+	// OnSubmit is called with the value on Confirm.
+	OnSubmit func(value string)
+	position geometry.Vector
+	control
+}
+
+var _ Control = (*TextInput)(nil)
+
+// NewTextInput creates a new TextInput holding the value.
+func NewTextInput(label string, face text.Face, value string, maxLength int) *TextInput {
+	return &TextInput{Label: label, FontFace: face, Value: value, MaxLength: maxLength}
+}
+
+// text returns the label and the value, without the cursor.
+func (t *TextInput) text() string {
+	if t.Label == "" {
+		return t.Value
+	}
+
+	return t.Label + ": " + t.Value
+}
+
+// Size returns the width and the height of the text input, with room for the cursor.
+func (t *TextInput) Size() (float64, float64) {
+	return measure(t.text()+"_", t.FontFace)
+}
+
+// Place centers the text input horizontally in the area, at its top.
+func (t *TextInput) Place(area geometry.Rect) {
+	width, _ := t.Size()
+
+	t.area = area
+	t.position = centered(area, width)
+}
+
+// Handle adds the characters typed to the value, erases and submits it.
+func (t *TextInput) Handle(in *Input) {
+	value := t.Value
+
+	for _, r := range in.Chars {
+		if t.MaxLength > 0 && utf8.RuneCountInString(value) >= t.MaxLength {
+			break
+		}
+
+		if t.Accept == nil || t.Accept(value, r) {
+			value += string(r)
+		}
+	}
+
+	if in.Erase && value != "" {
+		_, size := utf8.DecodeLastRuneInString(value)
+		value = value[:len(value)-size]
+	}
+
+	if value != t.Value {
+		t.Value = value
+
+		if t.OnChange != nil {
+			t.OnChange(value)
+		}
+	}
+
+	if in.Confirm && t.OnSubmit != nil {
+		t.OnSubmit(t.Value)
+	}
+}
+
+// Draw draws the text input, highlighted with a blinking cursor while it has the focus.
+func (t *TextInput) Draw(screen *ebiten.Image) {
+	value := t.text()
+	if t.focused && time.Now().UnixMilli()/cursorBlink.Milliseconds()%2 == 0 {
+		value += "_"
+	}
+
+	(&Text{Value: value, FontFace: t.FontFace, Position: t.position, Color: t.textColor()}).Draw(screen)
+}
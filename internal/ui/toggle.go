@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Toggle is an option turned on or off, drawn as "Label: < On >". Left, Right, Confirm and taps
+// all switch it.
+type Toggle struct {
+	Label    string
+	FontFace text.Face
+	Value    func() bool
+	Set      func(on bool)
+	// Format names the value, On or Off when it isn't set.
+	Format   func(on bool) string
+	position geometry.Vector
+	control
+}
+
+var _ Control = (*Toggle)(nil)
+
+// NewToggle creates a new Toggle.
+func NewToggle(label string, face text.Face, value func() bool, set func(on bool)) *Toggle {
+	return &Toggle{Label: label, FontFace: face, Value: value, Set: set}
+}
+
+// text returns the label and the name of the current value.
+func (t *Toggle) text() string {
+	on := t.Value()
+
+	if t.Format != nil {
+		return optionText(t.Label, t.Format(on))
+	}
+
+	if on {
+		return optionText(t.Label, "On")
+	}
+
+	return optionText(t.Label, "Off")
+}
+
+// Size returns the width and the height of the toggle.
+func (t *Toggle) Size() (float64, float64) {
+	return measure(t.text(), t.FontFace)
+}
+
+// Place centers the toggle horizontally in the area, at its top.
+func (t *Toggle) Place(area geometry.Rect) {
+	width, _ := t.Size()
+
+	t.area = area
+	t.position = centered(area, width)
+}
+
+// Handle switches the toggle on any step of the input.
+func (t *Toggle) Handle(in *Input) {
+	if t.step(in) != 0 && t.Set != nil {
+		t.Set(!t.Value())
+	}
+}
+
+// Draw draws the toggle, highlighted while it has the focus.
+func (t *Toggle) Draw(screen *ebiten.Image) {
+	(&Text{Value: t.text(), FontFace: t.FontFace, Position: t.position, Color: t.textColor()}).Draw(screen)
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/gandarez/pong-multiplayer-go/internal/theme"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Widget is an element of a menu, kept from a frame to the next and laid out by its container.
+type Widget interface {
+	// Size returns the width and the height the widget needs.
+	Size() (float64, float64)
+	// Place lays the widget out in the area, centered horizontally.
+	Place(area geometry.Rect)
+	// Draw draws the widget where it was placed.
+	Draw(screen *ebiten.Image)
+}
+
+// Control is a widget the player can focus and interact with.
+type Control interface {
+	Widget
+	// SetFocused gives the focus to the control or takes it away.
+	SetFocused(focused bool)
+	// Handle handles the input of the frame while the control has the focus. in.Tapped is only
+	// true when the control itself was tapped.
+	Handle(in *Input)
+}
+
+// control holds the area and the focus shared by the controls.
+type control struct {
+	area    geometry.Rect
+	focused bool
+}
+
+// SetFocused gives the focus to the control or takes it away.
+func (c *control) SetFocused(focused bool) {
+	c.focused = focused
+}
+
+// textColor returns the color of the control, highlighted while it has the focus.
+func (c *control) textColor() color.RGBA {
+	if c.focused {
+		return theme.Active().Highlight
+	}
+
+	return theme.Active().Text
+}
+
+// step returns the direction the input changes the value of a control in: -1 for Left or
+// a tap on the left half of the control, 1 for Right, Confirm or a tap on its right half,
+// and 0 otherwise.
+func (c *control) step(in *Input) int {
+	switch {
+	case in.Left:
+		return -1
+	case in.Right, in.Confirm:
+		return 1
+	case in.Tapped && in.Tap.X < c.area.X+c.area.Width/2:
+		return -1
+	case in.Tapped:
+		return 1
+	}
+
+	return 0
+}
+
+// measure returns the size of the text, zero without a face.
+func measure(value string, face text.Face) (float64, float64) {
+	if face == nil {
+		return 0, 0
+	}
+
+	return text.Measure(value, face, 1)
+}
+
+// centered returns where a widget width wide is drawn to be centered horizontally in the area.
+func centered(area geometry.Rect, width float64) geometry.Vector {
+	return geometry.Vector{X: area.X + (area.Width-width)/2, Y: area.Y}
+}
@@ -0,0 +1,80 @@
+// Package account proves who is playing online. Each player keeps an ed25519 keypair on their
+// device and signs their name for the server they join, along with a challenge the server issued
+// to accept it once; servers keep the public key of the first player to use a name and reserve it
+// for them, without any external service.
+package account
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxClockSkew is how far the time of an identity can be from the clock of the server.
+const MaxClockSkew = 2 * time.Minute
+
+var (
+	// ErrInvalidSignature is returned when an identity isn't signed by its key.
+	ErrInvalidSignature = errors.New("invalid identity signature")
+	// ErrExpired is returned when an identity was signed too long ago, or too far in the future.
+	ErrExpired = errors.New("identity expired")
+	// ErrNameReserved is returned when a name is reserved by another account.
+	ErrNameReserved = errors.New("name reserved by another account")
+)
+
+// Identity proves a player owns the key of an account. It's sent in the multiplayer handshake.
+type Identity struct {
+	PublicKey ed25519.PublicKey `json:"public_key"`
+	// Time is when the identity was signed, in Unix seconds.
+	Time int64 `json:"time"`
+	// Nonce is the challenge issued by the server, see Challenges.
+	Nonce string `json:"nonce"`
+	// Signature signs the name of the player, the server, the nonce and the time with the private key.
+	Signature []byte `json:"signature"`
+}
+
+// Sign signs the name of a player joining the server at the given time, answering the challenge
+// with the nonce.
+func Sign(key ed25519.PrivateKey, name, server, nonce string, at time.Time) *Identity {
+	pub, _ := key.Public().(ed25519.PublicKey)
+
+	return &Identity{
+		PublicKey: pub,
+		Time:      at.Unix(),
+		Nonce:     nonce,
+		Signature: ed25519.Sign(key, message(name, server, nonce, at.Unix())),
+	}
+}
+
+// Verify checks the identity signs the name of a player joining the server, and that it was
+// signed around now. Whether its challenge was issued is checked by Challenges.Consume.
+func (id *Identity) Verify(name, server string, now time.Time) error {
+	if len(id.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: bad public key", ErrInvalidSignature)
+	}
+
+	if skew := now.Sub(time.Unix(id.Time, 0)); skew > MaxClockSkew || skew < -MaxClockSkew {
+		return ErrExpired
+	}
+
+	if !ed25519.Verify(id.PublicKey, message(name, server, id.Nonce, id.Time), id.Signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// Fingerprint returns a short hexadecimal digest of the public key to tell accounts apart.
+func Fingerprint(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// message returns what's signed by an identity.
+func message(name, server, nonce string, at int64) []byte {
+	return []byte(fmt.Sprintf("pongo identity\n%s\n%s\n%s\n%d", server, name, nonce, at))
+}
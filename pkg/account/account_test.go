@@ -0,0 +1,128 @@
+package account_test
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+)
+
+func TestIdentity_Verify(t *testing.T) {
+	key := newKey(t)
+	signed := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		id   func() *account.Identity
+		name string
+		now  time.Time
+		err  error
+	}{
+		"verified": {
+			id:   func() *account.Identity { return account.Sign(key, "Ada", server, "nonce", signed) },
+			name: "Ada",
+			now:  signed,
+		},
+		"clock of the player behind": {
+			id:   func() *account.Identity { return account.Sign(key, "Ada", server, "nonce", signed) },
+			name: "Ada",
+			now:  signed.Add(account.MaxClockSkew),
+		},
+		"clock of the player ahead": {
+			id:   func() *account.Identity { return account.Sign(key, "Ada", server, "nonce", signed) },
+			name: "Ada",
+			now:  signed.Add(-account.MaxClockSkew),
+		},
+		"signed too long ago": {
+			id:   func() *account.Identity { return account.Sign(key, "Ada", server, "nonce", signed) },
+			name: "Ada",
+			now:  signed.Add(account.MaxClockSkew + time.Second),
+			err:  account.ErrExpired,
+		},
+		"signed in the future": {
+			id:   func() *account.Identity { return account.Sign(key, "Ada", server, "nonce", signed) },
+			name: "Ada",
+			now:  signed.Add(-account.MaxClockSkew - time.Second),
+			err:  account.ErrExpired,
+		},
+		"another name": {
+			id:   func() *account.Identity { return account.Sign(key, "Bob", server, "nonce", signed) },
+			name: "Ada",
+			now:  signed,
+			err:  account.ErrInvalidSignature,
+		},
+		"another server": {
+			id:   func() *account.Identity { return account.Sign(key, "Ada", "evil.test", "nonce", signed) },
+			name: "Ada",
+			now:  signed,
+			err:  account.ErrInvalidSignature,
+		},
+		"nonce changed": {
+			id: func() *account.Identity {
+				id := account.Sign(key, "Ada", server, "nonce", signed)
+				id.Nonce = "other"
+
+				return id
+			},
+			name: "Ada",
+			now:  signed,
+			err:  account.ErrInvalidSignature,
+		},
+		"time changed": {
+			id: func() *account.Identity {
+				id := account.Sign(key, "Ada", server, "nonce", signed)
+				id.Time++
+
+				return id
+			},
+			name: "Ada",
+			now:  signed,
+			err:  account.ErrInvalidSignature,
+		},
+		"signed by another key": {
+			id: func() *account.Identity {
+				id := account.Sign(key, "Ada", server, "nonce", signed)
+				id.PublicKey = account.Sign(newKey(t), "Ada", server, "nonce", signed).PublicKey
+
+				return id
+			},
+			name: "Ada",
+			now:  signed,
+			err:  account.ErrInvalidSignature,
+		},
+		"bad public key": {
+			id: func() *account.Identity {
+				id := account.Sign(key, "Ada", server, "nonce", signed)
+				id.PublicKey = id.PublicKey[:8]
+
+				return id
+			},
+			name: "Ada",
+			now:  signed,
+			err:  account.ErrInvalidSignature,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.id().Verify(test.name, server, test.now); !errors.Is(err, test.err) {
+				t.Errorf("error = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	public, _ := newKey(t).Public().(ed25519.PublicKey)
+	other, _ := newKey(t).Public().(ed25519.PublicKey)
+
+	fingerprint := account.Fingerprint(public)
+	if len(fingerprint) != 16 {
+		t.Errorf("fingerprint = %q, want 16 hexadecimal digits", fingerprint)
+	}
+
+	if account.Fingerprint(other) == fingerprint {
+		t.Errorf("fingerprints of different keys are both %q", fingerprint)
+	}
+}
@@ -0,0 +1,92 @@
+package account
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// ChallengeTTL is how long a challenge can be signed once issued.
+	ChallengeTTL = MaxClockSkew
+	// maxChallenges is the number of challenges waiting to be signed at once, so requesting
+	// challenges without ever signing them can't exhaust the memory of the server.
+	maxChallenges = 10_000
+)
+
+var (
+	// ErrUnknownChallenge is returned when an identity signs a challenge the server didn't issue,
+	// that expired or that was already used.
+	ErrUnknownChallenge = errors.New("unknown challenge")
+	// ErrTooManyChallenges is returned when too many challenges are waiting to be signed.
+	ErrTooManyChallenges = errors.New("too many challenges")
+)
+
+// Challenges issues the nonces signed by identities, each one being accepted once, so identities
+// can't be replayed. It's safe for concurrent use.
+type Challenges struct {
+	mu sync.Mutex
+	// issued are the nonces waiting to be signed, with when they were issued.
+	issued map[string]time.Time
+}
+
+// NewChallenges creates a new Challenges without any challenge issued.
+func NewChallenges() *Challenges {
+	return &Challenges{issued: make(map[string]time.Time)}
+}
+
+// Issue issues a new challenge at now, returning its nonce.
+func (c *Challenges) Issue(now time.Time) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// challenges that were never signed are forgotten once they're too many
+	if len(c.issued) >= maxChallenges {
+		for nonce, issued := range c.issued {
+			if expired(issued, now) {
+				delete(c.issued, nonce)
+			}
+		}
+	}
+
+	if len(c.issued) >= maxChallenges {
+		return "", ErrTooManyChallenges
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	nonce := hex.EncodeToString(b)
+	c.issued[nonce] = now
+
+	return nonce, nil
+}
+
+// Consume accepts the challenge with the nonce once, if it was issued and didn't expire at now.
+func (c *Challenges) Consume(nonce string, now time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issued, ok := c.issued[nonce]
+	if !ok {
+		return ErrUnknownChallenge
+	}
+
+	delete(c.issued, nonce)
+
+	if expired(issued, now) {
+		return ErrUnknownChallenge
+	}
+
+	return nil
+}
+
+// expired returns true if a challenge issued at the given time can't be signed anymore at now.
+func expired(issued, now time.Time) bool {
+	return now.Sub(issued) > ChallengeTTL
+}
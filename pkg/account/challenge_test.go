@@ -0,0 +1,100 @@
+package account_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+)
+
+func TestChallenges_Consume(t *testing.T) {
+	issued := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		// consumed is when the challenge is consumed for the first time.
+		consumed time.Time
+		err      error
+	}{
+		"right away": {
+			consumed: issued,
+		},
+		"just before it expires": {
+			consumed: issued.Add(account.ChallengeTTL),
+		},
+		"expired": {
+			consumed: issued.Add(account.ChallengeTTL + time.Second),
+			err:      account.ErrUnknownChallenge,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			challenges := account.NewChallenges()
+
+			nonce, err := challenges.Issue(issued)
+			if err != nil {
+				t.Fatalf("failed to issue challenge: %v", err)
+			}
+
+			if err := challenges.Consume(nonce, test.consumed); !errors.Is(err, test.err) {
+				t.Fatalf("error = %v, want %v", err, test.err)
+			}
+
+			// challenges are accepted once
+			if err := challenges.Consume(nonce, test.consumed); !errors.Is(err, account.ErrUnknownChallenge) {
+				t.Errorf("error consuming it again = %v, want %v", err, account.ErrUnknownChallenge)
+			}
+		})
+	}
+}
+
+func TestChallenges_Consume_Unknown(t *testing.T) {
+	challenges := account.NewChallenges()
+
+	for _, nonce := range []string{"", "00112233445566778899aabbccddeeff"} {
+		if err := challenges.Consume(nonce, time.Now()); !errors.Is(err, account.ErrUnknownChallenge) {
+			t.Errorf("error consuming %q = %v, want %v", nonce, err, account.ErrUnknownChallenge)
+		}
+	}
+}
+
+func TestChallenges_Issue_Unique(t *testing.T) {
+	challenges := account.NewChallenges()
+	nonces := make(map[string]bool)
+
+	for range 100 {
+		nonce, err := challenges.Issue(time.Now())
+		if err != nil {
+			t.Fatalf("failed to issue challenge: %v", err)
+		}
+
+		if nonces[nonce] {
+			t.Fatalf("nonce %q issued twice", nonce)
+		}
+
+		nonces[nonce] = true
+	}
+}
+
+func TestChallenges_Issue_TooMany(t *testing.T) {
+	challenges := account.NewChallenges()
+	now := time.Now()
+
+	var err error
+
+	for range 100_000 {
+		if _, err = challenges.Issue(now); err != nil {
+			break
+		}
+	}
+
+	if !errors.Is(err, account.ErrTooManyChallenges) {
+		t.Fatalf("error = %v, want %v", err, account.ErrTooManyChallenges)
+	}
+
+	// expired challenges make room for new ones
+	if _, err := challenges.Issue(now.Add(account.ChallengeTTL + time.Second)); err != nil {
+		t.Errorf("failed to issue challenge once the others expired: %v", err)
+	}
+}
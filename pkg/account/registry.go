@@ -0,0 +1,263 @@
+package account
+
+import (
+	"cmp"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/rating"
+)
+
+type (
+	// Registry keeps the accounts of a server, reserving each name for the key that used it first.
+	// It's safe for concurrent use.
+	Registry struct {
+		mu sync.Mutex
+		// path is the file the accounts are saved to, empty to keep them in memory.
+		path     string
+		accounts map[string]*Account
+		// challenges are the challenges issued to the players about to join.
+		challenges *Challenges
+	}
+
+	// Account is the public profile of a player with a reserved name.
+	Account struct {
+		Name      string            `json:"name"`
+		PublicKey ed25519.PublicKey `json:"public_key"`
+		// Fingerprint is a digest of the public key, see Fingerprint.
+		Fingerprint string    `json:"fingerprint"`
+		Created     time.Time `json:"created"`
+		LastSeen    time.Time `json:"last_seen"`
+		// Rating is the skill of the player, updated after each online match.
+		Rating rating.Rating `json:"rating"`
+	}
+
+	// RatingChange is the rating of a player after a match and how much it changed.
+	RatingChange struct {
+		Rating rating.Rating `json:"rating"`
+		Change float64       `json:"change"`
+	}
+)
+
+// NewRegistry creates a registry saving the accounts to the file at path, loading the ones
+// already saved. An empty path keeps the accounts in memory.
+func NewRegistry(path string) (*Registry, error) {
+	r := &Registry{path: path, accounts: make(map[string]*Account), challenges: NewChallenges()}
+
+	if path == "" {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path) // nolint:gosec
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	var accounts []*Account
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, fmt.Errorf("failed to parse accounts: %w", err)
+	}
+
+	for _, a := range accounts {
+		// accounts saved before ratings existed start with the rating of a new player
+		if a.Rating.IsZero() {
+			a.Rating = rating.Default()
+		}
+
+		r.accounts[key(a.Name)] = a
+	}
+
+	return r, nil
+}
+
+// Challenge issues a challenge for a player about to join, returning the nonce their identity signs.
+func (r *Registry) Challenge(now time.Time) (string, error) {
+	return r.challenges.Issue(now)
+}
+
+// Authenticate checks a player joining the server under name. Without identity the player
+// is a guest, who can only use names nobody reserved. With one, the name is reserved for its
+// key the first time it's used, and the challenge it signs is used up. It returns whether
+// the player is verified.
+func (r *Registry) Authenticate(name, server string, id *Identity, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, reserved := r.accounts[key(name)]
+
+	if id == nil {
+		if reserved {
+			return false, ErrNameReserved
+		}
+
+		return false, nil
+	}
+
+	if err := id.Verify(name, server, now); err != nil {
+		return false, err
+	}
+
+	if err := r.challenges.Consume(id.Nonce, now); err != nil {
+		return false, err
+	}
+
+	if reserved && !a.PublicKey.Equal(id.PublicKey) {
+		return false, ErrNameReserved
+	}
+
+	updated := Account{
+		Name:        name,
+		PublicKey:   id.PublicKey,
+		Fingerprint: Fingerprint(id.PublicKey),
+		Created:     now.UTC(),
+		Rating:      rating.Default(),
+	}
+	if reserved {
+		updated = *a
+	}
+
+	updated.LastSeen = now.UTC()
+
+	accounts := maps.Clone(r.accounts)
+	accounts[key(name)] = &updated
+
+	if err := r.commit(accounts); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Account returns the account holding the name, names being matched regardless of case.
+func (r *Registry) Account(name string) (Account, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.accounts[key(name)]
+	if !ok {
+		return Account{}, false
+	}
+
+	return *a, true
+}
+
+// Leaderboard returns limit accounts from offset, ranked by rating, the best first,
+// along with the number of accounts.
+func (r *Registry) Leaderboard(offset, limit int) ([]Account, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ranked := make([]Account, 0, len(r.accounts))
+	for _, a := range r.accounts {
+		ranked = append(ranked, *a)
+	}
+
+	slices.SortFunc(ranked, func(a, b Account) int {
+		if c := cmp.Compare(b.Rating.Rating, a.Rating.Rating); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(key(a.Name), key(b.Name))
+	})
+
+	offset = min(max(offset, 0), len(ranked))
+
+	return ranked[offset:min(offset+max(limit, 0), len(ranked))], len(ranked)
+}
+
+// Rating returns the rating of the player using the name, the rating of a new player for guests.
+func (r *Registry) Rating(name string) rating.Rating {
+	if a, ok := r.Account(name); ok {
+		return a.Rating
+	}
+
+	return rating.Default()
+}
+
+// RecordMatch updates the ratings of the players of an online match, each winner having beaten
+// each loser. Guests have no rating to update but count as new players for their opponents.
+// It returns the changes of the players with an account, by name.
+func (r *Registry) RecordMatch(winners, losers []string) (map[string]RatingChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := func(name string) rating.Rating {
+		if a, ok := r.accounts[key(name)]; ok {
+			return a.Rating
+		}
+
+		return rating.Default()
+	}
+
+	// results are computed from the ratings before the match for every player
+	results := make(map[string][]rating.Result, len(winners)+len(losers))
+
+	for _, winner := range winners {
+		for _, loser := range losers {
+			results[winner] = append(results[winner], rating.Result{Opponent: current(loser), Score: 1})
+			results[loser] = append(results[loser], rating.Result{Opponent: current(winner), Score: 0})
+		}
+	}
+
+	changes := make(map[string]RatingChange, len(results))
+	accounts := maps.Clone(r.accounts)
+
+	for name, res := range results {
+		a, ok := r.accounts[key(name)]
+		if !ok {
+			continue
+		}
+
+		updated := *a
+		updated.Rating = a.Rating.Update(res)
+		accounts[key(name)] = &updated
+
+		changes[a.Name] = RatingChange{Rating: updated.Rating, Change: updated.Rating.Rating - a.Rating.Rating}
+	}
+
+	if err := r.commit(accounts); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// commit saves the accounts to the file of the registry, if any, and makes them the accounts of
+// the registry once saved. The accounts of the registry are left untouched when they can't be saved,
+// so changes are never kept in memory only.
+func (r *Registry) commit(accounts map[string]*Account) error {
+	if r.path != "" {
+		saved := slices.Collect(maps.Values(accounts))
+
+		data, err := json.MarshalIndent(saved, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode accounts: %w", err)
+		}
+
+		if err := os.WriteFile(r.path, data, 0o600); err != nil {
+			return fmt.Errorf("failed to save accounts: %w", err)
+		}
+	}
+
+	r.accounts = accounts
+
+	return nil
+}
+
+// key returns the key of a name in the registry, names differing only by case being the same.
+func key(name string) string {
+	return strings.ToLower(name)
+}
@@ -0,0 +1,274 @@
+package account_test
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/account"
+	"github.com/gandarez/pong-multiplayer-go/pkg/rating"
+)
+
+const server = "pongo.test"
+
+func TestRegistry_Authenticate(t *testing.T) {
+	owner, other := newKey(t), newKey(t)
+	now := time.Now()
+
+	tests := map[string]struct {
+		name string
+		// id returns the identity of the player, nil for a guest.
+		id       func(registry *account.Registry) *account.Identity
+		verified bool
+		err      error
+	}{
+		"new account": {
+			name: "Bob",
+			id: func(registry *account.Registry) *account.Identity {
+				return sign(t, registry, other, "Bob", now)
+			},
+			verified: true,
+		},
+		"owner": {
+			name: "Ada",
+			id: func(registry *account.Registry) *account.Identity {
+				return sign(t, registry, owner, "Ada", now)
+			},
+			verified: true,
+		},
+		"owner with another case": {
+			name: "ADA",
+			id: func(registry *account.Registry) *account.Identity {
+				return sign(t, registry, owner, "ADA", now)
+			},
+			verified: true,
+		},
+		"reserved by another key": {
+			name: "ada",
+			id: func(registry *account.Registry) *account.Identity {
+				return sign(t, registry, other, "ada", now)
+			},
+			err: account.ErrNameReserved,
+		},
+		"guest with a free name": {
+			name: "Bob",
+			id:   func(*account.Registry) *account.Identity { return nil },
+		},
+		"guest with a reserved name": {
+			name: "Ada",
+			id:   func(*account.Registry) *account.Identity { return nil },
+			err:  account.ErrNameReserved,
+		},
+		"clock of the player too far": {
+			name: "Ada",
+			id: func(registry *account.Registry) *account.Identity {
+				return sign(t, registry, owner, "Ada", now.Add(-account.MaxClockSkew-time.Second))
+			},
+			err: account.ErrExpired,
+		},
+		"signed for another name": {
+			name: "Ada",
+			id: func(registry *account.Registry) *account.Identity {
+				return sign(t, registry, owner, "Bob", now)
+			},
+			err: account.ErrInvalidSignature,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			registry, _ := newRegistry(t)
+
+			if _, err := registry.Authenticate("Ada", server, sign(t, registry, owner, "Ada", now), now); err != nil {
+				t.Fatalf("failed to reserve Ada: %v", err)
+			}
+
+			verified, err := registry.Authenticate(test.name, server, test.id(registry), now)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error = %v, want %v", err, test.err)
+			}
+
+			if verified != test.verified {
+				t.Errorf("verified = %t, want %t", verified, test.verified)
+			}
+
+			// only the names of verified players are reserved
+			if _, ok := registry.Account("Bob"); ok != (test.name == "Bob" && test.verified) {
+				t.Errorf("Bob reserved = %t, want %t", ok, !ok)
+			}
+		})
+	}
+}
+
+func TestRegistry_Authenticate_Saved(t *testing.T) {
+	registry, path := newRegistry(t, "Ada")
+
+	before, _ := registry.Account("Ada")
+
+	if _, err := registry.RecordMatch([]string{"Ada"}, []string{"guest"}); err != nil {
+		t.Fatalf("failed to record match: %v", err)
+	}
+
+	loaded, err := account.NewRegistry(path)
+	if err != nil {
+		t.Fatalf("failed to load registry: %v", err)
+	}
+
+	a, ok := loaded.Account("ada")
+	if !ok {
+		t.Fatal("account of Ada wasn't saved")
+	}
+
+	if !a.PublicKey.Equal(before.PublicKey) || a.Fingerprint != before.Fingerprint || !a.Created.Equal(before.Created) {
+		t.Errorf("account = %+v, want %+v", a, before)
+	}
+
+	if a.Rating != registry.Rating("Ada") {
+		t.Errorf("rating = %+v, want %+v", a.Rating, registry.Rating("Ada"))
+	}
+
+	// the name stays reserved once the server restarts
+	if _, err := loaded.Authenticate("Ada", server, nil, time.Now()); !errors.Is(err, account.ErrNameReserved) {
+		t.Errorf("error = %v, want %v", err, account.ErrNameReserved)
+	}
+}
+
+func TestRegistry_Authenticate_SaveFailed(t *testing.T) {
+	registry, path := newRegistry(t)
+
+	if err := os.Mkdir(path, 0o700); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	now := time.Now()
+
+	if _, err := registry.Authenticate("Ada", server, sign(t, registry, newKey(t), "Ada", now), now); err == nil {
+		t.Fatal("authenticated, want an error")
+	}
+
+	// a name that couldn't be saved isn't reserved until the server restarts
+	if _, ok := registry.Account("Ada"); ok {
+		t.Error("Ada reserved, want the name free")
+	}
+}
+
+func TestRegistry_Authenticate_Replayed(t *testing.T) {
+	registry, _ := newRegistry(t)
+	key := newKey(t)
+	now := time.Now()
+
+	id := sign(t, registry, key, "Ada", now)
+
+	if _, err := registry.Authenticate("Ada", server, id, now); err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+
+	// a captured identity can't be used again, even right away
+	if _, err := registry.Authenticate("Ada", server, id, now); !errors.Is(err, account.ErrUnknownChallenge) {
+		t.Errorf("error replaying identity = %v, want %v", err, account.ErrUnknownChallenge)
+	}
+
+	// nor can an identity answering a challenge the server didn't issue
+	forged := account.Sign(key, "Ada", server, "forged", now)
+
+	if _, err := registry.Authenticate("Ada", server, forged, now); !errors.Is(err, account.ErrUnknownChallenge) {
+		t.Errorf("error with unknown challenge = %v, want %v", err, account.ErrUnknownChallenge)
+	}
+}
+
+func TestRegistry_RecordMatch(t *testing.T) {
+	registry, _ := newRegistry(t, "Ada", "Bob")
+
+	changes, err := registry.RecordMatch([]string{"Ada"}, []string{"Bob", "guest"})
+	if err != nil {
+		t.Fatalf("failed to record match: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("changes = %v, want the ones of Ada and Bob", changes)
+	}
+
+	for name, change := range changes {
+		if got := registry.Rating(name); got != change.Rating {
+			t.Errorf("rating of %s = %+v, want %+v", name, got, change.Rating)
+		}
+	}
+
+	if changes["Ada"].Change <= 0 || changes["Bob"].Change >= 0 {
+		t.Errorf("changes = %+v, want Ada to gain and Bob to lose", changes)
+	}
+}
+
+func TestRegistry_RecordMatch_SaveFailed(t *testing.T) {
+	registry, path := newRegistry(t, "Ada", "Bob")
+
+	// the accounts can't be written over a directory
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("failed to remove accounts: %v", err)
+	}
+
+	if err := os.Mkdir(path, 0o700); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	if _, err := registry.RecordMatch([]string{"Ada"}, []string{"Bob"}); err == nil {
+		t.Fatal("recorded match, want an error")
+	}
+
+	for _, name := range []string{"Ada", "Bob"} {
+		if got := registry.Rating(name); got != rating.Default() {
+			t.Errorf("rating of %s = %+v, want it unchanged", name, got)
+		}
+	}
+}
+
+// newRegistry returns a registry saved to a temporary file, along with its path, holding
+// the accounts of names.
+func newRegistry(t *testing.T, names ...string) (*account.Registry, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "accounts.json")
+
+	registry, err := account.NewRegistry(path)
+	if err != nil {
+		t.Fatalf("failed to create registry: %v", err)
+	}
+
+	now := time.Now()
+
+	for _, name := range names {
+		if _, err := registry.Authenticate(name, server, sign(t, registry, newKey(t), name, now), now); err != nil {
+			t.Fatalf("failed to authenticate %s: %v", name, err)
+		}
+	}
+
+	return registry, path
+}
+
+// sign returns the identity of the player with the key joining the server under name at the given
+// time, signing a challenge issued by the registry.
+func sign(t *testing.T, registry *account.Registry, key ed25519.PrivateKey, name string, at time.Time) *account.Identity {
+	t.Helper()
+
+	nonce, err := registry.Challenge(at)
+	if err != nil {
+		t.Fatalf("failed to issue challenge: %v", err)
+	}
+
+	return account.Sign(key, name, server, nonce, at)
+}
+
+// newKey returns a new private key.
+func newKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return key
+}
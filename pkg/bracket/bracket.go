@@ -0,0 +1,517 @@
+// Package bracket builds single and double elimination brackets from a list of entrants
+// and advances them as the results of their matches are recorded.
+package bracket
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxEntrants is the largest number of entrants of a bracket.
+const MaxEntrants = 32
+
+// maxMatches is the largest number of matches of a bracket, a double elimination bracket
+// of MaxEntrants entrants having 2*MaxEntrants-1 of them.
+const maxMatches = 2 * MaxEntrants
+
+var (
+	// ErrTooFewEntrants is returned when building a bracket with less than two entrants.
+	ErrTooFewEntrants = errors.New("a bracket needs at least two entrants")
+	// ErrTooManyEntrants is returned when building a bracket with more than MaxEntrants entrants.
+	ErrTooManyEntrants = errors.New("a bracket can't have more than 32 entrants")
+	// ErrInvalidEntrant is returned when an entrant has no name or the name of another entrant.
+	ErrInvalidEntrant = errors.New("entrants must have distinct names")
+	// ErrNotReady is returned when recording the result of a match that's over or whose entrants
+	// aren't known yet.
+	ErrNotReady = errors.New("match isn't ready to be played")
+	// ErrNotEntrant is returned when recording a winner who isn't an entrant of the match.
+	ErrNotEntrant = errors.New("winner isn't an entrant of the match")
+	// ErrInvalidBracket is returned when checking a bracket whose matches couldn't have been built
+	// by New and advanced by Record, e.g. one received from the network.
+	ErrInvalidBracket = errors.New("invalid bracket")
+)
+
+// Format is how entrants are eliminated.
+type Format int
+
+const (
+	// SingleElimination eliminates entrants after their first loss.
+	SingleElimination Format = iota
+	// DoubleElimination sends entrants to the losers bracket after their first loss
+	// and eliminates them after their second one.
+	DoubleElimination
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case SingleElimination:
+		return "Single elimination"
+	case DoubleElimination:
+		return "Double elimination"
+	default:
+		return "Undefined"
+	}
+}
+
+// Stage is the part of the bracket a match belongs to.
+type Stage int
+
+const (
+	// Winners is the bracket of the entrants who haven't lost yet.
+	Winners Stage = iota
+	// Losers is the bracket of the entrants who lost once, in double elimination.
+	Losers
+	// GrandFinal is where the winners of both brackets meet, in double elimination.
+	GrandFinal
+)
+
+// String returns the name of the stage.
+func (s Stage) String() string {
+	switch s {
+	case Winners:
+		return "Winners bracket"
+	case Losers:
+		return "Losers bracket"
+	case GrandFinal:
+		return "Grand final"
+	default:
+		return "Undefined"
+	}
+}
+
+type (
+	// Bracket is the matches of a tournament, in the order they're played.
+	Bracket struct {
+		Format Format `json:"format"`
+		// Entrants are the names of the entrants, the best seed first.
+		Entrants []string `json:"entrants"`
+		Matches  []Match  `json:"matches"`
+	}
+
+	// Match is a match of the bracket.
+	Match struct {
+		// ID is the index of the match in the bracket.
+		ID    int   `json:"id"`
+		Stage Stage `json:"stage"`
+		// Round is the round of the match in its stage, from 1.
+		Round int `json:"round"`
+		// Entrants are the names of the entrants, empty while they aren't known.
+		Entrants [2]string `json:"entrants"`
+		// Sources are where each entrant comes from.
+		Sources [2]Source `json:"sources"`
+		Done    bool      `json:"done,omitempty"`
+		// Winner is the entrant who won the match, empty when it's over without a winner.
+		Winner string `json:"winner,omitempty"`
+		// Bye is true when the match is over without being played, one of its entrants or both
+		// being missing, or for the reset of the grand final when it isn't needed.
+		Bye bool `json:"bye,omitempty"`
+	}
+
+	// Source is where the entrant of a match comes from.
+	Source struct {
+		// Match is the ID of the match the entrant comes from, -1 for the entrants seeded
+		// in the first round.
+		Match int `json:"match"`
+		// Loser is true when the entrant is the loser of the match instead of its winner.
+		Loser bool `json:"loser,omitempty"`
+	}
+)
+
+// New builds a bracket for the entrants, given from the best seed. The best seeds get the byes
+// when the number of entrants isn't a power of two.
+func New(format Format, entrants []string) (*Bracket, error) {
+	if err := validate(entrants); err != nil {
+		return nil, err
+	}
+
+	size := 2
+	for size < len(entrants) {
+		size *= 2
+	}
+
+	b := &Bracket{Format: format, Entrants: entrants}
+
+	seeded := Source{Match: -1}
+	seeds := seedOrder(size)
+
+	wr := make([]int, 0, size/2)
+
+	for i := 0; i < size; i += 2 {
+		wr = append(wr, b.add(Match{
+			Stage:    Winners,
+			Round:    1,
+			Entrants: [2]string{entrantAt(entrants, seeds[i]), entrantAt(entrants, seeds[i+1])},
+			Sources:  [2]Source{seeded, seeded},
+		}))
+	}
+
+	// lb are the entrants left in the losers bracket, waiting for their next match
+	lb := sources(wr, true)
+	lr := 0
+
+	for wround := 2; len(wr) > 1; wround++ {
+		wr = b.round(Winners, wround, pairs(sources(wr, false)))
+
+		if format != DoubleElimination {
+			continue
+		}
+
+		// the entrants left in the losers bracket play each other, then the winners play the
+		// entrants who just lost in the winners bracket, in reverse order to avoid rematches
+		lr++
+		paired := b.round(Losers, lr, pairs(lb))
+
+		lr++
+		lb = sources(b.round(Losers, lr, zip(sources(paired, false), reverse(sources(wr, true)))), false)
+	}
+
+	if format == DoubleElimination {
+		final := b.add(Match{Stage: GrandFinal, Round: 1, Sources: [2]Source{{Match: wr[0]}, lb[0]}})
+		b.add(Match{Stage: GrandFinal, Round: 2, Sources: [2]Source{{Match: final}, {Match: final, Loser: true}}})
+	}
+
+	b.resolve()
+
+	return b, nil
+}
+
+// validate checks the entrants of a new bracket.
+func validate(entrants []string) error {
+	switch {
+	case len(entrants) < 2:
+		return ErrTooFewEntrants
+	case len(entrants) > MaxEntrants:
+		return ErrTooManyEntrants
+	}
+
+	seen := make(map[string]bool, len(entrants))
+
+	for _, name := range entrants {
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			return fmt.Errorf("%w: %q", ErrInvalidEntrant, name)
+		}
+
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// seedOrder returns the seeds, from 1, in the order they're placed in the first round
+// of a bracket of size entrants, so the best seeds meet as late as possible.
+func seedOrder(size int) []int {
+	order := []int{1, 2}
+
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, len(order)*2+1-seed)
+		}
+
+		order = next
+	}
+
+	return order
+}
+
+// entrantAt returns the entrant with the seed, from 1, or an empty name for a bye.
+func entrantAt(entrants []string, seed int) string {
+	if seed > len(entrants) {
+		return ""
+	}
+
+	return entrants[seed-1]
+}
+
+// add adds the match to the bracket and returns its ID.
+func (b *Bracket) add(m Match) int {
+	m.ID = len(b.Matches)
+	b.Matches = append(b.Matches, m)
+
+	return m.ID
+}
+
+// round adds a round of matches between the pairs of entrants and returns their IDs.
+func (b *Bracket) round(stage Stage, round int, pairs [][2]Source) []int {
+	ids := make([]int, 0, len(pairs))
+	for _, p := range pairs {
+		ids = append(ids, b.add(Match{Stage: stage, Round: round, Sources: p}))
+	}
+
+	return ids
+}
+
+// sources returns the winners, or the losers, of the matches.
+func sources(ids []int, loser bool) []Source {
+	srcs := make([]Source, 0, len(ids))
+	for _, id := range ids {
+		srcs = append(srcs, Source{Match: id, Loser: loser})
+	}
+
+	return srcs
+}
+
+// pairs pairs consecutive entrants.
+func pairs(srcs []Source) [][2]Source {
+	p := make([][2]Source, 0, len(srcs)/2)
+	for i := 0; i+1 < len(srcs); i += 2 {
+		p = append(p, [2]Source{srcs[i], srcs[i+1]})
+	}
+
+	return p
+}
+
+// zip pairs the entrants of a with the entrants of b at the same index.
+func zip(a, b []Source) [][2]Source {
+	p := make([][2]Source, 0, len(a))
+	for i := range min(len(a), len(b)) {
+		p = append(p, [2]Source{a[i], b[i]})
+	}
+
+	return p
+}
+
+// reverse returns the entrants in reverse order.
+func reverse(srcs []Source) []Source {
+	r := slices.Clone(srcs)
+	slices.Reverse(r)
+
+	return r
+}
+
+// Loser returns the entrant who lost the match, empty for byes and while it isn't over.
+func (m Match) Loser() string {
+	if !m.Done || m.Bye {
+		return ""
+	}
+
+	if m.Entrants[0] == m.Winner {
+		return m.Entrants[1]
+	}
+
+	return m.Entrants[0]
+}
+
+// Ready returns true when both entrants of the match are known and it isn't over.
+func (m Match) Ready() bool {
+	return !m.Done && m.Entrants[0] != "" && m.Entrants[1] != ""
+}
+
+// Between returns true if the entrants of the match are the two names, in any order,
+// compared case-insensitively.
+func (m Match) Between(first, second string) bool {
+	return (strings.EqualFold(m.Entrants[0], first) && strings.EqualFold(m.Entrants[1], second)) ||
+		(strings.EqualFold(m.Entrants[0], second) && strings.EqualFold(m.Entrants[1], first))
+}
+
+// Next returns the next match to play, false when the bracket is over.
+func (b *Bracket) Next() (Match, bool) {
+	for _, m := range b.Matches {
+		if m.Ready() {
+			return m, true
+		}
+	}
+
+	return Match{}, false
+}
+
+// Record records the winner of the match with the ID and advances the entrants.
+// The winner's name is matched case-insensitively.
+func (b *Bracket) Record(id int, winner string) error {
+	if id < 0 || id >= len(b.Matches) || !b.Matches[id].Ready() {
+		return fmt.Errorf("%w: %d", ErrNotReady, id)
+	}
+
+	m := &b.Matches[id]
+
+	switch {
+	case strings.EqualFold(m.Entrants[0], winner):
+		m.Winner = m.Entrants[0]
+	case strings.EqualFold(m.Entrants[1], winner):
+		m.Winner = m.Entrants[1]
+	default:
+		return fmt.Errorf("%w: %q", ErrNotEntrant, winner)
+	}
+
+	m.Done = true
+
+	b.resolve()
+
+	return nil
+}
+
+// Champion returns the winner of the bracket, false while it isn't over.
+func (b *Bracket) Champion() (string, bool) {
+	if len(b.Matches) == 0 {
+		return "", false
+	}
+
+	last := b.Matches[len(b.Matches)-1]
+
+	return last.Winner, last.Done && last.Winner != ""
+}
+
+// Rounds returns the matches of the stage grouped by round, skipping the matches whose round
+// is out of range.
+func (b *Bracket) Rounds(stage Stage) [][]Match {
+	var rounds [][]Match
+
+	for _, m := range b.Matches {
+		// a stage can't have more rounds than the bracket has matches
+		if m.Stage != stage || m.Round < 1 || m.Round > len(b.Matches) {
+			continue
+		}
+
+		for len(rounds) < m.Round {
+			rounds = append(rounds, nil)
+		}
+
+		rounds[m.Round-1] = append(rounds[m.Round-1], m)
+	}
+
+	return rounds
+}
+
+// Stages returns the stages of the bracket.
+func (b *Bracket) Stages() []Stage {
+	if b.Format == DoubleElimination {
+		return []Stage{Winners, Losers, GrandFinal}
+	}
+
+	return []Stage{Winners}
+}
+
+// Includes returns true if the name is the name of an entrant, compared case-insensitively.
+func (b *Bracket) Includes(name string) bool {
+	for _, e := range b.Entrants {
+		if strings.EqualFold(e, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks that the bracket is one New could have built, with results recorded by Record:
+// valid entrants, matches in order, sources pointing to earlier matches and stages and rounds
+// in range. Brackets received from the network must be validated before being used.
+func (b *Bracket) Validate() error {
+	if b.Format != SingleElimination && b.Format != DoubleElimination {
+		return fmt.Errorf("%w: format %d", ErrInvalidBracket, b.Format)
+	}
+
+	if err := validate(b.Entrants); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBracket, err)
+	}
+
+	if len(b.Matches) == 0 || len(b.Matches) > maxMatches {
+		return fmt.Errorf("%w: %d matches", ErrInvalidBracket, len(b.Matches))
+	}
+
+	for i, m := range b.Matches {
+		if err := b.validateMatch(i, m); err != nil {
+			return fmt.Errorf("%w: match %d: %w", ErrInvalidBracket, i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateMatch checks the match at index i of the bracket.
+func (b *Bracket) validateMatch(i int, m Match) error {
+	switch {
+	case m.ID != i:
+		return fmt.Errorf("id %d", m.ID)
+	case !slices.Contains(b.Stages(), m.Stage):
+		return fmt.Errorf("stage %d", m.Stage)
+	case m.Round < 1 || m.Round > len(b.Matches):
+		return fmt.Errorf("round %d", m.Round)
+	case m.Stage == GrandFinal && m.Round > 2:
+		return fmt.Errorf("grand final round %d", m.Round)
+	}
+
+	for j, src := range m.Sources {
+		// only the first round of the winners bracket is seeded, the other matches
+		// coming from the ones before them
+		seeded := src.Match == -1 && !src.Loser && m.Stage == Winners && m.Round == 1
+		if !seeded && (src.Match < 0 || src.Match >= i) {
+			return fmt.Errorf("source %d of entrant %d", src.Match, j+1)
+		}
+
+		if m.Entrants[j] != "" && !slices.Contains(b.Entrants, m.Entrants[j]) {
+			return fmt.Errorf("entrant %q", m.Entrants[j])
+		}
+	}
+
+	if m.Stage == GrandFinal && m.Round == 2 && b.Matches[m.Sources[0].Match].Stage != GrandFinal {
+		return errors.New("reset of the grand final doesn't follow it")
+	}
+
+	if m.Winner != "" && (!m.Done || (m.Winner != m.Entrants[0] && m.Winner != m.Entrants[1])) {
+		return fmt.Errorf("winner %q", m.Winner)
+	}
+
+	return nil
+}
+
+// resolve fills in the entrants whose matches are over and ends the matches that can't be played.
+// Matches only come from the ones before them, so a single pass is enough.
+func (b *Bracket) resolve() {
+	for i := range b.Matches {
+		m := &b.Matches[i]
+		if m.Done {
+			continue
+		}
+
+		known := true
+
+		for j, src := range m.Sources {
+			if src.Match < 0 {
+				continue
+			}
+
+			from := b.Matches[src.Match]
+			if !from.Done {
+				known = false
+				continue
+			}
+
+			if src.Loser {
+				m.Entrants[j] = from.Loser()
+			} else {
+				m.Entrants[j] = from.Winner
+			}
+		}
+
+		if !known {
+			continue
+		}
+
+		// the grand final is only played again when the winner of the losers bracket wins it
+		if m.Stage == GrandFinal && m.Round == 2 {
+			final := b.Matches[m.Sources[0].Match]
+			if final.Winner == final.Entrants[0] {
+				m.Done, m.Bye, m.Winner = true, true, final.Winner
+				continue
+			}
+		}
+
+		if m.Entrants[0] == "" || m.Entrants[1] == "" {
+			m.Done, m.Bye, m.Winner = true, true, cmp.Or(m.Entrants[0], m.Entrants[1])
+		}
+	}
+}
+
+// Clone returns a copy of the bracket that doesn't share its matches.
+func (b *Bracket) Clone() *Bracket {
+	c := *b
+	c.Entrants = slices.Clone(b.Entrants)
+	c.Matches = slices.Clone(b.Matches)
+
+	return &c
+}
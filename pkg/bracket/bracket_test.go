@@ -0,0 +1,400 @@
+package bracket_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/bracket"
+)
+
+// names are the entrants of the tests, the best seed first.
+var names = []string{"Ada", "Bob", "Cyd", "Dan", "Eve", "Fay", "Gus", "Hal"} // nolint:gochecknoglobals
+
+func TestNew_DoubleElimination(t *testing.T) {
+	tests := map[int]struct {
+		matches int
+		// byes are the entrants who don't play in the first round.
+		byes []string
+	}{
+		2: {matches: 3},
+		3: {matches: 7, byes: []string{"Ada"}},
+		4: {matches: 7},
+		5: {matches: 15, byes: []string{"Ada", "Bob", "Cyd"}},
+		8: {matches: 15},
+	}
+
+	for size, test := range tests {
+		t.Run(names[size-1], func(t *testing.T) {
+			b := newBracket(t, bracket.DoubleElimination, size)
+
+			if len(b.Matches) != test.matches {
+				t.Fatalf("%d matches, want %d", len(b.Matches), test.matches)
+			}
+
+			var byes []string
+
+			for _, m := range b.Rounds(bracket.Winners)[0] {
+				if m.Bye {
+					byes = append(byes, m.Winner)
+				}
+			}
+
+			if !slices.Equal(byes, test.byes) {
+				t.Errorf("byes = %v, want %v", byes, test.byes)
+			}
+
+			// the grand final is played between the winners of both brackets and reset
+			// between the same entrants
+			finals := b.Rounds(bracket.GrandFinal)
+			if len(finals) != 2 || len(finals[0]) != 1 || len(finals[1]) != 1 {
+				t.Fatalf("grand final rounds = %v, want a final and its reset", finals)
+			}
+
+			final, reset := finals[0][0], finals[1][0]
+			winners, losers := b.Rounds(bracket.Winners), b.Rounds(bracket.Losers)
+
+			if final.Sources[0] != (bracket.Source{Match: winners[len(winners)-1][0].ID}) {
+				t.Errorf("first entrant of the grand final comes from %+v, want the winners bracket final", final.Sources[0])
+			}
+
+			// with two entrants the losers bracket is the loser of the only match
+			want := bracket.Source{Match: 0, Loser: true}
+			if len(losers) > 0 {
+				want = bracket.Source{Match: losers[len(losers)-1][0].ID}
+			}
+
+			if final.Sources[1] != want {
+				t.Errorf("second entrant of the grand final comes from %+v, want %+v", final.Sources[1], want)
+			}
+
+			if reset.Sources != [2]bracket.Source{{Match: final.ID}, {Match: final.ID, Loser: true}} {
+				t.Errorf("reset of the grand final comes from %+v", reset.Sources)
+			}
+
+			if err := b.Validate(); err != nil {
+				t.Errorf("new bracket isn't valid: %v", err)
+			}
+		})
+	}
+}
+
+// TestRecord_LosersDrop checks where the losers of the winners bracket of 8 entrants play next,
+// in reverse order in the second round to avoid rematches.
+func TestRecord_LosersDrop(t *testing.T) {
+	b := newBracket(t, bracket.DoubleElimination, 8)
+
+	// the best seed wins every match
+	play(t, b, best)
+
+	drops := map[int]int{
+		// the first round drops into the first round of the losers bracket, in pairs
+		0: 6, 1: 6, 2: 7, 3: 7,
+		// the second round meets the winners of the losers bracket in reverse order
+		4: 9, 5: 8,
+		// the final of the winners bracket meets the last entrant left in the losers bracket
+		10: 12,
+	}
+
+	for from, to := range drops {
+		loser := b.Matches[from].Loser()
+		if !slices.Contains(b.Matches[to].Entrants[:], loser) {
+			t.Errorf("loser %s of match %d plays %v in match %d, want them in it", loser, from, b.Matches[to].Entrants, to)
+		}
+	}
+
+	if champion, ok := b.Champion(); !ok || champion != "Ada" {
+		t.Errorf("champion = %q, %t, want Ada", champion, ok)
+	}
+}
+
+// TestRecord_DoubleElimination plays whole brackets and checks that every entrant but the champion
+// is eliminated after exactly two losses.
+func TestRecord_DoubleElimination(t *testing.T) {
+	winners := map[string]func(m bracket.Match) string{
+		"best seed":  best,
+		"worst seed": worst,
+		"upsets in the losers bracket": func(m bracket.Match) string {
+			if m.Stage == bracket.Losers {
+				return worst(m)
+			}
+
+			return best(m)
+		},
+	}
+
+	for _, size := range []int{2, 3, 4, 5, 8} {
+		for name, winner := range winners {
+			t.Run(names[size-1]+"/"+name, func(t *testing.T) {
+				b := newBracket(t, bracket.DoubleElimination, size)
+
+				played := play(t, b, winner)
+
+				champion, ok := b.Champion()
+				if !ok {
+					t.Fatal("bracket isn't over")
+				}
+
+				losses := make(map[string]int)
+
+				for _, m := range b.Matches {
+					if loser := m.Loser(); loser != "" {
+						losses[loser]++
+					}
+				}
+
+				for _, entrant := range b.Entrants {
+					// the champion lost once at most, in the grand final when it was reset
+					if (entrant == champion && losses[entrant] > 1) || (entrant != champion && losses[entrant] != 2) {
+						t.Errorf("%s lost %d times", entrant, losses[entrant])
+					}
+				}
+
+				// every match is played but the reset of the grand final, unless it's needed
+				if want := 2*size - 2 + losses[champion]; played != want {
+					t.Errorf("%d matches played, want %d", played, want)
+				}
+
+				if err := b.Validate(); err != nil {
+					t.Errorf("played bracket isn't valid: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestRecord_GrandFinal(t *testing.T) {
+	tests := map[string]struct {
+		// final and reset are the winners of the grand final and its reset.
+		final, reset string
+		played       bool
+	}{
+		"winners bracket champion wins": {final: "Ada"},
+		"losers bracket champion wins twice": {
+			final:  "Bob",
+			reset:  "Bob",
+			played: true,
+		},
+		"winners bracket champion wins the reset": {
+			final:  "Bob",
+			reset:  "Ada",
+			played: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := newBracket(t, bracket.DoubleElimination, 4)
+
+			// Ada wins the winners bracket and Bob the losers bracket
+			play(t, b, func(m bracket.Match) string {
+				switch m.Stage {
+				case bracket.GrandFinal:
+					if m.Round == 1 {
+						return test.final
+					}
+
+					return test.reset
+				default:
+					return best(m)
+				}
+			})
+
+			final, reset := b.Matches[5], b.Matches[6]
+			if final.Entrants != [2]string{"Ada", "Bob"} {
+				t.Fatalf("grand final entrants = %v, want Ada and Bob", final.Entrants)
+			}
+
+			if reset.Bye == test.played {
+				t.Errorf("reset played = %t, want %t", !reset.Bye, test.played)
+			}
+
+			want := test.final
+			if test.played {
+				want = test.reset
+			}
+
+			if champion, ok := b.Champion(); !ok || champion != want {
+				t.Errorf("champion = %q, %t, want %s", champion, ok, want)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	for _, size := range []int{2, 3, 4, 5, 8} {
+		t.Run(names[size-1], func(t *testing.T) {
+			b := newBracket(t, bracket.DoubleElimination, size)
+
+			previous := -1
+
+			for {
+				next, ok := b.Next()
+				if !ok {
+					break
+				}
+
+				if next.Bye || next.Done || next.Entrants[0] == "" || next.Entrants[1] == "" {
+					t.Fatalf("next match %+v can't be played", next)
+				}
+
+				// matches are played in order, the earliest ready one first
+				for _, m := range b.Matches[:next.ID] {
+					if m.Ready() {
+						t.Fatalf("next match is %d while %d is ready", next.ID, m.ID)
+					}
+				}
+
+				if next.ID <= previous {
+					t.Fatalf("next match is %d after %d", next.ID, previous)
+				}
+
+				previous = next.ID
+
+				if err := b.Record(next.ID, worst(next)); err != nil {
+					t.Fatalf("failed to record match %d: %v", next.ID, err)
+				}
+			}
+
+			if _, ok := b.Champion(); !ok {
+				t.Error("no next match while the bracket isn't over")
+			}
+		})
+	}
+}
+
+func TestRecord_Errors(t *testing.T) {
+	b := newBracket(t, bracket.DoubleElimination, 3)
+
+	tests := map[string]struct {
+		id     int
+		winner string
+		err    error
+	}{
+		"bye":              {id: 0, winner: "Ada", err: bracket.ErrNotReady},
+		"entrants unknown": {id: 3, winner: "Bob", err: bracket.ErrNotReady},
+		"out of range":     {id: 7, winner: "Bob", err: bracket.ErrNotReady},
+		"negative":         {id: -1, winner: "Bob", err: bracket.ErrNotReady},
+		"not an entrant":   {id: 1, winner: "Ada", err: bracket.ErrNotEntrant},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := b.Record(test.id, test.winner); !errors.Is(err, test.err) {
+				t.Errorf("error = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]func(b *bracket.Bracket){
+		"unknown format":       func(b *bracket.Bracket) { b.Format = 7 },
+		"no entrants":          func(b *bracket.Bracket) { b.Entrants = nil },
+		"same entrant twice":   func(b *bracket.Bracket) { b.Entrants[1] = "ada" },
+		"no matches":           func(b *bracket.Bracket) { b.Matches = nil },
+		"too many matches":     func(b *bracket.Bracket) { b.Matches = make([]bracket.Match, 1000) },
+		"wrong id":             func(b *bracket.Bracket) { b.Matches[2].ID = 5 },
+		"unknown stage":        func(b *bracket.Bracket) { b.Matches[4].Stage = -1 },
+		"round zero":           func(b *bracket.Bracket) { b.Matches[4].Round = 0 },
+		"round out of range":   func(b *bracket.Bracket) { b.Matches[4].Round = 1 << 40 },
+		"third grand final":    func(b *bracket.Bracket) { b.Matches[14].Round = 3 },
+		"source after match":   func(b *bracket.Bracket) { b.Matches[4].Sources[0].Match = 4 },
+		"source out of range":  func(b *bracket.Bracket) { b.Matches[4].Sources[0].Match = 99 },
+		"seeded losers":        func(b *bracket.Bracket) { b.Matches[6].Sources[0] = bracket.Source{Match: -1} },
+		"seeded reset":         func(b *bracket.Bracket) { b.Matches[14].Sources[0] = bracket.Source{Match: -1} },
+		"reset of a non final": func(b *bracket.Bracket) { b.Matches[14].Sources[0] = bracket.Source{Match: 12} },
+		"unknown entrant":      func(b *bracket.Bracket) { b.Matches[0].Entrants[0] = "Zed" },
+		"winner not entrant":   func(b *bracket.Bracket) { b.Matches[0].Done, b.Matches[0].Winner = true, "Bob" },
+		"winner not done":      func(b *bracket.Bracket) { b.Matches[0].Winner = b.Matches[0].Entrants[0] },
+	}
+
+	for name, corrupt := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := newBracket(t, bracket.DoubleElimination, 8)
+			corrupt(b)
+
+			if err := b.Validate(); !errors.Is(err, bracket.ErrInvalidBracket) {
+				t.Errorf("error = %v, want %v", err, bracket.ErrInvalidBracket)
+			}
+		})
+	}
+
+	t.Run("losers bracket in single elimination", func(t *testing.T) {
+		b := newBracket(t, bracket.SingleElimination, 8)
+		b.Matches[4].Stage = bracket.Losers
+
+		if err := b.Validate(); !errors.Is(err, bracket.ErrInvalidBracket) {
+			t.Errorf("error = %v, want %v", err, bracket.ErrInvalidBracket)
+		}
+	})
+}
+
+func TestRounds_OutOfRange(t *testing.T) {
+	b := newBracket(t, bracket.SingleElimination, 4)
+	b.Matches[0].Round = 0
+	b.Matches[1].Round = -3
+	b.Matches[2].Round = 1 << 40
+
+	if rounds := b.Rounds(bracket.Winners); len(rounds) != 0 {
+		t.Errorf("rounds = %v, want none", rounds)
+	}
+}
+
+func TestString_OutOfRange(t *testing.T) {
+	if got := bracket.Format(-1).String(); got != "Undefined" {
+		t.Errorf("format = %q, want Undefined", got)
+	}
+
+	if got := bracket.Stage(42).String(); got != "Undefined" {
+		t.Errorf("stage = %q, want Undefined", got)
+	}
+}
+
+// newBracket returns a new bracket of the first size entrants.
+func newBracket(t *testing.T, format bracket.Format, size int) *bracket.Bracket {
+	t.Helper()
+
+	b, err := bracket.New(format, slices.Clone(names[:size]))
+	if err != nil {
+		t.Fatalf("failed to create bracket: %v", err)
+	}
+
+	return b
+}
+
+// play records the matches of the bracket in order until it's over, winner choosing the winner
+// of each match. It returns the number of matches played.
+func play(t *testing.T, b *bracket.Bracket, winner func(m bracket.Match) string) int {
+	t.Helper()
+
+	played := 0
+
+	for next, ok := b.Next(); ok; next, ok = b.Next() {
+		if err := b.Record(next.ID, winner(next)); err != nil {
+			t.Fatalf("failed to record match %d: %v", next.ID, err)
+		}
+
+		played++
+	}
+
+	return played
+}
+
+// best returns the entrant of the match with the best seed.
+func best(m bracket.Match) string {
+	if slices.Index(names, m.Entrants[0]) < slices.Index(names, m.Entrants[1]) {
+		return m.Entrants[0]
+	}
+
+	return m.Entrants[1]
+}
+
+// worst returns the entrant of the match with the worst seed.
+func worst(m bracket.Match) string {
+	if best(m) == m.Entrants[0] {
+		return m.Entrants[1]
+	}
+
+	return m.Entrants[0]
+}
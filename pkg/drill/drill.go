@@ -0,0 +1,168 @@
+// Package drill describes the training drills, where a launcher serves balls at a player
+// who tries to return them into target zones, and keeps the result of a session.
+package drill
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Limits of the drills. Speeds are expressed in units per second and angles in degrees.
+const (
+	maxBalls    = 200
+	minInterval = 0.2
+	maxInterval = 10
+	maxSpeed    = 1500
+	maxAngle    = 60
+	// clearance keeps the balls served clear of the borders, more than half the width of a ball.
+	clearance = 10
+)
+
+type (
+	// Drill describes what the launcher serves and where the balls must be returned to.
+	// Drills are played on the classic arena, the launcher standing in front of the right goal
+	// and the player defending the left one. Positions are expressed in field units.
+	Drill struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		// Balls is the number of balls served.
+		Balls int `json:"balls"`
+		// Interval is the number of seconds between two serves.
+		Interval float64 `json:"interval"`
+		// Speed is the speed of the balls served.
+		Speed Range `json:"speed"`
+		// Angle is the angle of the balls served from straight at the player's goal,
+		// positive angles aiming down.
+		Angle Range `json:"angle"`
+		// Height is where the balls are served along the launcher's side.
+		Height Range `json:"height"`
+		// Targets are the zones the balls must go through once returned.
+		Targets []geometry.Rect `json:"targets"`
+	}
+
+	// Range is a range of values, each serve picking one at random. Min and Max are
+	// equal for a fixed value.
+	Range struct {
+		Min float64 `json:"min"`
+		Max float64 `json:"max"`
+	}
+)
+
+// Parse parses a drill from its JSON representation.
+// A missing height defaults to the playable height of the classic arena.
+func Parse(data []byte) (Drill, error) {
+	field := arena.Classic()
+	d := Drill{Height: Range{Min: field.BorderWidth + clearance, Max: field.Height - field.BorderWidth - clearance}}
+
+	if err := json.Unmarshal(data, &d); err != nil {
+		return Drill{}, fmt.Errorf("failed to parse drill: %w", err)
+	}
+
+	if err := d.Validate(); err != nil {
+		return Drill{}, fmt.Errorf("invalid drill %q: %w", d.Name, err)
+	}
+
+	return d, nil
+}
+
+// LoadDir loads all drills from the JSON files in dir.
+// It returns the drills it could load along with the errors of the ones it couldn't.
+func LoadDir(dir string) ([]Drill, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drills in %q: %w", dir, err)
+	}
+
+	var (
+		drills []Drill
+		errs   []error
+	)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read drill file %q: %w", path, err))
+			continue
+		}
+
+		d, err := Parse(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to load drill file %q: %w", path, err))
+			continue
+		}
+
+		drills = append(drills, d)
+	}
+
+	return drills, errors.Join(errs...)
+}
+
+// Validate checks the drill can be played.
+func (d Drill) Validate() error {
+	field := arena.Classic()
+
+	if strings.TrimSpace(d.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if d.Balls < 1 || d.Balls > maxBalls {
+		return fmt.Errorf("balls must be between 1 and %d", maxBalls)
+	}
+
+	if d.Interval < minInterval || d.Interval > maxInterval {
+		return fmt.Errorf("interval must be between %g and %d seconds", minInterval, maxInterval)
+	}
+
+	if !d.Speed.within(1, maxSpeed) {
+		return fmt.Errorf("speed must be between 1 and %d", maxSpeed)
+	}
+
+	if !d.Angle.within(-maxAngle, maxAngle) {
+		return fmt.Errorf("angle must be between %d and %d degrees", -maxAngle, maxAngle)
+	}
+
+	if !d.Height.within(field.BorderWidth+clearance, field.Height-field.BorderWidth-clearance) {
+		return errors.New("height must be within the playable height")
+	}
+
+	if len(d.Targets) == 0 {
+		return errors.New("at least a target is required")
+	}
+
+	half := geometry.Rect{X: field.Width / 2, Width: field.Width / 2, Height: field.Height}
+
+	for _, t := range d.Targets {
+		if t.Width <= 0 || t.Height <= 0 {
+			return fmt.Errorf("target %s must have a positive size", t)
+		}
+
+		if t.X < half.X || t.Y < half.Y || t.MaxX() > half.MaxX() || t.MaxY() > half.MaxY() {
+			return fmt.Errorf("target %s must be inside the launcher's half", t)
+		}
+	}
+
+	return nil
+}
+
+// within returns true if the range is ordered and inside [low, high].
+func (r Range) within(low, high float64) bool {
+	return r.Min <= r.Max && r.Min >= low && r.Max <= high
+}
+
+// OnTarget returns true if the bounds of a ball touch any target of the drill.
+func (d Drill) OnTarget(bounds geometry.Rect) bool {
+	for _, t := range d.Targets {
+		if bounds.Overlaps(t) {
+			return true
+		}
+	}
+
+	return false
+}
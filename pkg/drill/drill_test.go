@@ -0,0 +1,140 @@
+package drill_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/drill"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// valid is a drill that can be played, the tests changing one field at a time.
+const valid = `{
+  "name": "Corners",
+  "balls": 10,
+  "interval": 2,
+  "speed": {"min": 300, "max": 400},
+  "angle": {"min": -20, "max": 20},
+  "height": {"min": 100, "max": 380},
+  "targets": [{"x": 560, "y": 10, "width": 80, "height": 80}]
+}`
+
+func TestParse(t *testing.T) {
+	d, err := drill.Parse([]byte(valid))
+	if err != nil {
+		t.Fatalf("failed to parse drill: %v", err)
+	}
+
+	if d.Name != "Corners" || d.Balls != 10 || d.Interval != 2 || d.Speed != (drill.Range{Min: 300, Max: 400}) {
+		t.Errorf("drill = %+v", d)
+	}
+
+	// the height defaults to the playable height, clear of the borders
+	d, err = drill.Parse([]byte(strings.Replace(valid, `"height": {"min": 100, "max": 380},`, "", 1)))
+	if err != nil {
+		t.Fatalf("failed to parse drill without height: %v", err)
+	}
+
+	if d.Height != (drill.Range{Min: 20, Max: 460}) {
+		t.Errorf("height = %+v, want the playable height", d.Height)
+	}
+}
+
+func TestParse_Rejected(t *testing.T) {
+	tests := map[string]struct{ old, new string }{
+		"not json":             {old: `"name"`, new: `name`},
+		"no name":              {old: `"Corners"`, new: `"  "`},
+		"no balls":             {old: `"balls": 10`, new: `"balls": 0`},
+		"too many balls":       {old: `"balls": 10`, new: `"balls": 201`},
+		"interval too short":   {old: `"interval": 2`, new: `"interval": 0.1`},
+		"interval too long":    {old: `"interval": 2`, new: `"interval": 11`},
+		"speed reversed":       {old: `"min": 300, "max": 400`, new: `"min": 400, "max": 300`},
+		"speed zero":           {old: `"min": 300, "max": 400`, new: `"min": 0, "max": 400`},
+		"speed too high":       {old: `"min": 300, "max": 400`, new: `"min": 300, "max": 1501`},
+		"angle too wide":       {old: `"min": -20, "max": 20`, new: `"min": -61, "max": 20`},
+		"height in the border": {old: `"min": 100, "max": 380`, new: `"min": 5, "max": 380`},
+		"height off the field": {old: `"min": 100, "max": 380`, new: `"min": 100, "max": 470`},
+		"no targets":           {old: `[{"x": 560, "y": 10, "width": 80, "height": 80}]`, new: `[]`},
+		"target without size":  {old: `"width": 80`, new: `"width": 0`},
+		"target in the player's half": {
+			old: `"x": 560`,
+			new: `"x": 300`,
+		},
+		"target off the field": {
+			old: `"y": 10, "width": 80, "height": 80`,
+			new: `"y": 420, "width": 80, "height": 80`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := strings.Replace(valid, test.old, test.new, 1)
+			if data == valid {
+				t.Fatalf("%q isn't in the drill", test.old)
+			}
+
+			if _, err := drill.Parse([]byte(data)); err == nil {
+				t.Error("invalid drill didn't return an error")
+			}
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	// the built-in drills are all valid
+	drills, err := drill.LoadDir(filepath.Join("..", "..", "assets", "drills"))
+	if err != nil {
+		t.Fatalf("failed to load built-in drills: %v", err)
+	}
+
+	if len(drills) == 0 {
+		t.Error("no built-in drills loaded")
+	}
+
+	// invalid drills are reported, the others loaded
+	dir := t.TempDir()
+
+	for name, data := range map[string]string{"valid.json": valid, "invalid.json": `{"name": "Broken"}`, "notes.txt": "-"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatalf("failed to write drill: %v", err)
+		}
+	}
+
+	drills, err = drill.LoadDir(dir)
+	if err == nil || !strings.Contains(err.Error(), "invalid.json") {
+		t.Errorf("error = %v, want the invalid drill reported", err)
+	}
+
+	if len(drills) != 1 || drills[0].Name != "Corners" {
+		t.Errorf("drills = %+v, want the valid one", drills)
+	}
+}
+
+func TestDrill_OnTarget(t *testing.T) {
+	d := drill.Drill{Targets: []geometry.Rect{
+		{X: 560, Y: 10, Width: 80, Height: 80},
+		{X: 560, Y: 390, Width: 80, Height: 80},
+	}}
+
+	tests := map[string]struct {
+		ball geometry.Rect
+		want bool
+	}{
+		"inside the first target":  {ball: geometry.Rect{X: 600, Y: 40, Width: 10, Height: 10}, want: true},
+		"inside the second target": {ball: geometry.Rect{X: 600, Y: 420, Width: 10, Height: 10}, want: true},
+		"across an edge":           {ball: geometry.Rect{X: 555, Y: 85, Width: 10, Height: 10}, want: true},
+		"touching an edge":         {ball: geometry.Rect{X: 550, Y: 40, Width: 10, Height: 10}},
+		"between the targets":      {ball: geometry.Rect{X: 600, Y: 235, Width: 10, Height: 10}},
+		"in front of the targets":  {ball: geometry.Rect{X: 300, Y: 40, Width: 10, Height: 10}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := d.OnTarget(test.ball); got != test.want {
+				t.Errorf("on target = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package drill
+
+import (
+	"math/rand/v2"
+)
+
+// firstServeDelay is the number of seconds before the first serve, for the player to get ready.
+const firstServeDelay = 1.5
+
+// Shot is a ball served by the launcher.
+type Shot struct {
+	// Y is where the center of the ball is served along the launcher's side.
+	Y float64
+	// Angle is the direction of the ball in degrees, 180 going straight at the player's goal.
+	Angle float64
+	Speed float64
+}
+
+// Launcher serves the balls of a drill at its interval.
+type Launcher struct {
+	drill  Drill
+	served int
+	// wait is the number of seconds until the next serve.
+	wait float64
+}
+
+// NewLauncher creates a new Launcher serving the balls of the drill.
+func NewLauncher(d Drill) *Launcher {
+	return &Launcher{drill: d, wait: firstServeDelay}
+}
+
+// Update advances the launcher by dt seconds and returns the shot served, if any.
+func (l *Launcher) Update(dt float64) (Shot, bool) {
+	if l.Done() {
+		return Shot{}, false
+	}
+
+	l.wait -= dt
+	if l.wait > 0 {
+		return Shot{}, false
+	}
+
+	l.wait += l.drill.Interval
+	l.served++
+
+	return Shot{
+		Y:     l.drill.Height.random(),
+		Angle: 180 - l.drill.Angle.random(),
+		Speed: l.drill.Speed.random(),
+	}, true
+}
+
+// Served returns the number of balls served.
+func (l *Launcher) Served() int {
+	return l.served
+}
+
+// Done returns true once every ball of the drill was served.
+func (l *Launcher) Done() bool {
+	return l.served >= l.drill.Balls
+}
+
+// Next returns the number of seconds until the next serve.
+func (l *Launcher) Next() float64 {
+	return max(l.wait, 0)
+}
+
+// random returns a random value of the range.
+func (r Range) random() float64 {
+	return r.Min + (r.Max-r.Min)*rand.Float64() // nolint:gosec
+}
@@ -0,0 +1,102 @@
+package drill_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/drill"
+)
+
+// dt is a tick exactly representable in binary, so the serves fall on exact ticks.
+const dt = 0.25
+
+func TestLauncher_Update(t *testing.T) {
+	l := drill.NewLauncher(drill.Drill{
+		Balls:    3,
+		Interval: 0.5,
+		Speed:    drill.Range{Min: 300, Max: 300},
+		Angle:    drill.Range{Min: 10, Max: 10},
+		Height:   drill.Range{Min: 240, Max: 240},
+	})
+
+	if l.Next() != 1.5 {
+		t.Errorf("first serve in %v seconds, want 1.5", l.Next())
+	}
+
+	var ticks []int
+
+	for tick := 1; tick <= 40; tick++ {
+		shot, ok := l.Update(dt)
+		if !ok {
+			continue
+		}
+
+		ticks = append(ticks, tick)
+
+		if shot != (drill.Shot{Y: 240, Angle: 170, Speed: 300}) {
+			t.Errorf("shot = %+v", shot)
+		}
+	}
+
+	// the first ball after 1.5 seconds, then one every half a second
+	if len(ticks) != 3 || ticks[0] != 6 || ticks[1] != 8 || ticks[2] != 10 {
+		t.Errorf("balls served at ticks %v, want 6, 8 and 10", ticks)
+	}
+
+	if l.Served() != 3 || !l.Done() {
+		t.Errorf("%d balls served, done = %t, want all 3", l.Served(), l.Done())
+	}
+}
+
+func TestLauncher_Update_LongFrame(t *testing.T) {
+	l := drill.NewLauncher(drill.Drill{Balls: 5, Interval: 1})
+
+	// a long frame serves a single ball, the late ones following on the next frames
+	for i := range 5 {
+		if _, ok := l.Update(10); !ok {
+			t.Fatalf("update %d didn't serve a ball", i)
+		}
+
+		if l.Served() != i+1 {
+			t.Fatalf("%d balls served after %d updates", l.Served(), i+1)
+		}
+	}
+
+	if _, ok := l.Update(10); ok || !l.Done() {
+		t.Error("ball served after all of them were")
+	}
+
+	if l.Next() != 0 {
+		t.Errorf("next serve in %v seconds, want 0 when late", l.Next())
+	}
+}
+
+func TestLauncher_Update_Ranges(t *testing.T) {
+	d := drill.Drill{
+		Balls:    500,
+		Interval: dt,
+		Speed:    drill.Range{Min: 200, Max: 600},
+		Angle:    drill.Range{Min: -30, Max: 45},
+		Height:   drill.Range{Min: 50, Max: 150},
+	}
+	l := drill.NewLauncher(d)
+
+	for !l.Done() {
+		shot, ok := l.Update(dt)
+		if !ok {
+			continue
+		}
+
+		if shot.Speed < d.Speed.Min || shot.Speed > d.Speed.Max {
+			t.Fatalf("speed %v out of %+v", shot.Speed, d.Speed)
+		}
+
+		// the angle goes from straight at the player's goal, at 180 degrees
+		if shot.Angle < 180-d.Angle.Max || shot.Angle > 180-d.Angle.Min {
+			t.Fatalf("angle %v out of %+v", shot.Angle, d.Angle)
+		}
+
+		if shot.Y < d.Height.Min || shot.Y > d.Height.Max {
+			t.Fatalf("height %v out of %+v", shot.Y, d.Height)
+		}
+	}
+}
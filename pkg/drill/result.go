@@ -0,0 +1,29 @@
+package drill
+
+// Result is how a player did in a session of a drill.
+type Result struct {
+	// Served is the number of balls served.
+	Served int `json:"served"`
+	// Returned is the number of balls the player hit back.
+	Returned int `json:"returned"`
+	// OnTarget is the number of balls returned through a target.
+	OnTarget int `json:"on_target"`
+}
+
+// Accuracy returns the share of the balls served returned through a target, from 0 to 1.
+func (r Result) Accuracy() float64 {
+	if r.Served == 0 {
+		return 0
+	}
+
+	return float64(r.OnTarget) / float64(r.Served)
+}
+
+// ReturnRate returns the share of the balls served the player hit back, from 0 to 1.
+func (r Result) ReturnRate() float64 {
+	if r.Served == 0 {
+		return 0
+	}
+
+	return float64(r.Returned) / float64(r.Served)
+}
@@ -0,0 +1,30 @@
+package drill_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/drill"
+)
+
+func TestResult(t *testing.T) {
+	tests := map[string]struct {
+		result             drill.Result
+		accuracy, returned float64
+	}{
+		"nothing served": {},
+		"some on target": {result: drill.Result{Served: 10, Returned: 8, OnTarget: 4}, accuracy: 0.4, returned: 0.8},
+		"all on target":  {result: drill.Result{Served: 5, Returned: 5, OnTarget: 5}, accuracy: 1, returned: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.result.Accuracy(); got != test.accuracy {
+				t.Errorf("accuracy = %v, want %v", got, test.accuracy)
+			}
+
+			if got := test.result.ReturnRate(); got != test.returned {
+				t.Errorf("return rate = %v, want %v", got, test.returned)
+			}
+		})
+	}
+}
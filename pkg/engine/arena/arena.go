@@ -0,0 +1,208 @@
+package arena
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Default dimensions of the classic arena.
+const (
+	DefaultWidth       = 640
+	DefaultHeight      = 480
+	DefaultBorderWidth = 10
+
+	// ClassicName is the name of the classic arena.
+	ClassicName = "Classic"
+
+	// CornerSize is the side of the square walls closing the corners of four-way fields.
+	CornerSize = 40
+
+	minWidth  = 320
+	maxWidth  = 1280
+	minHeight = 240
+	maxHeight = 960
+	// minGoalSize is the smallest goal opening, a bit bigger than a paddle.
+	minGoalSize = 60
+)
+
+// Arena describes the field where a match is played.
+// Positions are expressed in field units, with the origin at the top left corner.
+type Arena struct {
+	Name        string  `json:"name"`
+	Width       float64 `json:"width"`
+	Height      float64 `json:"height"`
+	BorderWidth float64 `json:"border_width"`
+	// GoalSize is the height of the goal opening centered on the left and right sides.
+	// Zero means the whole side is a goal, as in the classic arena.
+	GoalSize float64 `json:"goal_size"`
+	// Walls are extra obstacles the ball bounces off.
+	Walls []geometry.Rect `json:"walls"`
+	// Bumpers are obstacles that speed up the ball when it bounces off them.
+	Bumpers []geometry.Rect `json:"bumpers"`
+}
+
+// Classic returns the classic arena: an empty field with full-height goals.
+func Classic() Arena {
+	return Arena{
+		Name:        ClassicName,
+		Width:       DefaultWidth,
+		Height:      DefaultHeight,
+		BorderWidth: DefaultBorderWidth,
+	}
+}
+
+// Parse parses an arena from its JSON representation.
+// Missing dimensions default to the classic arena ones.
+func Parse(data []byte) (Arena, error) {
+	a := Classic()
+	a.Name = ""
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return Arena{}, fmt.Errorf("failed to parse arena: %w", err)
+	}
+
+	if err := a.Validate(); err != nil {
+		return Arena{}, fmt.Errorf("invalid arena %q: %w", a.Name, err)
+	}
+
+	return a, nil
+}
+
+// LoadDir loads all arenas from the JSON files in dir.
+// It returns the arenas it could load along with the errors of the ones it couldn't.
+func LoadDir(dir string) ([]Arena, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list arenas in %q: %w", dir, err)
+	}
+
+	var (
+		arenas []Arena
+		errs   []error
+	)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read arena file %q: %w", path, err))
+			continue
+		}
+
+		a, err := Parse(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to load arena file %q: %w", path, err))
+			continue
+		}
+
+		arenas = append(arenas, a)
+	}
+
+	return arenas, errors.Join(errs...)
+}
+
+// Validate checks the arena is playable.
+func (a Arena) Validate() error {
+	if strings.TrimSpace(a.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if a.Width < minWidth || a.Width > maxWidth {
+		return fmt.Errorf("width must be between %d and %d", minWidth, maxWidth)
+	}
+
+	if a.Height < minHeight || a.Height > maxHeight {
+		return fmt.Errorf("height must be between %d and %d", minHeight, maxHeight)
+	}
+
+	if a.BorderWidth < 0 || a.BorderWidth > a.Height/8 {
+		return errors.New("border width must be between 0 and an eighth of the height")
+	}
+
+	if a.GoalSize != 0 && (a.GoalSize < minGoalSize || a.GoalSize > a.playableHeight()) {
+		return fmt.Errorf("goal size must be between %d and the playable height", minGoalSize)
+	}
+
+	field := geometry.Rect{Width: a.Width, Height: a.Height}
+
+	for _, r := range append(append([]geometry.Rect{}, a.Walls...), a.Bumpers...) {
+		if r.Width <= 0 || r.Height <= 0 {
+			return fmt.Errorf("obstacle %s must have a positive size", r)
+		}
+
+		if r.X < 0 || r.Y < 0 || r.MaxX() > field.MaxX() || r.MaxY() > field.MaxY() {
+			return fmt.Errorf("obstacle %s must be inside the field", r)
+		}
+	}
+
+	return nil
+}
+
+// Borders returns the top and bottom walls. They extend beyond the field, so a ball
+// leaving through a goal can't slip around them.
+func (a Arena) Borders() []geometry.Rect {
+	return []geometry.Rect{
+		{
+			X:      -a.Width,
+			Y:      -a.Height,
+			Width:  3 * a.Width,
+			Height: a.Height + a.BorderWidth,
+		},
+		{
+			X:      -a.Width,
+			Y:      a.Height - a.BorderWidth,
+			Width:  3 * a.Width,
+			Height: a.Height + a.BorderWidth,
+		},
+	}
+}
+
+// Posts returns the walls closing the left and right sides outside the goal openings.
+// The classic arena, with full-height goals, has no posts.
+func (a Arena) Posts() []geometry.Rect {
+	if a.GoalSize == 0 {
+		return nil
+	}
+
+	top, bottom := a.Goal()
+	thickness := a.Width
+
+	return []geometry.Rect{
+		{X: -thickness, Y: 0, Width: thickness, Height: top},
+		{X: -thickness, Y: bottom, Width: thickness, Height: a.Height - bottom},
+		{X: a.Width, Y: 0, Width: thickness, Height: top},
+		{X: a.Width, Y: bottom, Width: thickness, Height: a.Height - bottom},
+	}
+}
+
+// Corners returns the walls closing the corners of the field in four-way matches,
+// where the top and bottom sides are goals too.
+func (a Arena) Corners() []geometry.Rect {
+	return []geometry.Rect{
+		{X: 0, Y: 0, Width: CornerSize, Height: CornerSize},
+		{X: a.Width - CornerSize, Y: 0, Width: CornerSize, Height: CornerSize},
+		{X: 0, Y: a.Height - CornerSize, Width: CornerSize, Height: CornerSize},
+		{X: a.Width - CornerSize, Y: a.Height - CornerSize, Width: CornerSize, Height: CornerSize},
+	}
+}
+
+// Goal returns the top and bottom Y positions of the goal openings.
+func (a Arena) Goal() (float64, float64) {
+	if a.GoalSize == 0 {
+		return a.BorderWidth, a.Height - a.BorderWidth
+	}
+
+	top := (a.Height - a.GoalSize) / 2
+
+	return top, top + a.GoalSize
+}
+
+// playableHeight returns the height between the top and bottom borders.
+func (a Arena) playableHeight() float64 {
+	return a.Height - 2*a.BorderWidth
+}
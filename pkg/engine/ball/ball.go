@@ -5,16 +5,40 @@ import (
 )
 
 const (
-	initialSpeed = 2
-	maxSpeed     = 8
-	width        = 10
+	width = 10
+	// maxCollisions is the maximum number of contacts resolved in a single update.
+	maxCollisions = 4
+	// bumperBoost is the factor applied to the speed of the ball when it hits a bumper.
+	bumperBoost = 1.15
+)
+
+const (
+	// Wall is an obstacle the ball bounces off without changing its speed pattern.
+	Wall ObstacleKind = iota
+	// Paddle is an obstacle controlled by a player.
+	Paddle
+	// Bumper is an obstacle that speeds up the ball.
+	Bumper
 )
 
 type (
+	// ObstacleKind represents the kind of an obstacle.
+	ObstacleKind int
+
+	// Obstacle is something the ball can bounce off.
+	Obstacle struct {
+		Bounds geometry.Rect
+		Kind   ObstacleKind
+		// Side is the side of the player owning the obstacle, if any.
+		Side geometry.Side
+	}
+
 	ball struct {
 		angle    float64
 		bounces  int
+		lastHit  geometry.Side
 		position geometry.Vector
+		previous geometry.Vector
 		width    float64
 	}
 
@@ -24,12 +48,16 @@ type (
 		Bounces() int
 		Bounds() geometry.Rect
 		CheckGoal() (bool, geometry.Side)
+		LastHit() geometry.Side
 		Position() geometry.Vector
-		Reset() Ball
+		PreviousPosition() geometry.Vector
+		Reset(conceded geometry.Side) Ball
 		SetAngle(angle float64)
 		SetBounces(bounces int)
+		SetCurve(curve float64)
 		SetPosition(pos geometry.Vector)
-		Update(p1Bounds, p2Bounds geometry.Rect)
+		Spawn(towards geometry.Side) Ball
+		Update(dt float64, obstacles ...Obstacle)
 		Width() float64
 	}
 )
@@ -43,3 +71,13 @@ func (b *ball) Bounds() geometry.Rect {
 		Height: b.width,
 	}
 }
+
+// NewPaddle returns an obstacle for the paddle of the player on side.
+func NewPaddle(bounds geometry.Rect, side geometry.Side) Obstacle {
+	return Obstacle{Bounds: bounds, Kind: Paddle, Side: side}
+}
+
+// NewWall returns an obstacle for a wall.
+func NewWall(bounds geometry.Rect) Obstacle {
+	return Obstacle{Bounds: bounds, Kind: Wall}
+}
@@ -4,45 +4,93 @@ import (
 	"math"
 	"math/rand/v2"
 
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
 	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
 // Local represents the ball in a local game.
 type Local struct {
-	level        level.Level
-	nextSide     geometry.Side
-	screenHeight float64
-	screenWidth  float64
-	speed        float64
+	field  arena.Arena
+	level  level.Level
+	rules  rules.Rules
+	serve  geometry.Side
+	speed  float64
+	static []Obstacle
+	// curve is the angular velocity of the ball in degrees per second.
+	curve float64
+	// events receives the hits of the ball.
+	events *event.Bus
 	*ball
 }
 
-// NewLocal creates a new ball to play locally.
-// screenWidth and screenHeight are the dimensions of the screen.
+// NewLocal creates a new ball to play locally, served towards a random side.
+// field is the arena where the ball bounces off the borders, goal posts, walls and bumpers.
 // lvl is the level of the game.
-func NewLocal(screenWidth, screenHeight float64, lvl level.Level) *Local {
-	var nextSide geometry.Side
-	if rand.IntN(2) == 0 { // nolint:gosec
-		nextSide = geometry.Left
-	} else {
-		nextSide = geometry.Right
+// matchRules defines the ball speeds, the sides defending a goal and who receives the serve after a goal.
+// events receives the hits of the ball and of the balls it spawns, it can be nil.
+func NewLocal(field arena.Arena, lvl level.Level, matchRules rules.Rules, events *event.Bus) *Local {
+	sides := matchRules.Format.Sides()
+	serve := sides[rand.IntN(len(sides))] // nolint:gosec
+
+	events.Publish(event.Serve{Side: serve})
+
+	return newLocal(field, lvl, matchRules, events, serve)
+}
+
+// NewLaunched creates a new ball to play locally, launched with its center at position, at the angle
+// in degrees and the speed, such as the balls served by a training launcher. It's served towards the side
+// it's heading to, left or right.
+func NewLaunched(
+	field arena.Arena,
+	lvl level.Level,
+	matchRules rules.Rules,
+	events *event.Bus,
+	position geometry.Vector,
+	angle, speed float64,
+) *Local {
+	serve := geometry.Left
+	if math.Cos(angle*math.Pi/180) > 0 {
+		serve = geometry.Right
+	}
+
+	b := newLocal(field, lvl, matchRules, events, serve)
+	b.angle, b.speed = angle, min(speed, matchRules.MaxBallSpeed)
+	b.SetPosition(geometry.Vector{X: position.X - width/2, Y: position.Y - width/2})
+
+	return b
+}
+
+// newLocal creates a new ball served towards the given side.
+func newLocal(
+	field arena.Arena,
+	lvl level.Level,
+	matchRules rules.Rules,
+	events *event.Bus,
+	serve geometry.Side,
+) *Local {
+	position := geometry.Vector{
+		X: (field.Width - width) / 2,
+		Y: (field.Height - width) / 2,
 	}
 
 	return &Local{
-		level:        lvl,
-		nextSide:     nextSide,
-		screenHeight: screenHeight,
-		screenWidth:  screenWidth,
-		speed:        initialSpeed,
+		field:  field,
+		level:  lvl,
+		rules:  matchRules,
+		serve:  serve,
+		speed:  matchRules.InitialBallSpeed,
+		static: staticObstacles(field, matchRules.Format),
+		events: events,
 		ball: &ball{
-			angle:   calcInitialAngle(nextSide),
-			bounces: 0,
-			position: geometry.Vector{
-				X: (screenWidth - width) / 2,
-				Y: (screenHeight - width) / 2,
-			},
-			width: width,
+			angle:    calcInitialAngle(serve),
+			bounces:  0,
+			lastHit:  serve.Opposite(),
+			position: position,
+			previous: position,
+			width:    width,
 		},
 	}
 }
@@ -69,28 +117,50 @@ func (b *Local) CheckGoal() (bool, geometry.Side) {
 		return true, geometry.Left
 	}
 
-	if b.position.X >= b.screenWidth {
+	if b.position.X >= b.field.Width {
 		// player 1 scores (left player)
 		return true, geometry.Right
 	}
 
+	if b.rules.Format != rules.FourWay {
+		return false, geometry.Undefined
+	}
+
+	if b.position.Y+b.width <= 0 {
+		return true, geometry.Top
+	}
+
+	if b.position.Y >= b.field.Height {
+		return true, geometry.Bottom
+	}
+
 	return false, geometry.Undefined
 }
 
+// LastHit returns the side of the last player who hit the ball.
+// Until a player hits it, it's the side the ball was served from.
+func (b *Local) LastHit() geometry.Side {
+	return b.lastHit
+}
+
 // Position returns the position of the ball.
 func (b *Local) Position() geometry.Vector {
 	return b.position
 }
 
-// Reset resets the position of the ball.
-func (b *Local) Reset() Ball {
-	if b.nextSide == geometry.Left {
-		b.nextSide = geometry.Right
-	} else {
-		b.nextSide = geometry.Left
-	}
+// PreviousPosition returns the position of the ball before the last update.
+func (b *Local) PreviousPosition() geometry.Vector {
+	return b.previous
+}
+
+// Reset returns a new ball served according to the match rules.
+// conceded is the side that conceded the last goal.
+func (b *Local) Reset(conceded geometry.Side) Ball {
+	serve := b.rules.NextServe(b.serve, conceded)
 
-	return NewLocal(b.screenWidth, b.screenHeight, b.level)
+	b.events.Publish(event.Serve{Side: serve})
+
+	return newLocal(b.field, b.level, b.rules, b.events, serve)
 }
 
 // SetAngle will panic because it is not implemented.
@@ -103,17 +173,53 @@ func (*Local) SetBounces(_ int) {
 	panic("not implemented")
 }
 
+// SetCurve makes the ball curve by curve degrees per second until it hits a paddle.
+func (b *Local) SetCurve(curve float64) {
+	b.curve = curve
+}
+
 // SetPosition sets the position of the ball.
 func (b *Local) SetPosition(pos geometry.Vector) {
 	b.position = pos
+	b.previous = pos
 }
 
-// Update updates the position of the ball.
-func (b *Local) Update(p1Bounds, p2Bounds geometry.Rect) {
-	b.position.X += b.speed * math.Cos(b.angle*math.Pi/180)
-	b.position.Y += b.speed * math.Sin(b.angle*math.Pi/180)
+// Spawn returns a new ball, with the same level and rules, served towards the given side.
+func (b *Local) Spawn(towards geometry.Side) Ball {
+	return newLocal(b.field, b.level, b.rules, b.events, towards)
+}
+
+// Update moves the ball for dt seconds.
+// The movement is swept against the arena and the given obstacles, so the ball bounces at
+// the exact point of contact regardless of its speed and never passes through a paddle.
+// A paddle that moved into the ball since the last update pushes it out first.
+func (b *Local) Update(dt float64, obstacles ...Obstacle) {
+	b.previous = b.position
+	b.angle += b.curve * dt
+
+	obstacles = append(append(make([]Obstacle, 0, len(b.static)+len(obstacles)), b.static...), obstacles...)
+
+	b.separate(obstacles)
+
+	remaining := 1.0
+
+	for range maxCollisions {
+		velocity := b.velocity().Scale(dt * remaining)
+
+		hit, obs, ok := b.firstHit(velocity, obstacles)
+		if !ok {
+			b.position = b.position.Add(velocity)
+			return
+		}
 
-	b.bounce(p1Bounds, p2Bounds)
+		b.position = b.position.Add(velocity.Scale(hit.Time))
+		b.resolve(hit, obs)
+
+		remaining *= 1 - hit.Time
+		if remaining <= 0 {
+			return
+		}
+	}
 }
 
 // Width returns the width of the ball.
@@ -121,65 +227,195 @@ func (b *Local) Width() float64 {
 	return b.width
 }
 
-func calcInitialAngle(nextSide geometry.Side) float64 {
-	if nextSide == geometry.Left {
+// calcInitialAngle returns a random angle to serve the ball towards the given side.
+func calcInitialAngle(serve geometry.Side) float64 {
+	switch serve {
+	case geometry.Right:
 		return -45 + float64(rand.IntN(91)) // nolint:gosec
+	case geometry.Top:
+		return -135 + float64(rand.IntN(91)) // nolint:gosec
+	case geometry.Bottom:
+		return 45 + float64(rand.IntN(91)) // nolint:gosec
+	default:
+		return 135 + float64(rand.IntN(91)) // nolint:gosec
 	}
+}
 
-	return 135 + float64(rand.IntN(91)) // nolint:gosec
+// velocity returns the velocity of the ball in units per second.
+func (b *Local) velocity() geometry.Vector {
+	return geometry.Vector{
+		X: b.speed * math.Cos(b.angle*math.Pi/180),
+		Y: b.speed * math.Sin(b.angle*math.Pi/180),
+	}
 }
 
-// bounce checks if the ball is bouncing on the walls or the players and changes the angle of the ball.
-func (b *Local) bounce(p1Bounds, p2Bounds geometry.Rect) {
-	b.checkWallBounce()
-	b.checkPaddleBounce(p1Bounds, p2Bounds)
+// separate pushes the ball out of the obstacles it overlaps, such as a paddle that moved into it,
+// through the side it penetrates the least whatever its direction. It bounces off the obstacles
+// it's moving towards and keeps its direction otherwise.
+func (b *Local) separate(obstacles []Obstacle) {
+	for range maxCollisions {
+		if !b.pushOut(obstacles, shallowest) {
+			return
+		}
+	}
+
+	// a paddle squeezing the ball against a wall pushes it back and forth between both,
+	// so the ball leaves the wall first, then the paddle along the axis of its goal
+	b.pushOut(b.static, shallowest)
+	b.pushOut(obstacles, alongGoal)
 }
 
-// checkWallBounce checks if the ball bounces off the top or bottom walls.
-func (b *Local) checkWallBounce() {
-	if b.position.Y <= width {
-		b.position.Y = width
-		b.bounceOffWall()
+// pushOut pushes the ball out of each obstacle it overlaps with the contact returned by penetration.
+// It returns true if the ball overlapped any obstacle.
+func (b *Local) pushOut(obstacles []Obstacle, penetration func(geometry.Rect, Obstacle) (geometry.Hit, bool)) bool {
+	overlapping := false
+
+	for _, obs := range obstacles {
+		hit, ok := penetration(b.ball.Bounds(), obs)
+		if !ok {
+			continue
+		}
+
+		overlapping = true
+
+		if movingTowards(b.velocity(), hit.Normal) {
+			b.resolve(hit, obs)
+			continue
+		}
+
+		b.place(hit, obs)
 	}
 
-	if b.position.Y >= b.screenHeight-b.width-width {
-		b.position.Y = b.screenHeight - b.width - width
-		b.bounceOffWall()
+	return overlapping
+}
+
+// firstHit returns the earliest contact of the ball moving by velocity against the obstacles.
+// Contacts against surfaces the ball is moving away from are ignored.
+func (b *Local) firstHit(velocity geometry.Vector, obstacles []Obstacle) (geometry.Hit, Obstacle, bool) {
+	var (
+		first geometry.Hit
+		found Obstacle
+		ok    bool
+	)
+
+	for _, obs := range obstacles {
+		hit, contact := b.ball.Bounds().Sweep(velocity, obs.Bounds)
+		if !contact || !movingTowards(velocity, hit.Normal) {
+			continue
+		}
+
+		if !ok || hit.Time < first.Time {
+			first, found, ok = hit, obs, true
+		}
 	}
+
+	return first, found, ok
+}
+
+// resolve places the ball against the surface that was hit and bounces off.
+func (b *Local) resolve(hit geometry.Hit, obs Obstacle) {
+	b.place(hit, obs)
+
+	if obs.Kind == Bumper {
+		b.bounceOffBumper(hit.Normal)
+		b.events.Publish(event.WallHit{})
+
+		return
+	}
+
+	// the ball bounces off the edges of a paddle as if it was a wall
+	if obs.Kind == Wall || (hit.Normal.Y != 0) != obs.Side.Horizontal() {
+		b.bounceOffWall(hit.Normal)
+		b.events.Publish(event.WallHit{})
+
+		return
+	}
+
+	b.lastHit = obs.Side
+	b.curve = 0
+	b.bounceOffPaddle(hit.Normal)
+
+	b.events.Publish(event.PaddleHit{
+		Side:     obs.Side,
+		Speed:    b.speed,
+		Offset:   b.paddleOffset(obs),
+		Position: b.Bounds().Center(),
+		Angle:    b.angle,
+	})
+}
+
+// place places the ball against the surface of the obstacle with the normal of the contact.
+func (b *Local) place(hit geometry.Hit, obs Obstacle) {
+	switch {
+	case hit.Normal.X > 0:
+		b.position.X = obs.Bounds.MaxX()
+	case hit.Normal.X < 0:
+		b.position.X = obs.Bounds.X - b.width
+	case hit.Normal.Y > 0:
+		b.position.Y = obs.Bounds.MaxY()
+	case hit.Normal.Y < 0:
+		b.position.Y = obs.Bounds.Y - b.width
+	}
+}
+
+// paddleOffset returns where the ball touches the paddle, from -1 at its top or left end
+// to 1 at its bottom or right end.
+func (b *Local) paddleOffset(obs Obstacle) float64 {
+	center, paddle := b.Bounds().Center(), obs.Bounds.Center()
+
+	offset := (center.Y - paddle.Y) / (obs.Bounds.Height / 2)
+	if obs.Side.Horizontal() {
+		offset = (center.X - paddle.X) / (obs.Bounds.Width / 2)
+	}
+
+	return min(max(offset, -1), 1)
 }
 
 // bounceOffWall changes the ball's angle when it hits a wall and slightly adjusts its angle randomly.
-func (b *Local) bounceOffWall() {
+// normal is the normal of the wall's surface.
+func (b *Local) bounceOffWall(normal geometry.Vector) {
 	b.bounces++
-	b.angle *= -1
+	b.reflect(normal)
+
 	// nolint:gosec
 	// slight random adjustment to avoid flat bounces
 	b.angle += 5 * (rand.Float64() - 0.5)
 	b.increaseSpeed()
 }
 
-// checkPaddleBounce checks if the ball is hitting one of the paddles and bounces off.
-func (b *Local) checkPaddleBounce(p1Bounds, p2Bounds geometry.Rect) {
-	if b.ball.Bounds().Intersects(p1Bounds) {
-		b.position.X = p1Bounds.X + p1Bounds.Width + width
-		b.bounceOffPaddle()
-	}
+// bounceOffBumper reflects the ball off a bumper and speeds it up.
+func (b *Local) bounceOffBumper(normal geometry.Vector) {
+	b.bounces++
+	b.reflect(normal)
 
-	if b.ball.Bounds().Intersects(p2Bounds) {
-		b.position.X = p2Bounds.X - b.width
-		b.bounceOffPaddle()
-	}
+	b.speed = math.Min(b.speed*bumperBoost, b.rules.MaxBallSpeed)
 }
 
 // bounceOffPaddle changes the ball's angle when it hits a paddle and slightly randomizes the angle.
-func (b *Local) bounceOffPaddle() {
+// normal is the normal of the paddle's face, the ball always leaves in its direction.
+func (b *Local) bounceOffPaddle(normal geometry.Vector) {
 	b.bounces++
-	b.randomBounce()
+	b.randomBounce(normal)
+
+	if !movingTowards(b.velocity(), normal.Scale(-1)) {
+		b.reflect(normal)
+	}
+
 	b.increaseSpeed()
 }
 
-func (b *Local) randomBounce() {
-	b.angle = 180 - b.angle - width + 20*rand.Float64() // nolint:gosec
+func (b *Local) randomBounce(normal geometry.Vector) {
+	b.reflect(normal)
+	b.angle += -10 + 20*rand.Float64() // nolint:gosec
+}
+
+// reflect mirrors the direction of the ball against a surface with the given normal.
+func (b *Local) reflect(normal geometry.Vector) {
+	if normal.Y != 0 {
+		b.angle *= -1
+	} else {
+		b.angle = 180 - b.angle
+	}
 }
 
 func (b *Local) increaseSpeed() {
@@ -189,15 +425,73 @@ func (b *Local) increaseSpeed() {
 
 	switch b.level {
 	case level.Easy:
-		b.speed += 0.5
+		b.speed += 30
 	case level.Medium:
-		b.speed++
+		b.speed += 60
 	case level.Hard:
-		b.speed += 2
+		b.speed += 120
 	}
 
-	if b.speed > maxSpeed {
-		b.speed = maxSpeed
+	if b.speed > b.rules.MaxBallSpeed {
+		b.speed = b.rules.MaxBallSpeed
 		return
 	}
 }
+
+// staticObstacles returns the obstacles of the arena that never move.
+// In four-way matches the top and bottom borders are replaced by goals between the corners.
+func staticObstacles(field arena.Arena, format rules.Format) []Obstacle {
+	var obstacles []Obstacle
+
+	borders := field.Borders()
+	if format == rules.FourWay {
+		borders = field.Corners()
+	}
+
+	for _, r := range append(borders, field.Posts()...) {
+		obstacles = append(obstacles, NewWall(r))
+	}
+
+	for _, r := range field.Walls {
+		obstacles = append(obstacles, NewWall(r))
+	}
+
+	for _, r := range field.Bumpers {
+		obstacles = append(obstacles, Obstacle{Bounds: r, Kind: Bumper})
+	}
+
+	return obstacles
+}
+
+// shallowest returns the contact pushing the ball out of the obstacle through the side
+// it penetrates the least.
+func shallowest(ball geometry.Rect, obs Obstacle) (geometry.Hit, bool) {
+	return ball.Penetration(obs.Bounds)
+}
+
+// alongGoal returns the contact pushing the ball out of a paddle through the nearest of the sides
+// facing the field and the goal, leaving aside the ends of the paddle.
+func alongGoal(ball geometry.Rect, obs Obstacle) (geometry.Hit, bool) {
+	if obs.Kind != Paddle || !ball.Overlaps(obs.Bounds) {
+		return geometry.Hit{}, false
+	}
+
+	if obs.Side.Horizontal() {
+		if ball.MaxY()-obs.Bounds.Y < obs.Bounds.MaxY()-ball.Y {
+			return geometry.Hit{Normal: geometry.Vector{Y: -1}}, true
+		}
+
+		return geometry.Hit{Normal: geometry.Vector{Y: 1}}, true
+	}
+
+	if ball.MaxX()-obs.Bounds.X < obs.Bounds.MaxX()-ball.X {
+		return geometry.Hit{Normal: geometry.Vector{X: -1}}, true
+	}
+
+	return geometry.Hit{Normal: geometry.Vector{X: 1}}, true
+}
+
+// movingTowards returns true if the velocity points against the surface with the given normal.
+func movingTowards(velocity, normal geometry.Vector) bool {
+	return velocity.X*normal.X+velocity.Y*normal.Y < 0
+}
@@ -0,0 +1,288 @@
+package ball_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/arena"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/ball"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/level"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+const (
+	dt          = 1.0 / 60
+	paddleSpeed = 600.0
+)
+
+// fastRules returns the default rules with a ball reaching speed.
+func fastRules(speed float64) rules.Rules {
+	r := rules.Default()
+	r.MaxBallSpeed = speed
+
+	return r
+}
+
+func TestLocal_Update_HighSpeed(t *testing.T) {
+	paddle := geometry.Rect{X: 20, Y: 200, Width: 10, Height: 50}
+
+	for _, speed := range []float64{480, 2000, 10000, 50000} {
+		for _, angle := range []float64{180, 160, 200, 135, 225} {
+			b := ball.NewLaunched(arena.Classic(), level.Hard, fastRules(speed), nil, geometry.Vector{X: 300, Y: 225},
+				angle, speed)
+
+			// aim the ball at the center of the paddle
+			center := paddle.Center()
+			start := geometry.Vector{
+				X: center.X + 5 + 200,
+				Y: center.Y + 200*math.Tan(angle*math.Pi/180),
+			}
+			b.SetPosition(geometry.Vector{X: start.X - 5, Y: start.Y - 5})
+
+			for range 120 {
+				b.Update(dt, ball.NewPaddle(paddle, geometry.Left))
+
+				if b.Bounds().Overlaps(paddle) {
+					t.Fatalf("speed %v, angle %v: ball %v overlaps the paddle", speed, angle, b.Bounds())
+				}
+
+				if behind(b.Bounds(), paddle) {
+					t.Fatalf("speed %v, angle %v: ball %v went through the paddle", speed, angle, b.Bounds())
+				}
+			}
+
+			if b.LastHit() != geometry.Left {
+				t.Errorf("speed %v, angle %v: the ball missed the paddle", speed, angle)
+			}
+		}
+	}
+}
+
+func TestLocal_Update_CornerGraze(t *testing.T) {
+	paddle := geometry.Rect{X: 20, Y: 200, Width: 10, Height: 50}
+
+	tests := map[string]struct {
+		position geometry.Vector
+		angle    float64
+	}{
+		"top corner":    {position: geometry.Vector{X: 60, Y: 160}, angle: 180 - 45},
+		"bottom corner": {position: geometry.Vector{X: 60, Y: 280}, angle: 180 + 45},
+		"top edge":      {position: geometry.Vector{X: 24, Y: 150}, angle: 90},
+		"bottom edge":   {position: geometry.Vector{X: 24, Y: 300}, angle: -90},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, speed := range []float64{480, 2000, 10000} {
+				b := ball.NewLaunched(arena.Classic(), level.Hard, fastRules(speed), nil, test.position, test.angle, speed)
+
+				for range 60 {
+					b.Update(dt, ball.NewPaddle(paddle, geometry.Left))
+
+					if b.Bounds().Overlaps(paddle) {
+						t.Fatalf("speed %v: ball %v overlaps the paddle", speed, b.Bounds())
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestLocal_Update_PaddleMovingIntoBall checks that a paddle that moved into the ball pushes it out
+// through the side it penetrates the least, whatever the direction of the ball.
+func TestLocal_Update_PaddleMovingIntoBall(t *testing.T) {
+	paddle := geometry.Rect{X: 20, Y: 100, Width: 10, Height: 50}
+
+	tests := map[string]struct {
+		// position is the center of the ball.
+		position geometry.Vector
+		angle    float64
+		// outside tells if the ball is out of the paddle on the expected side.
+		outside func(b geometry.Rect) bool
+	}{
+		"end of the paddle, ball moving towards the goal": {
+			position: geometry.Vector{X: 27, Y: 100},
+			angle:    180,
+			outside:  func(b geometry.Rect) bool { return b.MaxY() <= paddle.Y },
+		},
+		"end of the paddle, ball moving away": {
+			position: geometry.Vector{X: 27, Y: 100},
+			angle:    -90,
+			outside:  func(b geometry.Rect) bool { return b.MaxY() <= paddle.Y },
+		},
+		"end of the paddle, ball moving into it": {
+			position: geometry.Vector{X: 27, Y: 152},
+			angle:    -100,
+			outside:  func(b geometry.Rect) bool { return b.Y >= paddle.MaxY() },
+		},
+		"face of the paddle, ball moving along it": {
+			position: geometry.Vector{X: 33, Y: 125},
+			angle:    90,
+			outside:  func(b geometry.Rect) bool { return b.X >= paddle.MaxX() },
+		},
+		"face of the paddle, ball moving away": {
+			position: geometry.Vector{X: 33, Y: 125},
+			angle:    0,
+			outside:  func(b geometry.Rect) bool { return b.X >= paddle.MaxX() },
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := ball.NewLaunched(arena.Classic(), level.Hard, fastRules(2000), nil, test.position, test.angle, 300)
+
+			if !b.Bounds().Overlaps(paddle) {
+				t.Fatalf("ball %v doesn't start in the paddle", b.Bounds())
+			}
+
+			b.Update(dt, ball.NewPaddle(paddle, geometry.Left))
+
+			if b.Bounds().Overlaps(paddle) {
+				t.Fatalf("ball %v still overlaps the paddle", b.Bounds())
+			}
+
+			if !test.outside(b.Bounds()) {
+				t.Errorf("ball %v was pushed out on the wrong side", b.Bounds())
+			}
+		})
+	}
+}
+
+// TestLocal_Update_PaddleSqueezingBall checks a paddle moving into a ball against the top wall
+// pushes it out of both, through the nearest of the face and the back of the paddle.
+func TestLocal_Update_PaddleSqueezingBall(t *testing.T) {
+	field := arena.Classic()
+	paddle := geometry.Rect{X: 20, Y: field.BorderWidth + 7, Width: 10, Height: 50}
+
+	tests := map[string]struct {
+		// x is the horizontal center of the ball, which touches the top wall.
+		x       float64
+		outside func(b geometry.Rect) bool
+	}{
+		"nearer the face": {
+			x:       28,
+			outside: func(b geometry.Rect) bool { return b.X >= paddle.MaxX() },
+		},
+		"nearer the back": {
+			x:       20,
+			outside: func(b geometry.Rect) bool { return b.MaxX() <= paddle.X },
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			position := geometry.Vector{X: test.x, Y: field.BorderWidth + 5}
+			b := ball.NewLaunched(field, level.Hard, fastRules(2000), nil, position, -90, 300)
+
+			b.Update(dt, ball.NewPaddle(paddle, geometry.Left))
+
+			if b.Bounds().Overlaps(paddle) {
+				t.Fatalf("ball %v still overlaps the paddle", b.Bounds())
+			}
+
+			if b.Bounds().Y < field.BorderWidth {
+				t.Fatalf("ball %v was pushed into the top wall", b.Bounds())
+			}
+
+			if !test.outside(b.Bounds()) {
+				t.Errorf("ball %v was pushed out on the wrong side", b.Bounds())
+			}
+		})
+	}
+}
+
+// TestLocal_Update_Rallies plays rallies between paddles chasing the ball at a high speed, moving
+// into it now and then, and checks the ball never overlaps nor goes through a paddle.
+func TestLocal_Update_Rallies(t *testing.T) {
+	rng := rand.New(rand.NewPCG(26, 3000)) // nolint:gosec
+	field := arena.Classic()
+	matchRules := fastRules(2000)
+
+	for rally := range 3000 {
+		paddles := []geometry.Rect{
+			{X: 20, Y: 215, Width: 10, Height: 50},
+			{X: field.Width - 30, Y: 215, Width: 10, Height: 50},
+		}
+		sides := []geometry.Side{geometry.Left, geometry.Right}
+		// aim is where each paddle tries to meet the ball, from its top to its bottom end
+		aim := []float64{rng.Float64() * 60, rng.Float64() * 60}
+
+		// passedThrough assumes the ball moves in a straight line, which it doesn't when it
+		// bounces off a wall in the middle of an update
+		events := event.NewBus()
+		bounced := false
+
+		event.On(events, func(event.WallHit) { bounced = true })
+
+		var b ball.Ball = ball.NewLocal(field, level.Hard, matchRules, events)
+
+		for range 3600 {
+			for i := range paddles {
+				target := b.Bounds().Center().Y - aim[i] + 5
+				move := max(-paddleSpeed*dt, min(paddleSpeed*dt, target-paddles[i].Y))
+				paddles[i].Y = max(field.BorderWidth, min(field.Height-field.BorderWidth-paddles[i].Height, paddles[i].Y+move))
+			}
+
+			previous := b.Bounds()
+			bounced = false
+
+			b.Update(dt, ball.NewPaddle(paddles[0], sides[0]), ball.NewPaddle(paddles[1], sides[1]))
+
+			for i, paddle := range paddles {
+				if b.Bounds().Overlaps(paddle) {
+					t.Fatalf("rally %d: ball %v overlaps the %v paddle %v", rally, b.Bounds(), sides[i], paddle)
+				}
+
+				if !bounced && passedThrough(previous, b.Bounds(), paddle, sides[i]) {
+					t.Fatalf("rally %d: ball went from %v to %v through the %v paddle %v",
+						rally, previous, b.Bounds(), sides[i], paddle)
+				}
+			}
+
+			if goal, _ := b.CheckGoal(); goal {
+				break
+			}
+
+			// change where the paddles meet the ball after every hit
+			if rng.IntN(30) == 0 {
+				aim[rng.IntN(2)] = rng.Float64() * 60
+			}
+		}
+	}
+}
+
+// behind returns true if the ball is behind the left paddle, level with it.
+func behind(b, paddle geometry.Rect) bool {
+	return b.MaxX() <= paddle.X && b.MaxY() > paddle.Y && b.Y < paddle.MaxY()
+}
+
+// passedThrough returns true if the ball went from the front to the back of the paddle while
+// crossing its face, level with it.
+func passedThrough(from, to, paddle geometry.Rect, side geometry.Side) bool {
+	var front, back bool
+
+	switch side {
+	case geometry.Left:
+		front, back = from.X >= paddle.MaxX(), to.MaxX() <= paddle.X
+	default:
+		front, back = from.MaxX() <= paddle.X, to.X >= paddle.MaxX()
+	}
+
+	if !front || !back {
+		return false
+	}
+
+	// where the ball crosses the face of the paddle
+	face := paddle.MaxX()
+	if side != geometry.Left {
+		face = paddle.X - from.Width
+	}
+
+	t := (face - from.X) / (to.X - from.X)
+	y := from.Y + (to.Y-from.Y)*t
+
+	return y+from.Height > paddle.Y && y < paddle.MaxY()
+}
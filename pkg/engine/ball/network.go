@@ -21,7 +21,7 @@ func (b *Network) Angle() float64 {
 	return b.angle
 }
 
-// Bounces will panic because it is not implemented.
+// Bounces returns the number of bounces of the ball.
 func (b *Network) Bounces() int {
 	return b.bounces
 }
@@ -36,13 +36,23 @@ func (*Network) CheckGoal() (bool, geometry.Side) {
 	panic("not implemented")
 }
 
+// LastHit returns the side of the last player who hit the ball.
+func (b *Network) LastHit() geometry.Side {
+	return b.lastHit
+}
+
 // Position returns the position of the ball.
 func (b *Network) Position() geometry.Vector {
 	return b.position
 }
 
+// PreviousPosition returns the position of the ball before the last received update.
+func (b *Network) PreviousPosition() geometry.Vector {
+	return b.previous
+}
+
 // Reset will panic because it is not implemented.
-func (*Network) Reset() Ball {
+func (*Network) Reset(_ geometry.Side) Ball {
 	panic("not implemented")
 }
 
@@ -56,13 +66,24 @@ func (b *Network) SetBounces(bounces int) {
 	b.bounces = bounces
 }
 
+// SetCurve will panic because it is not implemented.
+func (*Network) SetCurve(_ float64) {
+	panic("not implemented")
+}
+
 // SetPosition sets the position of the ball.
 func (b *Network) SetPosition(pos geometry.Vector) {
+	b.previous = b.position
 	b.position = pos
 }
 
+// Spawn will panic because it is not implemented.
+func (*Network) Spawn(_ geometry.Side) Ball {
+	panic("not implemented")
+}
+
 // Update will panic because it is not implemented.
-func (*Network) Update(_, _ geometry.Rect) {
+func (*Network) Update(_ float64, _ ...Obstacle) {
 	panic("not implemented")
 }
 
@@ -0,0 +1,100 @@
+// Package event publishes what happens in a match, such as hits, goals, serves and its end,
+// so audio, effects, stats or replays can react to it without being called by the engine directly.
+package event
+
+import "github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+
+type (
+	// Event is something that happened in a match.
+	Event interface {
+		event()
+	}
+
+	// PaddleHit is published when the ball bounces off the face of a paddle.
+	PaddleHit struct {
+		// Side is the side defended by the player who hit the ball, geometry.Undefined
+		// when it isn't known. Network matches guess it from where the ball is.
+		Side geometry.Side
+		// Speed is the speed of the ball leaving the paddle, in units per second,
+		// estimated in network matches and zero when it isn't known.
+		Speed float64
+		// Offset is where the ball hit the paddle, from -1 at its top or left end
+		// to 1 at its bottom or right end, 0 being its center.
+		Offset float64
+		// Position is the center of the ball when it hit the paddle, in field units.
+		Position geometry.Vector
+		// Angle is the direction of the ball leaving the paddle, in degrees,
+		// 0 being towards the right side and 90 towards the bottom side.
+		Angle float64
+	}
+
+	// WallHit is published when the ball bounces off a border, a wall, a bumper,
+	// a shield or the edge of a paddle.
+	WallHit struct{}
+
+	// Goal is published when a ball goes into a goal.
+	Goal struct {
+		// Side is the side that conceded the goal,
+		// geometry.Undefined when it isn't known, as in network matches.
+		Side geometry.Side
+		// Scorer is the side given the point, geometry.Undefined when nobody scores
+		// or when it isn't known.
+		Scorer geometry.Side
+		// Position is the center of the ball when it went into the goal, in field units.
+		Position geometry.Vector
+	}
+
+	// Serve is published when a ball is served, at the start of the match and after each goal.
+	Serve struct {
+		// Side is the side the ball is served towards.
+		Side geometry.Side
+	}
+
+	// MatchOver is published once a side has won the match.
+	MatchOver struct {
+		// Winner is the side that won the match.
+		Winner geometry.Side
+	}
+)
+
+func (PaddleHit) event() {}
+func (WallHit) event()   {}
+func (Goal) event()      {}
+func (Serve) event()     {}
+func (MatchOver) event() {}
+
+// Bus delivers the published events to their subscribers, synchronously and in the order
+// they subscribed. A nil Bus discards every event.
+type Bus struct {
+	handlers []func(Event)
+}
+
+// NewBus creates a new Bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Publish delivers the event to every subscriber.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+
+	for _, handle := range b.handlers {
+		handle(e)
+	}
+}
+
+// Subscribe calls handle for every event published from now on.
+func (b *Bus) Subscribe(handle func(Event)) {
+	b.handlers = append(b.handlers, handle)
+}
+
+// On calls handle for every event of type T published on the bus from now on.
+func On[T Event](b *Bus, handle func(T)) {
+	b.Subscribe(func(e Event) {
+		if typed, ok := e.(T); ok {
+			handle(typed)
+		}
+	})
+}
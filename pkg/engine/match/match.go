@@ -0,0 +1,103 @@
+// Package match keeps the scores and the clock of a local match and publishes its goals and its end.
+package match
+
+import (
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/event"
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Match is a match played by the rules, from the first serve to the winner.
+type Match struct {
+	rules   rules.Rules
+	events  *event.Bus
+	scores  map[geometry.Side]int
+	elapsed time.Duration
+	// winner is the side that won the match, geometry.Undefined while it's being played.
+	winner      geometry.Side
+	suddenDeath bool
+}
+
+// New creates a new match played by matchRules.
+// events receives the goals and the end of the match, it can be nil.
+func New(matchRules rules.Rules, events *event.Bus) *Match {
+	scores := make(map[geometry.Side]int)
+	for _, side := range matchRules.Format.Sides() {
+		scores[side] = 0
+	}
+
+	return &Match{
+		rules:  matchRules,
+		events: events,
+		scores: scores,
+	}
+}
+
+// Advance adds dt seconds to the elapsed time, ending the match when its time is up.
+func (m *Match) Advance(dt float64) {
+	if m.Over() {
+		return
+	}
+
+	m.elapsed += time.Duration(dt * float64(time.Second))
+	m.check()
+}
+
+// Goal gives a point to the side scoring when conceded concedes a goal and publishes it.
+// lastHit is the side of the last player who hit the ball and position the center of the ball
+// in the goal. Goals are ignored once the match is over.
+func (m *Match) Goal(conceded, lastHit geometry.Side, position geometry.Vector) {
+	if m.Over() {
+		return
+	}
+
+	scorer := m.rules.Scorer(conceded, lastHit)
+	if scorer != geometry.Undefined {
+		m.scores[scorer]++
+	}
+
+	m.events.Publish(event.Goal{Side: conceded, Scorer: scorer, Position: position})
+	m.check()
+}
+
+// check ends the match and publishes its end once a side has won.
+func (m *Match) check() {
+	m.suddenDeath = m.rules.SuddenDeath(m.scores, m.elapsed)
+
+	m.winner = m.rules.Winner(m.scores, m.elapsed)
+	if m.winner != geometry.Undefined {
+		m.events.Publish(event.MatchOver{Winner: m.winner})
+	}
+}
+
+// Score returns the score of the side.
+func (m *Match) Score(side geometry.Side) int {
+	return m.scores[side]
+}
+
+// Elapsed returns the time played.
+func (m *Match) Elapsed() time.Duration {
+	return m.elapsed
+}
+
+// Remaining returns the time left in a timed match, zero once the time is up.
+func (m *Match) Remaining() time.Duration {
+	return max(m.rules.TimeLimit-m.elapsed, 0)
+}
+
+// SuddenDeath returns true when the time is up with a tied lead, so the next goal wins.
+func (m *Match) SuddenDeath() bool {
+	return m.suddenDeath
+}
+
+// Winner returns the side that won the match, geometry.Undefined while it's being played.
+func (m *Match) Winner() geometry.Side {
+	return m.winner
+}
+
+// Over returns true once a side has won the match.
+func (m *Match) Over() bool {
+	return m.winner != geometry.Undefined
+}
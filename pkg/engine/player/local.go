@@ -4,28 +4,17 @@ import "github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 
 // Local represents a player that is controlled by the user.
 type Local struct {
-	screenWidth      float64
-	screenHeight     float64
-	fieldBorderWidth float64
+	area geometry.Rect
 	*player
 }
 
 // NewLocal creates a new player to play locally.
-func NewLocal(name string, side geometry.Side, screenWidth, screenHeight, fieldBorderWidth float64) *Local {
+// area is the part of the field the paddle moves in, it stands close to the edge of side.
+// paddle is the size and the speed of the paddle.
+func NewLocal(name string, side geometry.Side, area geometry.Rect, paddle Paddle) *Local {
 	return &Local{
-		screenHeight:     screenHeight,
-		screenWidth:      screenWidth,
-		fieldBorderWidth: fieldBorderWidth,
-		player: &player{
-			name:          name,
-			side:          side,
-			bouncerHeight: bouncerHeight,
-			bouncerWidth:  bouncerWidth,
-			position: geometry.Vector{
-				X: calculatePositionX(side, screenWidth),
-				Y: (screenHeight - bouncerHeight) / 2,
-			},
-		},
+		area:   area,
+		player: newPlayer(name, side, area, paddle),
 	}
 }
 
@@ -54,19 +43,40 @@ func (p *Local) Position() geometry.Vector {
 	return p.position
 }
 
+// PreviousPosition returns the position of the player before the last update.
+func (p *Local) PreviousPosition() geometry.Vector {
+	return p.previous
+}
+
 // Side returns the side of the player.
 func (p *Local) Side() geometry.Side {
 	return p.side
 }
 
-// Reset resets the position of the player.
+// Reset centers the player in its area.
 func (p *Local) Reset() {
-	p.position.Y = (p.screenHeight - p.bouncerHeight) / 2
+	low, high := p.limits()
+	p.setOffset((low + high) / 2)
+	p.previous = p.position
 }
 
-// SetPosition sets the Y position of the player.
-func (p *Local) SetPosition(y float64) {
-	p.position.Y = y
+// SetBouncerHeight sets the height of the bouncer, keeping it centered where it was.
+func (p *Local) SetBouncerHeight(height float64) {
+	if height == p.bouncerHeight {
+		return
+	}
+
+	p.setOffset(p.offset() + (p.bouncerHeight-height)/2)
+	p.bouncerHeight = height
+	p.keepInBounds()
+	p.previous = p.position
+}
+
+// SetPosition sets the position of the player along the axis it moves on:
+// Y for the left and right sides and X for the top and bottom ones.
+func (p *Local) SetPosition(pos float64) {
+	p.previous = p.position
+	p.setOffset(pos)
 }
 
 // SetName sets the name of the player.
@@ -74,24 +84,46 @@ func (p *Local) SetName(name string) {
 	p.name = name
 }
 
-// Update updates the position of the player based on the input.
-func (p *Local) Update(input Input) {
+// Speed returns the speed of the paddle in units per second.
+func (p *Local) Speed() float64 {
+	return p.speed
+}
+
+// Update moves the player for dt seconds based on the input.
+func (p *Local) Update(dt float64, input Input) {
+	p.previous = p.position
+
+	backward, forward := input.Up, input.Down
+	if p.side.Horizontal() {
+		backward, forward = input.Left, input.Right
+	}
+
 	switch {
-	case input.Up:
-		p.position.Y -= movementSpeed
-	case input.Down:
-		p.position.Y += movementSpeed
+	case input.Follow:
+		step := p.speed * dt
+		delta := input.Target - (p.offset() + p.bouncerHeight/2)
+		p.setOffset(p.offset() + min(max(delta, -step), step))
+	case input.Axis != 0:
+		p.setOffset(p.offset() + min(max(input.Axis, -1), 1)*p.speed*dt)
+	case backward:
+		p.setOffset(p.offset() - p.speed*dt)
+	case forward:
+		p.setOffset(p.offset() + p.speed*dt)
 	}
 
 	p.keepInBounds()
 }
 
-func (p *Local) keepInBounds() {
-	if p.position.Y < p.fieldBorderWidth {
-		p.position.Y = p.fieldBorderWidth
+// limits returns the lowest and highest positions the player can take along the axis it moves on.
+func (p *Local) limits() (float64, float64) {
+	if p.side.Horizontal() {
+		return p.area.X, p.area.MaxX() - p.bouncerHeight
 	}
 
-	if p.position.Y > p.screenHeight-p.bouncerHeight-p.fieldBorderWidth {
-		p.position.Y = p.screenHeight - p.bouncerHeight - p.fieldBorderWidth
-	}
+	return p.area.Y, p.area.MaxY() - p.bouncerHeight
+}
+
+func (p *Local) keepInBounds() {
+	low, high := p.limits()
+	p.setOffset(min(max(p.offset(), low), high))
 }
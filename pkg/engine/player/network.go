@@ -9,18 +9,11 @@ type Network struct {
 }
 
 // NewNetwork creates a new player to play in a network game.
-func NewNetwork(name string, side geometry.Side, screenWidth, screenHeight float64) *Network {
+// area is the part of the field the paddle moves in, it stands close to the edge of side.
+// paddle is the size and the speed of the paddle, as the server moves it.
+func NewNetwork(name string, side geometry.Side, area geometry.Rect, paddle Paddle) *Network {
 	return &Network{
-		player: &player{
-			name:          name,
-			side:          side,
-			bouncerHeight: bouncerHeight,
-			bouncerWidth:  bouncerWidth,
-			position: geometry.Vector{
-				X: calculatePositionX(side, screenWidth),
-				Y: (screenHeight - bouncerHeight) / 2,
-			},
-		},
+		player: newPlayer(name, side, area, paddle),
 	}
 }
 
@@ -49,6 +42,11 @@ func (p *Network) Position() geometry.Vector {
 	return p.position
 }
 
+// PreviousPosition returns the position of the player before the last received update.
+func (p *Network) PreviousPosition() geometry.Vector {
+	return p.previous
+}
+
 // Side returns the side of the player.
 func (p *Network) Side() geometry.Side {
 	return p.side
@@ -59,13 +57,25 @@ func (*Network) Reset() {
 	panic("not implemented")
 }
 
-// SetPosition sets the Y position of the player.
-func (p *Network) SetPosition(y float64) {
-	p.position.Y = y
+// SetBouncerHeight sets the height of the bouncer.
+func (p *Network) SetBouncerHeight(height float64) {
+	p.bouncerHeight = height
+}
+
+// SetPosition sets the position of the player along the axis it moves on:
+// Y for the left and right sides and X for the top and bottom ones.
+func (p *Network) SetPosition(pos float64) {
+	p.previous = p.position
+	p.setOffset(pos)
+}
+
+// Speed returns the speed of the paddle in units per second.
+func (p *Network) Speed() float64 {
+	return p.speed
 }
 
 // Update will panic because it is not implemented.
-func (*Network) Update(_ Input) {
+func (*Network) Update(_ float64, _ Input) {
 	panic("not implemented")
 }
 
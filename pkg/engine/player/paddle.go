@@ -0,0 +1,90 @@
+package player
+
+// Default size and speed of the paddles.
+const (
+	DefaultBouncerHeight = 50
+	DefaultBouncerWidth  = 10
+	// DefaultSpeed is expressed in units per second.
+	DefaultSpeed = 240
+)
+
+const (
+	// NoHandicap plays with the default paddle.
+	NoHandicap Handicap = iota
+	// BigPaddle makes the paddle half as long again, for beginners.
+	BigPaddle
+	// HugePaddle makes the paddle twice as long.
+	HugePaddle
+	// FastPaddle makes the paddle a quarter faster.
+	FastPaddle
+	// SmallPaddle makes the paddle shorter, for the stronger player.
+	SmallPaddle
+	// SlowPaddle makes the paddle a quarter slower, for the stronger player.
+	SlowPaddle
+)
+
+type (
+	// Paddle is the size and the speed of the paddle of a player.
+	Paddle struct {
+		// Height is the length of the paddle along the axis it moves on and Width its thickness.
+		Height float64
+		Width  float64
+		// Speed is expressed in units per second.
+		Speed float64
+	}
+
+	// Handicap changes the paddle of a player to even out a match between players of
+	// different skills.
+	Handicap int
+)
+
+// DefaultPaddle returns the paddle every player has without a handicap.
+func DefaultPaddle() Paddle {
+	return Paddle{Height: DefaultBouncerHeight, Width: DefaultBouncerWidth, Speed: DefaultSpeed}
+}
+
+// Handicaps returns every handicap, from none to the ones helping the most and hindering the most.
+func Handicaps() []Handicap {
+	return []Handicap{NoHandicap, BigPaddle, HugePaddle, FastPaddle, SmallPaddle, SlowPaddle}
+}
+
+// String returns the name of the handicap as displayed to players.
+func (h Handicap) String() string {
+	switch h {
+	case BigPaddle:
+		return "Big paddle"
+	case HugePaddle:
+		return "Huge paddle"
+	case FastPaddle:
+		return "Fast paddle"
+	case SmallPaddle:
+		return "Small paddle"
+	case SlowPaddle:
+		return "Slow paddle"
+	default:
+		return "None"
+	}
+}
+
+// Apply returns the paddle changed by the handicap.
+func (h Handicap) Apply(p Paddle) Paddle {
+	switch h {
+	case BigPaddle:
+		p.Height *= 1.5
+	case HugePaddle:
+		p.Height *= 2
+	case FastPaddle:
+		p.Speed *= 1.25
+	case SmallPaddle:
+		p.Height *= 0.7
+	case SlowPaddle:
+		p.Speed *= 0.75
+	}
+
+	return p
+}
+
+// Paddle returns the default paddle changed by the handicap.
+func (h Handicap) Paddle() Paddle {
+	return h.Apply(DefaultPaddle())
+}
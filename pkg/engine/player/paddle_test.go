@@ -0,0 +1,29 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/player"
+)
+
+func TestHandicap_Paddle(t *testing.T) {
+	tests := map[player.Handicap]player.Paddle{
+		player.NoHandicap:  {Height: 50, Width: 10, Speed: 240},
+		player.BigPaddle:   {Height: 75, Width: 10, Speed: 240},
+		player.HugePaddle:  {Height: 100, Width: 10, Speed: 240},
+		player.FastPaddle:  {Height: 50, Width: 10, Speed: 300},
+		player.SmallPaddle: {Height: 35, Width: 10, Speed: 240},
+		player.SlowPaddle:  {Height: 50, Width: 10, Speed: 180},
+		// servers derive the paddle of unknown handicaps sent by players as the default one
+		player.Handicap(-1): player.DefaultPaddle(),
+		player.Handicap(42): player.DefaultPaddle(),
+	}
+
+	for handicap, want := range tests {
+		t.Run(handicap.String(), func(t *testing.T) {
+			if got := handicap.Paddle(); got != want {
+				t.Errorf("paddle = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
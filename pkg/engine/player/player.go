@@ -4,17 +4,27 @@ import (
 	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
 )
 
-const (
-	bouncerHeight = 50
-	bouncerWidth  = 10
-	movementSpeed = 4
-)
+// goalDistance is the distance between the paddle and the edge of its side.
+const goalDistance = 15
 
 type (
 	// Input represents the input of the player.
+	// Paddles on the left and right sides move with Up and Down,
+	// paddles on the top and bottom sides move with Left and Right.
 	Input struct {
-		Up   bool
-		Down bool
+		Up    bool
+		Down  bool
+		Left  bool
+		Right bool
+		// Axis is the analog control of the paddle, between -1 and 1, along the axis it
+		// moves on. Negative values move it up or left. When it's not zero it takes
+		// precedence over the digital controls and sets the speed of the paddle.
+		Axis float64
+		// Follow moves the paddle at full speed until its center reaches Target, a position
+		// along the axis it moves on. Used by touch and mouse controls, it takes precedence
+		// over the other controls.
+		Follow bool
+		Target float64
 	}
 
 	player struct {
@@ -22,7 +32,10 @@ type (
 		side          geometry.Side
 		bouncerHeight float64
 		bouncerWidth  float64
-		position      geometry.Vector
+		// speed is expressed in units per second.
+		speed    float64
+		position geometry.Vector
+		previous geometry.Vector
 	}
 
 	// Player represents a player.
@@ -34,24 +47,56 @@ type (
 		SetName(name string)
 		Side() geometry.Side
 		Position() geometry.Vector
+		PreviousPosition() geometry.Vector
 		Reset()
-		SetPosition(y float64)
-		Update(input Input)
+		SetBouncerHeight(height float64)
+		SetPosition(pos float64)
+		Speed() float64
+		Update(dt float64, input Input)
 	}
 )
 
-// calculatePositionX calculates the initial X position of the player.
-func calculatePositionX(side geometry.Side, screenWidth float64) float64 {
-	x := 15.0
-	if side == geometry.Right {
-		x = screenWidth - 25
+// newPlayer creates the paddle of a player centered in area, close to the edge of side.
+func newPlayer(name string, side geometry.Side, area geometry.Rect, paddle Paddle) *player {
+	position := initialPosition(side, area, paddle)
+
+	return &player{
+		name:          name,
+		side:          side,
+		bouncerHeight: paddle.Height,
+		bouncerWidth:  paddle.Width,
+		speed:         paddle.Speed,
+		position:      position,
+		previous:      position,
 	}
+}
 
-	return x
+// initialPosition returns the position of a paddle centered in area, close to the edge of side.
+func initialPosition(side geometry.Side, area geometry.Rect, paddle Paddle) geometry.Vector {
+	switch side {
+	case geometry.Right:
+		return geometry.Vector{X: area.MaxX() - goalDistance - paddle.Width, Y: area.Y + (area.Height-paddle.Height)/2}
+	case geometry.Top:
+		return geometry.Vector{X: area.X + (area.Width-paddle.Height)/2, Y: area.Y + goalDistance}
+	case geometry.Bottom:
+		return geometry.Vector{X: area.X + (area.Width-paddle.Height)/2, Y: area.MaxY() - goalDistance - paddle.Width}
+	default:
+		return geometry.Vector{X: area.X + goalDistance, Y: area.Y + (area.Height-paddle.Height)/2}
+	}
 }
 
 // Bounds returns the bounds of the player.
+// Paddles on the top and bottom sides lie horizontally, so their height is the bouncer width.
 func (p *player) Bounds() geometry.Rect {
+	if p.side.Horizontal() {
+		return geometry.Rect{
+			X:      p.position.X,
+			Y:      p.position.Y,
+			Width:  p.bouncerHeight,
+			Height: p.bouncerWidth,
+		}
+	}
+
 	return geometry.Rect{
 		X:      p.position.X,
 		Y:      p.position.Y,
@@ -59,3 +104,22 @@ func (p *player) Bounds() geometry.Rect {
 		Height: p.bouncerHeight,
 	}
 }
+
+// offset returns the position of the player along the axis it moves on.
+func (p *player) offset() float64 {
+	if p.side.Horizontal() {
+		return p.position.X
+	}
+
+	return p.position.Y
+}
+
+// setOffset sets the position of the player along the axis it moves on.
+func (p *player) setOffset(pos float64) {
+	if p.side.Horizontal() {
+		p.position.X = pos
+		return
+	}
+
+	p.position.Y = pos
+}
@@ -0,0 +1,261 @@
+package powerup
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// Kind represents the kind of a power-up.
+type Kind int
+
+const (
+	// Grow makes the paddle of the collector bigger.
+	Grow Kind = iota
+	// Shrink makes the paddle of the opponent smaller.
+	Shrink
+	// ExtraBall serves an additional ball.
+	ExtraBall
+	// SlowMo slows down all balls.
+	SlowMo
+	// Curve makes the ball curve until it hits a paddle.
+	Curve
+	// Shield puts a wall behind the paddle of the collector.
+	Shield
+)
+
+const (
+	// Size is the width and height of a power-up.
+	Size = 20
+
+	spawnInterval = 6 * time.Second
+	lifetime      = 10 * time.Second
+	maxOnField    = 3
+	// spawnAttempts is how many random positions are tried to avoid spawning on an obstacle.
+	spawnAttempts = 10
+
+	growFactor   = 1.5
+	shrinkFactor = 0.6
+	slowMoFactor = 0.5
+)
+
+// String returns a string representation of the power-up kind.
+func (k Kind) String() string {
+	return [...]string{"Grow", "Shrink", "Extra ball", "Slow-mo", "Curve", "Shield"}[k]
+}
+
+// Symbol returns a single character representing the power-up kind on the field.
+func (k Kind) Symbol() string {
+	return [...]string{"G", "S", "+", "~", "C", "#"}[k]
+}
+
+// duration returns how long the effect of a power-up kind lasts.
+// Zero means the effect is applied instantly.
+func (k Kind) duration() time.Duration {
+	switch k {
+	case Grow, Shrink:
+		return 10 * time.Second
+	case SlowMo:
+		return 5 * time.Second
+	case Shield:
+		return 8 * time.Second
+	default:
+		return 0
+	}
+}
+
+type (
+	// PowerUp represents a power-up waiting to be collected on the field.
+	PowerUp struct {
+		Kind      Kind            `json:"kind"`
+		Position  geometry.Vector `json:"position"`
+		remaining time.Duration
+	}
+
+	// effect represents an active effect of a collected power-up.
+	effect struct {
+		kind      Kind
+		side      geometry.Side
+		remaining time.Duration
+	}
+
+	// Field spawns power-ups and keeps track of the active effects.
+	Field struct {
+		bounds     geometry.Rect
+		blocked    []geometry.Rect
+		untilSpawn time.Duration
+		items      []PowerUp
+		effects    []effect
+	}
+)
+
+// Bounds returns the bounds of the power-up.
+func (p PowerUp) Bounds() geometry.Rect {
+	return geometry.Rect{
+		X:      p.Position.X,
+		Y:      p.Position.Y,
+		Width:  Size,
+		Height: Size,
+	}
+}
+
+// NewField creates a new field spawning power-ups inside bounds.
+// blocked are the obstacles power-ups must not be spawned on.
+func NewField(bounds geometry.Rect, blocked []geometry.Rect) *Field {
+	return &Field{
+		bounds:     bounds,
+		blocked:    blocked,
+		untilSpawn: spawnInterval,
+	}
+}
+
+// Update advances the field by dt seconds, spawning new power-ups and
+// expiring the uncollected power-ups and the active effects.
+func (f *Field) Update(dt float64) {
+	elapsed := time.Duration(dt * float64(time.Second))
+
+	f.items = expire(f.items, elapsed, func(p *PowerUp) *time.Duration { return &p.remaining })
+	f.effects = expire(f.effects, elapsed, func(e *effect) *time.Duration { return &e.remaining })
+
+	f.untilSpawn -= elapsed
+	if f.untilSpawn > 0 {
+		return
+	}
+
+	f.untilSpawn = spawnInterval
+
+	if len(f.items) >= maxOnField {
+		return
+	}
+
+	position, ok := f.freePosition()
+	if !ok {
+		return
+	}
+
+	f.items = append(f.items, PowerUp{
+		Kind:      Kind(rand.IntN(int(Shield) + 1)), // nolint:gosec
+		Position:  position,
+		remaining: lifetime,
+	})
+}
+
+// freePosition returns a random position inside the bounds that isn't on an obstacle.
+func (f *Field) freePosition() (geometry.Vector, bool) {
+	for range spawnAttempts {
+		p := PowerUp{
+			Position: geometry.Vector{
+				X: f.bounds.X + rand.Float64()*(f.bounds.Width-Size),  // nolint:gosec
+				Y: f.bounds.Y + rand.Float64()*(f.bounds.Height-Size), // nolint:gosec
+			},
+		}
+
+		if !slices.ContainsFunc(f.blocked, p.Bounds().Intersects) {
+			return p.Position, true
+		}
+	}
+
+	return geometry.Vector{}, false
+}
+
+// Collect returns the power-up touched by bounds and removes it from the field.
+func (f *Field) Collect(bounds geometry.Rect) (PowerUp, bool) {
+	for i, p := range f.items {
+		if bounds.Intersects(p.Bounds()) {
+			f.items = append(f.items[:i], f.items[i+1:]...)
+			return p, true
+		}
+	}
+
+	return PowerUp{}, false
+}
+
+// Activate starts the effect of a power-up collected by side.
+// Instant effects, such as ExtraBall and Curve, must be applied by the caller.
+func (f *Field) Activate(kind Kind, side geometry.Side) {
+	duration := kind.duration()
+	if duration == 0 {
+		return
+	}
+
+	switch kind {
+	case Shrink:
+		side = side.Opposite()
+	case SlowMo:
+		side = geometry.Undefined
+	}
+
+	// collecting an active effect again restarts it
+	for i, e := range f.effects {
+		if e.kind == kind && e.side == side {
+			f.effects[i].remaining = duration
+			return
+		}
+	}
+
+	f.effects = append(f.effects, effect{kind: kind, side: side, remaining: duration})
+}
+
+// PowerUps returns the power-ups waiting to be collected.
+func (f *Field) PowerUps() []PowerUp {
+	return f.items
+}
+
+// PaddleScale returns the factor to apply to the height of the paddle on side.
+func (f *Field) PaddleScale(side geometry.Side) float64 {
+	scale := 1.0
+
+	if f.active(Grow, side) {
+		scale *= growFactor
+	}
+
+	if f.active(Shrink, side) {
+		scale *= shrinkFactor
+	}
+
+	return scale
+}
+
+// TimeScale returns the factor to apply to the time elapsed for the balls.
+func (f *Field) TimeScale() float64 {
+	if f.active(SlowMo, geometry.Undefined) {
+		return slowMoFactor
+	}
+
+	return 1
+}
+
+// Shielded returns true if the goal on side is protected by a shield.
+func (f *Field) Shielded(side geometry.Side) bool {
+	return f.active(Shield, side)
+}
+
+// active returns true if the effect kind is active for side.
+func (f *Field) active(kind Kind, side geometry.Side) bool {
+	for _, e := range f.effects {
+		if e.kind == kind && e.side == side {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expire subtracts elapsed from the remaining time of every item and
+// returns the items whose time isn't over.
+func expire[T any](items []T, elapsed time.Duration, remaining func(*T) *time.Duration) []T {
+	kept := items[:0]
+
+	for i := range items {
+		r := remaining(&items[i])
+
+		*r -= elapsed
+		if *r > 0 {
+			kept = append(kept, items[i])
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,222 @@
+package rules
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// ServeRule represents who receives the serve after a goal.
+type ServeRule int
+
+const (
+	// ServeAlternate alternates the serve between both sides.
+	ServeAlternate ServeRule = iota
+	// ServeToLoser serves the ball towards the side that conceded the last goal.
+	ServeToLoser
+)
+
+// String returns a string representation of the serve rule.
+func (s ServeRule) String() string {
+	switch s {
+	case ServeAlternate:
+		return "Alternate"
+	case ServeToLoser:
+		return "To loser"
+	default:
+		return "Undefined"
+	}
+}
+
+// Format represents how many players take part in a match and where they play.
+type Format int
+
+const (
+	// Singles is the classic format: one player on the left and one on the right.
+	Singles Format = iota
+	// Doubles is played by two teams of two, each player defending half of the team's goal.
+	Doubles
+	// FourWay is played by four players, each one defending a side of the field.
+	FourWay
+)
+
+// String returns a string representation of the format.
+func (f Format) String() string {
+	switch f {
+	case Singles:
+		return "Singles"
+	case Doubles:
+		return "Doubles"
+	case FourWay:
+		return "Four-way"
+	default:
+		return "Undefined"
+	}
+}
+
+// Sides returns the sides that defend a goal in this format, starting with the left and right ones.
+func (f Format) Sides() []geometry.Side {
+	if f == FourWay {
+		return []geometry.Side{geometry.Left, geometry.Right, geometry.Top, geometry.Bottom}
+	}
+
+	return []geometry.Side{geometry.Left, geometry.Right}
+}
+
+// Lanes returns the number of players defending each side.
+func (f Format) Lanes() int {
+	if f == Doubles {
+		return 2
+	}
+
+	return 1
+}
+
+// Default values of the match rules.
+// Speeds are expressed in units per second.
+const (
+	DefaultScoreLimit       = 10
+	DefaultInitialBallSpeed = 120
+	DefaultMaxBallSpeed     = 480
+)
+
+// Rules represents the rules of a match.
+type Rules struct {
+	// ScoreLimit is the score a player must reach to win. Zero means no limit,
+	// which is only allowed for timed matches.
+	ScoreLimit int `json:"score_limit"`
+	// WinByTwo requires the winner to lead by two goals once the score limit is reached (deuce).
+	WinByTwo bool `json:"win_by_two"`
+	// TimeLimit is the duration of a timed match. Zero means the match isn't timed.
+	// When the time is up and the score is tied, the next goal wins (sudden death).
+	TimeLimit time.Duration `json:"time_limit"`
+	// Serve defines who receives the serve after a goal.
+	Serve ServeRule `json:"serve"`
+	// InitialBallSpeed is the speed of the ball when it's served.
+	InitialBallSpeed float64 `json:"initial_ball_speed"`
+	// MaxBallSpeed is the maximum speed the ball can reach.
+	MaxBallSpeed float64 `json:"max_ball_speed"`
+	// Arcade spawns power-ups on the field that are collected by the balls.
+	Arcade bool `json:"arcade"`
+	// Format defines the number of players and the sides they defend.
+	Format Format `json:"format"`
+}
+
+// Default returns the classic rules: first to 10 goals and alternate serve.
+func Default() Rules {
+	return Rules{
+		ScoreLimit:       DefaultScoreLimit,
+		Serve:            ServeAlternate,
+		InitialBallSpeed: DefaultInitialBallSpeed,
+		MaxBallSpeed:     DefaultMaxBallSpeed,
+	}
+}
+
+// Validate checks that the rules can produce a winner.
+func (r Rules) Validate() error {
+	if r.ScoreLimit <= 0 && r.TimeLimit <= 0 {
+		return errors.New("either a score limit or a time limit is required")
+	}
+
+	if r.InitialBallSpeed <= 0 {
+		return errors.New("initial ball speed must be positive")
+	}
+
+	if r.MaxBallSpeed < r.InitialBallSpeed {
+		return errors.New("max ball speed must not be lower than the initial ball speed")
+	}
+
+	return nil
+}
+
+// Winner returns the side of the winner given the scores of each side and the elapsed
+// time of the match. It returns geometry.Undefined while nobody has won.
+func (r Rules) Winner(scores map[geometry.Side]int, elapsed time.Duration) geometry.Side {
+	leader, lead := r.leader(scores)
+	if leader == geometry.Undefined {
+		return geometry.Undefined
+	}
+
+	if r.ScoreLimit > 0 && scores[leader] >= r.ScoreLimit && (!r.WinByTwo || lead >= 2) {
+		return leader
+	}
+
+	if r.TimeUp(elapsed) {
+		return leader
+	}
+
+	return geometry.Undefined
+}
+
+// Scorer returns the side that scores when conceded concedes a goal.
+// lastHit is the side of the last player who hit the ball. In four-way matches the point
+// goes to the last player who hit the ball and nobody scores own goals.
+func (r Rules) Scorer(conceded, lastHit geometry.Side) geometry.Side {
+	if r.Format != FourWay {
+		return conceded.Opposite()
+	}
+
+	if lastHit == conceded {
+		return geometry.Undefined
+	}
+
+	return lastHit
+}
+
+// TimeUp returns true if the match is timed and its time limit was reached.
+func (r Rules) TimeUp(elapsed time.Duration) bool {
+	return r.TimeLimit > 0 && elapsed >= r.TimeLimit
+}
+
+// SuddenDeath returns true if the time is up with a tied lead, so the next goal wins.
+func (r Rules) SuddenDeath(scores map[geometry.Side]int, elapsed time.Duration) bool {
+	leader, _ := r.leader(scores)
+
+	return r.TimeUp(elapsed) && leader == geometry.Undefined
+}
+
+// NextServe returns the side the ball is served towards after a goal.
+// previous is the side the last ball was served towards and conceded is the side
+// that conceded the goal. In four-way matches the alternate serve goes around the field.
+func (r Rules) NextServe(previous, conceded geometry.Side) geometry.Side {
+	if r.Serve == ServeToLoser {
+		return conceded
+	}
+
+	if r.Format != FourWay {
+		return previous.Opposite()
+	}
+
+	// rotate clockwise from the left
+	sides := []geometry.Side{geometry.Left, geometry.Top, geometry.Right, geometry.Bottom}
+	i := slices.Index(sides, previous)
+
+	return sides[(i+1)%len(sides)]
+}
+
+// leader returns the side with the highest score and its lead over the second best.
+// It returns geometry.Undefined when the highest score is tied.
+func (r Rules) leader(scores map[geometry.Side]int) (geometry.Side, int) {
+	var (
+		leader geometry.Side
+		best   = -1
+		second = -1
+	)
+
+	for _, side := range r.Format.Sides() {
+		switch score := scores[side]; {
+		case score > best:
+			leader, best, second = side, score, best
+		case score > second:
+			second = score
+		}
+	}
+
+	if best == second {
+		return geometry.Undefined, 0
+	}
+
+	return leader, best - second
+}
@@ -0,0 +1,17 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/engine/rules"
+)
+
+func TestString_OutOfRange(t *testing.T) {
+	if got := rules.Format(-1).String(); got != "Undefined" {
+		t.Errorf("format = %q, want Undefined", got)
+	}
+
+	if got := rules.ServeRule(42).String(); got != "Undefined" {
+		t.Errorf("serve rule = %q, want Undefined", got)
+	}
+}
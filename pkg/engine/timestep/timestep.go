@@ -0,0 +1,85 @@
+package timestep
+
+import "time"
+
+const (
+	// TickRate is the default number of simulation ticks per second.
+	TickRate = 120
+	// maxFrameTime caps the time consumed by a single call to Advance, so a long
+	// stall (window dragged, breakpoint, tab in background) doesn't trigger a burst of ticks.
+	maxFrameTime = 250 * time.Millisecond
+)
+
+// Timestep steps a simulation in fixed ticks, independently of the frame rate.
+// Elapsed time is accumulated and consumed in ticks of the same duration.
+type Timestep struct {
+	accumulator time.Duration
+	last        time.Time
+	step        time.Duration
+	now         func() time.Time
+}
+
+// New creates a new timestep running at tickRate ticks per second.
+func New(tickRate int) *Timestep {
+	return &Timestep{
+		step: time.Second / time.Duration(tickRate),
+		now:  time.Now,
+	}
+}
+
+// Advance accumulates the time elapsed since the previous call and returns how many
+// ticks must be simulated to catch up. The first call never returns any tick.
+func (t *Timestep) Advance() int {
+	now := t.now()
+
+	if t.last.IsZero() {
+		t.last = now
+		return 0
+	}
+
+	elapsed := min(now.Sub(t.last), maxFrameTime)
+	t.last = now
+	t.accumulator += elapsed
+
+	ticks := int(t.accumulator / t.step)
+	t.accumulator -= time.Duration(ticks) * t.step
+
+	return ticks
+}
+
+// Alpha returns how far, between 0 and 1, the current time is between the last
+// simulated tick and the next one. It's used to interpolate rendering.
+func (t *Timestep) Alpha() float64 {
+	return float64(t.accumulator) / float64(t.step)
+}
+
+// Delta returns the duration of a tick in seconds.
+func (t *Timestep) Delta() float64 {
+	return t.step.Seconds()
+}
+
+// Reset discards the accumulated time, e.g. after the game was paused.
+func (t *Timestep) Reset() {
+	t.accumulator = 0
+	t.last = time.Time{}
+}
+
+// Clock measures the real time elapsed between frames, whatever the frame rate, e.g. for
+// the effects fading out in real time.
+type Clock struct {
+	last time.Time
+}
+
+// Delta returns the seconds elapsed between the previous call and now, capped like the time
+// consumed by Advance. The first call, and a clock going backwards, return 0.
+func (c *Clock) Delta(now time.Time) float64 {
+	if c.last.IsZero() {
+		c.last = now
+		return 0
+	}
+
+	elapsed := min(max(now.Sub(c.last), 0), maxFrameTime)
+	c.last = now
+
+	return elapsed.Seconds()
+}
@@ -0,0 +1,134 @@
+package timestep
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock advanced by the tests.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// newTimestep returns a timestep at 100 ticks per second, 10ms each, driven by a fake clock.
+func newTimestep() (*Timestep, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC)}
+
+	t := New(100)
+	t.now = func() time.Time { return clock.now }
+
+	return t, clock
+}
+
+func TestTimestep_Advance_SteadyTicks(t *testing.T) {
+	ts, clock := newTimestep()
+
+	if ticks := ts.Advance(); ticks != 0 {
+		t.Fatalf("first advance = %d ticks, want 0", ticks)
+	}
+
+	// one tick a frame at the tick rate, two at half of it
+	for _, frame := range []time.Duration{10 * time.Millisecond, 20 * time.Millisecond} {
+		want := int(frame / (10 * time.Millisecond))
+
+		for i := range 100 {
+			clock.advance(frame)
+
+			if ticks := ts.Advance(); ticks != want {
+				t.Fatalf("frame %d of %v: %d ticks, want %d", i, frame, ticks, want)
+			}
+		}
+	}
+
+	if ts.Delta() != 0.01 {
+		t.Errorf("delta = %v, want 0.01", ts.Delta())
+	}
+}
+
+func TestTimestep_Advance_Stall(t *testing.T) {
+	ts, clock := newTimestep()
+	ts.Advance()
+
+	// a stall only catches up on maxFrameTime
+	clock.advance(5 * time.Second)
+
+	if ticks := ts.Advance(); ticks != 25 {
+		t.Errorf("%d ticks after a stall, want 25", ticks)
+	}
+
+	clock.advance(10 * time.Millisecond)
+
+	if ticks := ts.Advance(); ticks != 1 {
+		t.Errorf("%d ticks after the stall, want 1", ticks)
+	}
+
+	// nothing is caught up after a reset
+	clock.advance(time.Second)
+	ts.Reset()
+
+	if ticks := ts.Advance(); ticks != 0 || ts.Alpha() != 0 {
+		t.Errorf("%d ticks and alpha %v after a reset, want none", ticks, ts.Alpha())
+	}
+}
+
+func TestTimestep_Advance_PartialTick(t *testing.T) {
+	ts, clock := newTimestep()
+	ts.Advance()
+
+	tests := []struct {
+		frame time.Duration
+		ticks int
+		alpha float64
+	}{
+		{frame: 4 * time.Millisecond, ticks: 0, alpha: 0.4},
+		{frame: 4 * time.Millisecond, ticks: 0, alpha: 0.8},
+		// the time left over is kept for the next tick
+		{frame: 4 * time.Millisecond, ticks: 1, alpha: 0.2},
+		{frame: 25 * time.Millisecond, ticks: 2, alpha: 0.7},
+		{frame: 3 * time.Millisecond, ticks: 1, alpha: 0},
+	}
+
+	for i, test := range tests {
+		clock.advance(test.frame)
+
+		if ticks := ts.Advance(); ticks != test.ticks {
+			t.Errorf("frame %d: %d ticks, want %d", i, ticks, test.ticks)
+		}
+
+		if math.Abs(ts.Alpha()-test.alpha) > 1e-9 {
+			t.Errorf("frame %d: alpha = %v, want %v", i, ts.Alpha(), test.alpha)
+		}
+	}
+}
+
+func TestClock_Delta(t *testing.T) {
+	var c Clock
+
+	now := time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		elapsed time.Duration
+		want    float64
+	}{
+		// the first call has nothing to measure
+		{elapsed: 0, want: 0},
+		{elapsed: 16 * time.Millisecond, want: 0.016},
+		{elapsed: 50 * time.Millisecond, want: 0.05},
+		// a stall is capped and a clock going backwards measures nothing
+		{elapsed: 3 * time.Second, want: 0.25},
+		{elapsed: -time.Second, want: 0},
+	}
+
+	for i, test := range tests {
+		now = now.Add(test.elapsed)
+
+		if got := c.Delta(now); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("call %d: delta = %v, want %v", i, got, test.want)
+		}
+	}
+}
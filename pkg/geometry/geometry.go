@@ -1,6 +1,9 @@
 package geometry
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 type (
 	// Rect represents a rectangle.
@@ -19,6 +22,14 @@ type (
 		X float64
 		Y float64
 	}
+
+	// Hit represents the first contact of a moving rectangle against another one.
+	Hit struct {
+		// Time is the fraction of the movement, between 0 and 1, at which the contact happens.
+		Time float64
+		// Normal is the unit normal of the surface that was hit.
+		Normal Vector
+	}
 )
 
 const (
@@ -28,8 +39,49 @@ const (
 	Right
 	// Left is the left side of the board.
 	Left
+	// Top is the top side of the board.
+	Top
+	// Bottom is the bottom side of the board.
+	Bottom
 )
 
+// Opposite returns the opposite side of the board.
+func (s Side) Opposite() Side {
+	switch s {
+	case Left:
+		return Right
+	case Right:
+		return Left
+	case Top:
+		return Bottom
+	case Bottom:
+		return Top
+	default:
+		return Undefined
+	}
+}
+
+// Horizontal returns true for the top and bottom sides, where paddles move horizontally.
+func (s Side) Horizontal() bool {
+	return s == Top || s == Bottom
+}
+
+// String returns a string representation of the side.
+func (s Side) String() string {
+	switch s {
+	case Left:
+		return "Left"
+	case Right:
+		return "Right"
+	case Top:
+		return "Top"
+	case Bottom:
+		return "Bottom"
+	default:
+		return "Undefined"
+	}
+}
+
 // MaxX returns the maximum X value of the rectangle.
 func (r Rect) MaxX() float64 {
 	return r.X + r.Width
@@ -40,6 +92,11 @@ func (r Rect) MaxY() float64 {
 	return r.Y + r.Height
 }
 
+// Contains returns true if the point is inside the rectangle.
+func (r Rect) Contains(p Vector) bool {
+	return p.X >= r.X && p.X < r.MaxX() && p.Y >= r.Y && p.Y < r.MaxY()
+}
+
 // Intersects returns true if the rectangle intersects with another rectangle.
 func (r Rect) Intersects(other Rect) bool {
 	return r.X <= other.MaxX() &&
@@ -48,11 +105,123 @@ func (r Rect) Intersects(other Rect) bool {
 		other.Y <= r.MaxY()
 }
 
+// Overlaps returns true if the rectangle overlaps with another rectangle.
+// Unlike Intersects, rectangles only touching each other are not considered overlapping.
+func (r Rect) Overlaps(other Rect) bool {
+	return r.X < other.MaxX() &&
+		other.X < r.MaxX() &&
+		r.Y < other.MaxY() &&
+		other.Y < r.MaxY()
+}
+
+// Sweep moves the rectangle by velocity and returns the first contact against the static
+// rectangle other. It returns false if there is no contact during the movement.
+// If both rectangles already overlap, the contact happens at time 0 and the normal points
+// to the side with the smallest penetration.
+func (r Rect) Sweep(velocity Vector, other Rect) (Hit, bool) {
+	if hit, ok := r.Penetration(other); ok {
+		return hit, true
+	}
+
+	entryX, exitX := sweepAxis(r.X, r.MaxX(), other.X, other.MaxX(), velocity.X)
+	entryY, exitY := sweepAxis(r.Y, r.MaxY(), other.Y, other.MaxY(), velocity.Y)
+
+	entry := math.Max(entryX, entryY)
+	exit := math.Min(exitX, exitY)
+
+	if entry > exit || entry < 0 || entry > 1 {
+		return Hit{}, false
+	}
+
+	var normal Vector
+
+	if entryX > entryY {
+		normal.X = -math.Copysign(1, velocity.X)
+	} else {
+		normal.Y = -math.Copysign(1, velocity.Y)
+	}
+
+	return Hit{Time: entry, Normal: normal}, true
+}
+
+// Penetration returns a contact at time 0 pointing out of other through the side where
+// the rectangle penetrates the least. It returns false if both rectangles don't overlap.
+func (r Rect) Penetration(other Rect) (Hit, bool) {
+	if !r.Overlaps(other) {
+		return Hit{}, false
+	}
+
+	left := r.MaxX() - other.X
+	right := other.MaxX() - r.X
+	top := r.MaxY() - other.Y
+	bottom := other.MaxY() - r.Y
+
+	depth := math.Min(math.Min(left, right), math.Min(top, bottom))
+
+	switch depth {
+	case left:
+		return Hit{Normal: Vector{X: -1}}, true
+	case right:
+		return Hit{Normal: Vector{X: 1}}, true
+	case top:
+		return Hit{Normal: Vector{Y: -1}}, true
+	default:
+		return Hit{Normal: Vector{Y: 1}}, true
+	}
+}
+
+// sweepAxis returns the entry and exit times of a segment [minA, maxA] moving by speed
+// against the static segment [minB, maxB].
+func sweepAxis(minA, maxA, minB, maxB, speed float64) (float64, float64) {
+	if speed == 0 {
+		if minA < maxB && minB < maxA {
+			return math.Inf(-1), math.Inf(1)
+		}
+
+		return math.Inf(1), math.Inf(-1)
+	}
+
+	if speed > 0 {
+		return (minB - maxA) / speed, (maxB - minA) / speed
+	}
+
+	return (maxB - minA) / speed, (minB - maxA) / speed
+}
+
+// Center returns the center of the rectangle.
+func (r Rect) Center() Vector {
+	return Vector{X: r.X + r.Width/2, Y: r.Y + r.Height/2}
+}
+
 // String returns a string representation of the rectangle.
 func (r Rect) String() string {
 	return fmt.Sprintf("x:%.f-y:%.f - w:%.f-h:%.f", r.X, r.Y, r.Width, r.Height)
 }
 
+// Add returns the sum of the vector and another vector.
+func (v Vector) Add(other Vector) Vector {
+	return Vector{X: v.X + other.X, Y: v.Y + other.Y}
+}
+
+// Lerp returns the linear interpolation between the vector and another vector.
+// t is the interpolation factor, 0 returns the vector and 1 returns other.
+func (v Vector) Lerp(other Vector, t float64) Vector {
+	return Vector{
+		X: v.X + (other.X-v.X)*t,
+		Y: v.Y + (other.Y-v.Y)*t,
+	}
+}
+
+// Scale returns the vector multiplied by factor.
+func (v Vector) Scale(factor float64) Vector {
+	return Vector{X: v.X * factor, Y: v.Y * factor}
+}
+
+// Distance returns the distance between the vector and another vector.
+func (v Vector) Distance(other Vector) float64 {
+	return math.Hypot(other.X-v.X, other.Y-v.Y)
+}
+
 // String returns a string representation of the vector.
 func (v Vector) String() string {
 	return fmt.Sprintf("%.f:%.f", v.X, v.Y)
@@ -0,0 +1,198 @@
+package geometry_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/geometry"
+)
+
+// epsilon absorbs the rounding of contact times.
+const epsilon = 1e-9
+
+func TestRect_Sweep(t *testing.T) {
+	tests := map[string]struct {
+		rect     geometry.Rect
+		velocity geometry.Vector
+		other    geometry.Rect
+		hit      bool
+		time     float64
+		normal   geometry.Vector
+	}{
+		"head on": {
+			rect:     geometry.Rect{Width: 10, Height: 10},
+			velocity: geometry.Vector{X: 20},
+			other:    geometry.Rect{X: 25, Width: 10, Height: 10},
+			hit:      true,
+			time:     0.75,
+			normal:   geometry.Vector{X: -1},
+		},
+		"from below": {
+			rect:     geometry.Rect{Y: 40, Width: 10, Height: 10},
+			velocity: geometry.Vector{Y: -40},
+			other:    geometry.Rect{Width: 10, Height: 20},
+			hit:      true,
+			time:     0.5,
+			normal:   geometry.Vector{Y: 1},
+		},
+		"high speed through a thin rect": {
+			rect:     geometry.Rect{Width: 10, Height: 10},
+			velocity: geometry.Vector{X: 10000},
+			other:    geometry.Rect{X: 500, Width: 1, Height: 10},
+			hit:      true,
+			time:     0.049,
+			normal:   geometry.Vector{X: -1},
+		},
+		"corner graze": {
+			rect:     geometry.Rect{Width: 10, Height: 10},
+			velocity: geometry.Vector{X: 20, Y: 19},
+			other:    geometry.Rect{X: 20, Y: 20, Width: 10, Height: 10},
+			hit:      true,
+			time:     10.0 / 19,
+			normal:   geometry.Vector{Y: -1},
+		},
+		"corner miss": {
+			rect:     geometry.Rect{Width: 10, Height: 10},
+			velocity: geometry.Vector{X: 20, Y: 5},
+			other:    geometry.Rect{X: 20, Y: 20, Width: 10, Height: 10},
+		},
+		"sliding along an edge": {
+			rect:     geometry.Rect{Width: 10, Height: 10},
+			velocity: geometry.Vector{X: 50},
+			other:    geometry.Rect{X: 20, Y: 10, Width: 10, Height: 10},
+		},
+		"moving away": {
+			rect:     geometry.Rect{X: 40, Width: 10, Height: 10},
+			velocity: geometry.Vector{X: 20},
+			other:    geometry.Rect{X: 25, Width: 10, Height: 10},
+		},
+		"too far": {
+			rect:     geometry.Rect{Width: 10, Height: 10},
+			velocity: geometry.Vector{X: 10},
+			other:    geometry.Rect{X: 25, Width: 10, Height: 10},
+		},
+		"already overlapping": {
+			rect:     geometry.Rect{X: 17, Width: 10, Height: 10},
+			velocity: geometry.Vector{X: -5},
+			other:    geometry.Rect{X: 25, Width: 10, Height: 10},
+			hit:      true,
+			normal:   geometry.Vector{X: -1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hit, ok := test.rect.Sweep(test.velocity, test.other)
+			if ok != test.hit {
+				t.Fatalf("contact = %t, want %t", ok, test.hit)
+			}
+
+			if !ok {
+				return
+			}
+
+			if math.Abs(hit.Time-test.time) > epsilon {
+				t.Errorf("time = %v, want %v", hit.Time, test.time)
+			}
+
+			if hit.Normal != test.normal {
+				t.Errorf("normal = %v, want %v", hit.Normal, test.normal)
+			}
+		})
+	}
+}
+
+func TestRect_Penetration(t *testing.T) {
+	other := geometry.Rect{X: 20, Y: 20, Width: 10, Height: 50}
+
+	tests := map[string]struct {
+		rect    geometry.Rect
+		overlap bool
+		normal  geometry.Vector
+	}{
+		"left":     {rect: geometry.Rect{X: 12, Y: 40, Width: 10, Height: 10}, overlap: true, normal: geometry.Vector{X: -1}},
+		"right":    {rect: geometry.Rect{X: 28, Y: 40, Width: 10, Height: 10}, overlap: true, normal: geometry.Vector{X: 1}},
+		"top":      {rect: geometry.Rect{X: 20, Y: 13, Width: 10, Height: 10}, overlap: true, normal: geometry.Vector{Y: -1}},
+		"bottom":   {rect: geometry.Rect{X: 20, Y: 67, Width: 10, Height: 10}, overlap: true, normal: geometry.Vector{Y: 1}},
+		"corner":   {rect: geometry.Rect{X: 12, Y: 15, Width: 10, Height: 10}, overlap: true, normal: geometry.Vector{X: -1}},
+		"touching": {rect: geometry.Rect{X: 10, Y: 40, Width: 10, Height: 10}},
+		"apart":    {rect: geometry.Rect{X: 50, Y: 40, Width: 10, Height: 10}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hit, ok := test.rect.Penetration(other)
+			if ok != test.overlap {
+				t.Fatalf("overlap = %t, want %t", ok, test.overlap)
+			}
+
+			if ok && hit.Normal != test.normal {
+				t.Errorf("normal = %v, want %v", hit.Normal, test.normal)
+			}
+
+			if ok && hit.Time != 0 {
+				t.Errorf("time = %v, want 0", hit.Time)
+			}
+		})
+	}
+}
+
+// TestRect_Sweep_Random checks that a rectangle stopped at the contact never overlaps the other one,
+// and that a movement without contact never goes through it, whatever the speed.
+func TestRect_Sweep_Random(t *testing.T) {
+	rng := rand.New(rand.NewPCG(26, 2024)) // nolint:gosec
+
+	for i := range 20000 {
+		rect := geometry.Rect{X: rng.Float64() * 600, Y: rng.Float64() * 440, Width: 10, Height: 10}
+		other := geometry.Rect{
+			X:      rng.Float64() * 600,
+			Y:      rng.Float64() * 440,
+			Width:  1 + rng.Float64()*20,
+			Height: 1 + rng.Float64()*80,
+		}
+		velocity := geometry.Vector{X: (2*rng.Float64() - 1) * 5000, Y: (2*rng.Float64() - 1) * 5000}
+
+		if rect.Overlaps(other) {
+			continue
+		}
+
+		hit, ok := rect.Sweep(velocity, other)
+		if ok {
+			if hit.Time < 0 || hit.Time > 1 {
+				t.Fatalf("#%d: time %v out of [0, 1]", i, hit.Time)
+			}
+
+			if moved := shrink(move(rect, velocity, hit.Time)); moved.Overlaps(other) {
+				t.Fatalf("#%d: %v moving by %v overlaps %v at the contact", i, rect, velocity, other)
+			}
+
+			if velocity.X*hit.Normal.X+velocity.Y*hit.Normal.Y >= 0 {
+				t.Fatalf("#%d: normal %v doesn't face the velocity %v", i, hit.Normal, velocity)
+			}
+
+			continue
+		}
+
+		for step := range 1001 {
+			if moved := shrink(move(rect, velocity, float64(step)/1000)); moved.Overlaps(other) {
+				t.Fatalf("#%d: %v moving by %v goes through %v without contact", i, rect, velocity, other)
+			}
+		}
+	}
+}
+
+// move returns the rectangle moved by the fraction t of velocity.
+func move(r geometry.Rect, velocity geometry.Vector, t float64) geometry.Rect {
+	r.X += velocity.X * t
+	r.Y += velocity.Y * t
+
+	return r
+}
+
+// shrink returns the rectangle reduced by a rounding error on every side.
+func shrink(r geometry.Rect) geometry.Rect {
+	const margin = 1e-6
+
+	return geometry.Rect{X: r.X + margin, Y: r.Y + margin, Width: r.Width - 2*margin, Height: r.Height - 2*margin}
+}
@@ -0,0 +1,121 @@
+// Package matchmaking pairs the players waiting for an online match, preferring opponents with
+// close ratings and accepting wider differences the longer a player waits.
+package matchmaking
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/rating"
+)
+
+const (
+	// BaseWindow is the largest rating difference accepted for a player who just joined the queue.
+	BaseWindow = 100.0
+	// WindowGrowth is how much the accepted difference grows every second in the queue.
+	WindowGrowth = 10.0
+	// MaxWait is how long a player waits before being paired with anyone.
+	MaxWait = 60 * time.Second
+)
+
+type (
+	// Queue holds the players waiting for a match, identified by keys of type K.
+	// It isn't safe for concurrent use.
+	Queue[K comparable] struct {
+		entries []entry[K]
+	}
+
+	// entry is a player waiting in the queue.
+	entry[K comparable] struct {
+		player K
+		rating rating.Rating
+		joined time.Time
+	}
+
+	// Pair is two players paired for a match.
+	Pair[K comparable] struct {
+		First, Second K
+	}
+)
+
+// Join adds a player to the queue, or updates their rating if they're already in it.
+func (q *Queue[K]) Join(player K, r rating.Rating, now time.Time) {
+	if i := q.index(player); i >= 0 {
+		q.entries[i].rating = r
+		return
+	}
+
+	q.entries = append(q.entries, entry[K]{player: player, rating: r, joined: now})
+}
+
+// Leave removes a player from the queue, e.g. when they disconnect.
+func (q *Queue[K]) Leave(player K) {
+	if i := q.index(player); i >= 0 {
+		q.entries = slices.Delete(q.entries, i, i+1)
+	}
+}
+
+// Len returns the number of players waiting.
+func (q *Queue[K]) Len() int {
+	return len(q.entries)
+}
+
+// Pairs removes from the queue and returns the players that can be paired now. The players who
+// waited the longest are paired first, each with the closest rating their window accepts.
+func (q *Queue[K]) Pairs(now time.Time) []Pair[K] {
+	var pairs []Pair[K]
+
+	// entries are kept in the order players joined, the longest waiting first
+	for i := 0; i < len(q.entries); {
+		first := q.entries[i]
+		best, bestDiff := -1, math.Inf(1)
+
+		for j := i + 1; j < len(q.entries); j++ {
+			second := q.entries[j]
+			diff := math.Abs(first.rating.Rating - second.rating.Rating)
+
+			// the player waiting the longest widens the window for both
+			if diff <= Window(now.Sub(first.joined)) && diff < bestDiff {
+				best, bestDiff = j, diff
+			}
+		}
+
+		if best < 0 {
+			i++
+			continue
+		}
+
+		pairs = append(pairs, Pair[K]{First: first.player, Second: q.entries[best].player})
+
+		q.entries = slices.Delete(q.entries, best, best+1)
+		q.entries = slices.Delete(q.entries, i, i+1)
+	}
+
+	return pairs
+}
+
+// Wait returns how long the player has been waiting, zero if they aren't in the queue.
+func (q *Queue[K]) Wait(player K, now time.Time) time.Duration {
+	i := q.index(player)
+	if i < 0 {
+		return 0
+	}
+
+	return now.Sub(q.entries[i].joined)
+}
+
+// index returns the index of the player in the queue, -1 if they aren't in it.
+func (q *Queue[K]) index(player K) int {
+	return slices.IndexFunc(q.entries, func(e entry[K]) bool { return e.player == player })
+}
+
+// Window returns the largest rating difference accepted for a player who waited for the given time.
+// Once they waited for MaxWait, any difference is accepted.
+func Window(wait time.Duration) float64 {
+	if wait >= MaxWait {
+		return math.Inf(1)
+	}
+
+	return BaseWindow + WindowGrowth*max(wait.Seconds(), 0)
+}
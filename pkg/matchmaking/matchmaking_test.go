@@ -0,0 +1,174 @@
+package matchmaking_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/matchmaking"
+	"github.com/gandarez/pong-multiplayer-go/pkg/rating"
+)
+
+// start is when the first player joins the queue in the tests.
+var start = time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC) // nolint:gochecknoglobals
+
+// rated returns the rating of a player with the given rating and the default deviation.
+func rated(r float64) rating.Rating {
+	return rating.Rating{Rating: r, Deviation: rating.DefaultDeviation, Volatility: rating.DefaultVolatility}
+}
+
+func TestWindow(t *testing.T) {
+	tests := map[string]struct {
+		wait time.Duration
+		want float64
+	}{
+		"just joined":      {wait: 0, want: 100},
+		"clock went back":  {wait: -time.Second, want: 100},
+		"half a second":    {wait: 500 * time.Millisecond, want: 105},
+		"ten seconds":      {wait: 10 * time.Second, want: 200},
+		"almost a minute":  {wait: 59 * time.Second, want: 690},
+		"a minute":         {wait: matchmaking.MaxWait, want: math.Inf(1)},
+		"more than enough": {wait: time.Hour, want: math.Inf(1)},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := matchmaking.Window(test.wait); got != test.want {
+				t.Errorf("window = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestQueue_Pairs_WindowWidens(t *testing.T) {
+	var q matchmaking.Queue[string]
+
+	q.Join("ada", rated(1500), start)
+	q.Join("bob", rated(1750), start)
+
+	// 250 points apart: paired once the window grew by 150 points
+	for _, wait := range []time.Duration{0, 5 * time.Second, 14 * time.Second} {
+		if pairs := q.Pairs(start.Add(wait)); len(pairs) != 0 {
+			t.Fatalf("after %v: pairs = %v, want none", wait, pairs)
+		}
+	}
+
+	pairs := q.Pairs(start.Add(15 * time.Second))
+	if want := []matchmaking.Pair[string]{{First: "ada", Second: "bob"}}; !slices.Equal(pairs, want) {
+		t.Fatalf("pairs = %v, want %v", pairs, want)
+	}
+
+	if q.Len() != 0 {
+		t.Errorf("%d players left in the queue, want none", q.Len())
+	}
+}
+
+func TestQueue_Pairs_MaxWait(t *testing.T) {
+	var q matchmaking.Queue[string]
+
+	q.Join("ada", rated(900), start)
+	q.Join("bob", rated(2600), start.Add(30*time.Second))
+
+	if pairs := q.Pairs(start.Add(matchmaking.MaxWait - time.Second)); len(pairs) != 0 {
+		t.Fatalf("pairs = %v, want none before the max wait", pairs)
+	}
+
+	// the window of the player waiting the longest is the one used
+	pairs := q.Pairs(start.Add(matchmaking.MaxWait))
+	if want := []matchmaking.Pair[string]{{First: "ada", Second: "bob"}}; !slices.Equal(pairs, want) {
+		t.Errorf("pairs = %v, want %v", pairs, want)
+	}
+}
+
+func TestQueue_Pairs_Order(t *testing.T) {
+	var q matchmaking.Queue[string]
+
+	// joined in this order, a second apart
+	players := []struct {
+		name   string
+		rating float64
+	}{
+		{name: "ada", rating: 1000},
+		{name: "bob", rating: 1500},
+		{name: "cyd", rating: 1580},
+		{name: "dan", rating: 1530},
+		{name: "eve", rating: 1560},
+		{name: "fay", rating: 1540},
+	}
+
+	for i, p := range players {
+		q.Join(p.name, rated(p.rating), start.Add(time.Duration(i)*time.Second))
+	}
+
+	pairs := q.Pairs(start.Add(5 * time.Second))
+
+	// ada is too far from everyone, bob waited the longest after her and gets the closest rating,
+	// then cyd, left with the closest of the others
+	want := []matchmaking.Pair[string]{
+		{First: "bob", Second: "dan"},
+		{First: "cyd", Second: "eve"},
+	}
+	if !slices.Equal(pairs, want) {
+		t.Fatalf("pairs = %v, want %v", pairs, want)
+	}
+
+	if q.Len() != 2 || q.Wait("ada", start.Add(5*time.Second)) != 5*time.Second {
+		t.Errorf("queue left with %d players, want ada and fay still waiting", q.Len())
+	}
+}
+
+func TestQueue_Pairs_ClosestInWindow(t *testing.T) {
+	var q matchmaking.Queue[string]
+
+	q.Join("ada", rated(1500), start)
+	q.Join("bob", rated(1590), start)
+	q.Join("cyd", rated(1420), start)
+	q.Join("dan", rated(1450), start)
+
+	pairs := q.Pairs(start)
+
+	want := []matchmaking.Pair[string]{
+		{First: "ada", Second: "dan"},
+	}
+	if !slices.Equal(pairs, want) {
+		t.Fatalf("pairs = %v, want %v", pairs, want)
+	}
+
+	// bob and cyd are 170 points apart, left for later
+	if q.Len() != 2 {
+		t.Errorf("%d players left in the queue, want 2", q.Len())
+	}
+}
+
+func TestQueue_JoinAndLeave(t *testing.T) {
+	var q matchmaking.Queue[string]
+
+	q.Join("ada", rated(1500), start)
+	q.Join("bob", rated(2000), start.Add(time.Second))
+
+	// joining again updates the rating but keeps the wait
+	q.Join("bob", rated(1550), start.Add(10*time.Second))
+
+	if q.Len() != 2 {
+		t.Fatalf("%d players in the queue, want 2", q.Len())
+	}
+
+	if wait := q.Wait("bob", start.Add(10*time.Second)); wait != 9*time.Second {
+		t.Errorf("wait = %v, want 9s", wait)
+	}
+
+	q.Leave("ada")
+	q.Leave("cyd")
+
+	if q.Wait("ada", start.Add(10*time.Second)) != 0 || q.Len() != 1 {
+		t.Fatalf("ada is still in the queue")
+	}
+
+	q.Join("ada", rated(1500), start.Add(10*time.Second))
+
+	pairs := q.Pairs(start.Add(10 * time.Second))
+	if want := []matchmaking.Pair[string]{{First: "bob", Second: "ada"}}; !slices.Equal(pairs, want) {
+		t.Errorf("pairs = %v, want %v", pairs, want)
+	}
+}
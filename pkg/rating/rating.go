@@ -0,0 +1,161 @@
+// Package rating rates the skill of online players with the Glicko-2 system: each player has a
+// rating, how uncertain it is and how consistent their results are, all updated after each match.
+// See http://www.glicko.net/glicko/glicko2.pdf.
+package rating
+
+import "math"
+
+const (
+	// DefaultRating, DefaultDeviation and DefaultVolatility rate a new player.
+	DefaultRating     = 1500.0
+	DefaultDeviation  = 350.0
+	DefaultVolatility = 0.06
+
+	// tau constrains how much the volatility can change after a match.
+	tau = 0.5
+	// scale converts ratings to and from the Glicko-2 scale.
+	scale = 173.7178
+	// epsilon is the precision the volatility is computed to.
+	epsilon = 0.000001
+)
+
+type (
+	// Rating is the skill of a player.
+	Rating struct {
+		// Rating is the skill of the player, 1500 being the skill of a new player.
+		Rating float64 `json:"rating"`
+		// Deviation is how uncertain the rating is: the actual skill of the player is within
+		// twice the deviation of their rating with 95% confidence.
+		Deviation float64 `json:"deviation"`
+		// Volatility is how much the skill of the player is expected to change.
+		Volatility float64 `json:"volatility"`
+	}
+
+	// Result is the result of a match against an opponent.
+	Result struct {
+		Opponent Rating
+		// Score is 1 for a win, 0 for a loss and 0.5 for a draw.
+		Score float64
+	}
+)
+
+// Default returns the rating of a new player.
+func Default() Rating {
+	return Rating{Rating: DefaultRating, Deviation: DefaultDeviation, Volatility: DefaultVolatility}
+}
+
+// IsZero returns true for a rating that was never set, e.g. when loading accounts
+// saved before they had ratings.
+func (r Rating) IsZero() bool {
+	return r == Rating{}
+}
+
+// Expected returns the expected score of the player against the opponent, between 0 and 1.
+func (r Rating) Expected(opponent Rating) float64 {
+	mu, _ := r.glicko2()
+	muOpponent, phiOpponent := opponent.glicko2()
+
+	return expected(mu, muOpponent, phiOpponent)
+}
+
+// Update returns the rating of the player after the results of a rating period, e.g. a match
+// against one or more opponents. Without results the deviation grows, the rating of a player
+// who doesn't play getting more uncertain.
+func (r Rating) Update(results []Result) Rating {
+	mu, phi := r.glicko2()
+
+	if len(results) == 0 {
+		return fromGlicko2(mu, math.Sqrt(phi*phi+r.Volatility*r.Volatility), r.Volatility)
+	}
+
+	// the estimated variance of the rating based on the results, and the improvement
+	var variance, improvement float64
+
+	for _, res := range results {
+		muOpponent, phiOpponent := res.Opponent.glicko2()
+		g := g(phiOpponent)
+		e := expected(mu, muOpponent, phiOpponent)
+
+		variance += g * g * e * (1 - e)
+		improvement += g * (res.Score - e)
+	}
+
+	variance = 1 / variance
+	delta := variance * improvement
+
+	volatility := r.volatility(phi, variance, delta)
+
+	phiStar := math.Sqrt(phi*phi + volatility*volatility)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/variance)
+	newMu := mu + newPhi*newPhi*improvement
+
+	return fromGlicko2(newMu, newPhi, volatility)
+}
+
+// volatility returns the new volatility of the player, step 5 of the Glicko-2 paper,
+// using the Illinois algorithm.
+func (r Rating) volatility(phi, variance, delta float64) float64 {
+	a := math.Log(r.Volatility * r.Volatility)
+
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + variance + ex
+
+		return ex*(delta*delta-d)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	lower, upper := a, 0.0
+
+	if delta*delta > phi*phi+variance {
+		upper = math.Log(delta*delta - phi*phi - variance)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+
+		upper = a - k*tau
+	}
+
+	fLower, fUpper := f(lower), f(upper)
+
+	for math.Abs(upper-lower) > epsilon {
+		c := lower + (lower-upper)*fLower/(fUpper-fLower)
+		fc := f(c)
+
+		if fc*fUpper < 0 {
+			lower, fLower = upper, fUpper
+		} else {
+			fLower /= 2
+		}
+
+		upper, fUpper = c, fc
+	}
+
+	return math.Exp(lower / 2)
+}
+
+// glicko2 returns the rating and deviation converted to the Glicko-2 scale.
+func (r Rating) glicko2() (float64, float64) {
+	return (r.Rating - DefaultRating) / scale, r.Deviation / scale
+}
+
+// fromGlicko2 returns the rating from values on the Glicko-2 scale, the deviation
+// never growing beyond the one of a new player.
+func fromGlicko2(mu, phi, volatility float64) Rating {
+	return Rating{
+		Rating:     mu*scale + DefaultRating,
+		Deviation:  min(phi*scale, DefaultDeviation),
+		Volatility: volatility,
+	}
+}
+
+// g reduces the impact of a result against an opponent whose rating is uncertain.
+func g(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// expected returns the expected score against an opponent on the Glicko-2 scale.
+func expected(mu, muOpponent, phiOpponent float64) float64 {
+	return 1 / (1 + math.Exp(-g(phiOpponent)*(mu-muOpponent)))
+}
@@ -0,0 +1,83 @@
+package rating_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/rating"
+)
+
+// TestRating_Update_Paper checks the example of the Glicko-2 paper.
+func TestRating_Update_Paper(t *testing.T) {
+	player := rating.Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+
+	updated := player.Update([]rating.Result{
+		{Opponent: rating.Rating{Rating: 1400, Deviation: 30, Volatility: 0.06}, Score: 1},
+		{Opponent: rating.Rating{Rating: 1550, Deviation: 100, Volatility: 0.06}, Score: 0},
+		{Opponent: rating.Rating{Rating: 1700, Deviation: 300, Volatility: 0.06}, Score: 0},
+	})
+
+	if math.Abs(updated.Rating-1464.06) > 0.01 {
+		t.Errorf("rating = %v, want 1464.06", updated.Rating)
+	}
+
+	if math.Abs(updated.Deviation-151.52) > 0.01 {
+		t.Errorf("deviation = %v, want 151.52", updated.Deviation)
+	}
+
+	if math.Abs(updated.Volatility-0.05999) > 0.00001 {
+		t.Errorf("volatility = %v, want 0.05999", updated.Volatility)
+	}
+}
+
+func TestRating_Update_NoResults(t *testing.T) {
+	player := rating.Rating{Rating: 1620, Deviation: 80, Volatility: 0.06}
+
+	updated := player.Update(nil)
+
+	if updated.Rating != player.Rating || updated.Volatility != player.Volatility {
+		t.Errorf("rating = %+v, want only the deviation to change", updated)
+	}
+
+	// the deviation grows by the volatility on the Glicko-2 scale
+	want := math.Sqrt(80*80 + 0.06*0.06*173.7178*173.7178)
+	if math.Abs(updated.Deviation-want) > 0.001 {
+		t.Errorf("deviation = %v, want %v", updated.Deviation, want)
+	}
+
+	// never beyond the deviation of a new player
+	for range 10000 {
+		updated = updated.Update(nil)
+	}
+
+	if updated.Deviation != rating.DefaultDeviation {
+		t.Errorf("deviation = %v, want %v", updated.Deviation, rating.DefaultDeviation)
+	}
+}
+
+func TestRating_Update_Draw(t *testing.T) {
+	player := rating.Default()
+
+	updated := player.Update([]rating.Result{{Opponent: rating.Default(), Score: 0.5}})
+
+	if math.Abs(updated.Rating-player.Rating) > 1e-9 {
+		t.Errorf("rating = %v, want %v after a draw between equals", updated.Rating, player.Rating)
+	}
+
+	if updated.Deviation >= player.Deviation {
+		t.Errorf("deviation = %v, want less than %v after a match", updated.Deviation, player.Deviation)
+	}
+}
+
+func TestRating_Expected(t *testing.T) {
+	strong := rating.Rating{Rating: 1800, Deviation: 50, Volatility: 0.06}
+	weak := rating.Rating{Rating: 1400, Deviation: 50, Volatility: 0.06}
+
+	if e := strong.Expected(strong); math.Abs(e-0.5) > 1e-9 {
+		t.Errorf("expected score against an equal = %v, want 0.5", e)
+	}
+
+	if e, other := strong.Expected(weak), weak.Expected(strong); e <= 0.5 || math.Abs(e+other-1) > 1e-9 {
+		t.Errorf("expected scores = %v and %v, want the stronger player favored and a sum of 1", e, other)
+	}
+}
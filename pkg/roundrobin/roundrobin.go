@@ -0,0 +1,247 @@
+// Package roundrobin schedules leagues where every player plays every other player once,
+// and ranks the players by their results.
+package roundrobin
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// MaxPlayers is the largest number of players of a league.
+	MaxPlayers = 16
+	// WinPoints and DrawPoints are the points given for a win and a draw.
+	WinPoints  = 3
+	DrawPoints = 1
+)
+
+var (
+	// ErrTooFewPlayers is returned when scheduling a league with less than two players.
+	ErrTooFewPlayers = errors.New("a league needs at least two players")
+	// ErrTooManyPlayers is returned when scheduling a league with more than MaxPlayers players.
+	ErrTooManyPlayers = errors.New("a league can't have more than 16 players")
+	// ErrInvalidPlayer is returned when a player has no name or the name of another player.
+	ErrInvalidPlayer = errors.New("players must have distinct names")
+	// ErrNotScheduled is returned when recording the result of a fixture that doesn't exist
+	// or was already played.
+	ErrNotScheduled = errors.New("fixture isn't scheduled")
+)
+
+type (
+	// League is the fixtures of a league, in the order they're played.
+	League struct {
+		Players  []string  `json:"players"`
+		Fixtures []Fixture `json:"fixtures"`
+	}
+
+	// Fixture is a match of the league.
+	Fixture struct {
+		// ID is the index of the fixture in the league.
+		ID int `json:"id"`
+		// Round is the round of the fixture, from 1, every player playing at most once a round.
+		Round int    `json:"round"`
+		Home  string `json:"home"`
+		Away  string `json:"away"`
+		// Played is true once the result of the fixture is recorded.
+		Played    bool `json:"played,omitempty"`
+		HomeScore int  `json:"home_score"`
+		AwayScore int  `json:"away_score"`
+	}
+
+	// Standing is the record of a player in the league.
+	Standing struct {
+		Player       string `json:"player"`
+		Played       int    `json:"played"`
+		Won          int    `json:"won"`
+		Drawn        int    `json:"drawn"`
+		Lost         int    `json:"lost"`
+		GoalsFor     int    `json:"goals_for"`
+		GoalsAgainst int    `json:"goals_against"`
+		Points       int    `json:"points"`
+	}
+)
+
+// New schedules a league between the players with the circle method. With an odd number
+// of players, a different player sits out each round.
+func New(players []string) (*League, error) {
+	if err := validate(players); err != nil {
+		return nil, err
+	}
+
+	l := &League{Players: players}
+
+	// an empty name stands for sitting out
+	circle := slices.Clone(players)
+	if len(circle)%2 == 1 {
+		circle = append(circle, "")
+	}
+
+	n := len(circle)
+
+	for round := 1; round < n; round++ {
+		for i := range n / 2 {
+			home, away := circle[i], circle[n-1-i]
+			if home == "" || away == "" {
+				continue
+			}
+
+			// the first player alternates playing at home, which the rotation does for the others
+			if i == 0 && round%2 == 0 {
+				home, away = away, home
+			}
+
+			l.Fixtures = append(l.Fixtures, Fixture{ID: len(l.Fixtures), Round: round, Home: home, Away: away})
+		}
+
+		// keep the first player in place and rotate the others
+		last := circle[n-1]
+		copy(circle[2:], circle[1:n-1])
+		circle[1] = last
+	}
+
+	return l, nil
+}
+
+// validate checks the players of a new league.
+func validate(players []string) error {
+	switch {
+	case len(players) < 2:
+		return ErrTooFewPlayers
+	case len(players) > MaxPlayers:
+		return ErrTooManyPlayers
+	}
+
+	seen := make(map[string]bool, len(players))
+
+	for _, name := range players {
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			return fmt.Errorf("%w: %q", ErrInvalidPlayer, name)
+		}
+
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// Next returns the next fixture to play, false when the league is over.
+func (l *League) Next() (Fixture, bool) {
+	for _, f := range l.Fixtures {
+		if !f.Played {
+			return f, true
+		}
+	}
+
+	return Fixture{}, false
+}
+
+// Over returns true once every fixture was played.
+func (l *League) Over() bool {
+	_, ok := l.Next()
+	return !ok
+}
+
+// Played returns the number of fixtures already played.
+func (l *League) Played() int {
+	var n int
+
+	for _, f := range l.Fixtures {
+		if f.Played {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Record records the score of the fixture with the ID.
+func (l *League) Record(id, homeScore, awayScore int) error {
+	if id < 0 || id >= len(l.Fixtures) || l.Fixtures[id].Played {
+		return fmt.Errorf("%w: %d", ErrNotScheduled, id)
+	}
+
+	f := &l.Fixtures[id]
+	f.Played, f.HomeScore, f.AwayScore = true, homeScore, awayScore
+
+	return nil
+}
+
+// Rounds returns the number of rounds of the league.
+func (l *League) Rounds() int {
+	if len(l.Fixtures) == 0 {
+		return 0
+	}
+
+	return l.Fixtures[len(l.Fixtures)-1].Round
+}
+
+// Round returns the fixtures of the round, from 1.
+func (l *League) Round(round int) []Fixture {
+	var fixtures []Fixture
+
+	for _, f := range l.Fixtures {
+		if f.Round == round {
+			fixtures = append(fixtures, f)
+		}
+	}
+
+	return fixtures
+}
+
+// Standings returns the record of every player, ranked by points, then goal difference,
+// then goals scored.
+func (l *League) Standings() []Standing {
+	standings := make([]Standing, len(l.Players))
+	index := make(map[string]int, len(l.Players))
+
+	for i, p := range l.Players {
+		standings[i].Player = p
+		index[p] = i
+	}
+
+	for _, f := range l.Fixtures {
+		if !f.Played {
+			continue
+		}
+
+		standings[index[f.Home]].add(f.HomeScore, f.AwayScore)
+		standings[index[f.Away]].add(f.AwayScore, f.HomeScore)
+	}
+
+	slices.SortStableFunc(standings, func(a, b Standing) int {
+		return cmp.Or(
+			cmp.Compare(b.Points, a.Points),
+			cmp.Compare(b.GoalDifference(), a.GoalDifference()),
+			cmp.Compare(b.GoalsFor, a.GoalsFor),
+		)
+	})
+
+	return standings
+}
+
+// add adds the result of a fixture where the player scored scored goals and conceded conceded.
+func (s *Standing) add(scored, conceded int) {
+	s.Played++
+	s.GoalsFor += scored
+	s.GoalsAgainst += conceded
+
+	switch {
+	case scored > conceded:
+		s.Won++
+		s.Points += WinPoints
+	case scored == conceded:
+		s.Drawn++
+		s.Points += DrawPoints
+	default:
+		s.Lost++
+	}
+}
+
+// GoalDifference returns the goals scored minus the goals conceded.
+func (s Standing) GoalDifference() int {
+	return s.GoalsFor - s.GoalsAgainst
+}
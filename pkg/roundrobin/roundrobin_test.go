@@ -0,0 +1,261 @@
+package roundrobin_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/gandarez/pong-multiplayer-go/pkg/roundrobin"
+)
+
+func TestNew_Schedule(t *testing.T) {
+	for size := 2; size <= roundrobin.MaxPlayers; size++ {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			players := make([]string, size)
+			for i := range players {
+				players[i] = fmt.Sprintf("P%d", i+1)
+			}
+
+			l, err := roundrobin.New(players)
+			if err != nil {
+				t.Fatalf("failed to schedule league: %v", err)
+			}
+
+			if want := size * (size - 1) / 2; len(l.Fixtures) != want {
+				t.Fatalf("%d fixtures, want %d", len(l.Fixtures), want)
+			}
+
+			// each pair plays exactly once
+			met := make(map[[2]string]int)
+
+			for i, f := range l.Fixtures {
+				if f.ID != i {
+					t.Errorf("fixture %d has ID %d", i, f.ID)
+				}
+
+				if f.Home == f.Away || !slices.Contains(players, f.Home) || !slices.Contains(players, f.Away) {
+					t.Fatalf("fixture %d between %q and %q", i, f.Home, f.Away)
+				}
+
+				pair := [2]string{f.Home, f.Away}
+				slices.Sort(pair[:])
+				met[pair]++
+			}
+
+			for pair, n := range met {
+				if n != 1 {
+					t.Errorf("%s and %s play %d times", pair[0], pair[1], n)
+				}
+			}
+
+			// every player plays at most once a round, all of them with an even number of players
+			// and all but one otherwise, a different one sitting out each round
+			rounds := size - 1 + size%2
+			if l.Rounds() != rounds {
+				t.Fatalf("%d rounds, want %d", l.Rounds(), rounds)
+			}
+
+			satOut := make(map[string]bool)
+
+			for round := 1; round <= rounds; round++ {
+				playing := make(map[string]bool)
+
+				for _, f := range l.Round(round) {
+					if playing[f.Home] || playing[f.Away] {
+						t.Fatalf("round %d: %s or %s plays twice", round, f.Home, f.Away)
+					}
+
+					playing[f.Home], playing[f.Away] = true, true
+				}
+
+				if len(playing) != size-size%2 {
+					t.Errorf("round %d: %d players, want %d", round, len(playing), size-size%2)
+				}
+
+				for _, p := range players {
+					if playing[p] {
+						continue
+					}
+
+					if satOut[p] {
+						t.Errorf("round %d: %s sits out again", round, p)
+					}
+
+					satOut[p] = true
+				}
+			}
+
+			// fixtures are played round after round
+			if !slices.IsSortedFunc(l.Fixtures, func(a, b roundrobin.Fixture) int { return a.Round - b.Round }) {
+				t.Error("fixtures aren't in the order of their rounds")
+			}
+		})
+	}
+}
+
+func TestNew_HomeAndAway(t *testing.T) {
+	l, err := roundrobin.New([]string{"Ada", "Bob", "Cyd", "Dan", "Eve", "Fay"})
+	if err != nil {
+		t.Fatalf("failed to schedule league: %v", err)
+	}
+
+	// the first player alternates playing at home
+	for round := 1; round <= l.Rounds(); round++ {
+		for _, f := range l.Round(round) {
+			if f.Home != "Ada" && f.Away != "Ada" {
+				continue
+			}
+
+			if home := f.Home == "Ada"; home != (round%2 == 1) {
+				t.Errorf("round %d: Ada at home = %t", round, home)
+			}
+		}
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		players []string
+		err     error
+	}{
+		"no players": {err: roundrobin.ErrTooFewPlayers},
+		"one player": {players: []string{"Ada"}, err: roundrobin.ErrTooFewPlayers},
+		"too many":   {players: make([]string, roundrobin.MaxPlayers+1), err: roundrobin.ErrTooManyPlayers},
+		"empty name": {players: []string{"Ada", ""}, err: roundrobin.ErrInvalidPlayer},
+		"same name":  {players: []string{"Ada", "Bob", "ADA"}, err: roundrobin.ErrInvalidPlayer},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := roundrobin.New(test.players); !errors.Is(err, test.err) {
+				t.Errorf("error = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestLeague_Record(t *testing.T) {
+	l, err := roundrobin.New([]string{"Ada", "Bob", "Cyd"})
+	if err != nil {
+		t.Fatalf("failed to schedule league: %v", err)
+	}
+
+	next, ok := l.Next()
+	if !ok || next.ID != 0 {
+		t.Fatalf("next fixture = %+v, %t, want the first one", next, ok)
+	}
+
+	if err := l.Record(0, 5, 3); err != nil {
+		t.Fatalf("failed to record fixture: %v", err)
+	}
+
+	for _, id := range []int{0, -1, len(l.Fixtures)} {
+		if err := l.Record(id, 1, 0); !errors.Is(err, roundrobin.ErrNotScheduled) {
+			t.Errorf("fixture %d: error = %v, want %v", id, err, roundrobin.ErrNotScheduled)
+		}
+	}
+
+	if next, _ := l.Next(); next.ID != 1 || l.Played() != 1 || l.Over() {
+		t.Errorf("next fixture = %d after %d played", next.ID, l.Played())
+	}
+
+	for _, f := range l.Fixtures[1:] {
+		if err := l.Record(f.ID, 0, 0); err != nil {
+			t.Fatalf("failed to record fixture: %v", err)
+		}
+	}
+
+	if !l.Over() {
+		t.Error("league isn't over after every fixture was played")
+	}
+}
+
+func TestLeague_Standings(t *testing.T) {
+	tests := map[string]struct {
+		results []result
+		want    []roundrobin.Standing
+	}{
+		// Ada and Bob have the most points, Bob ahead on goal difference
+		"goal difference": {
+			results: []result{
+				{"Ada", 0, "Bob", 5}, {"Ada", 5, "Cyd", 4}, {"Ada", 5, "Dan", 4},
+				{"Bob", 5, "Cyd", 3}, {"Bob", 2, "Dan", 5}, {"Cyd", 5, "Dan", 1},
+			},
+			want: []roundrobin.Standing{
+				{Player: "Bob", Played: 3, Won: 2, Lost: 1, GoalsFor: 12, GoalsAgainst: 8, Points: 6},
+				{Player: "Ada", Played: 3, Won: 2, Lost: 1, GoalsFor: 10, GoalsAgainst: 13, Points: 6},
+				{Player: "Cyd", Played: 3, Won: 1, Lost: 2, GoalsFor: 12, GoalsAgainst: 11, Points: 3},
+				{Player: "Dan", Played: 3, Won: 1, Lost: 2, GoalsFor: 10, GoalsAgainst: 12, Points: 3},
+			},
+		},
+		// everyone has the same points and goal difference, Cyd scored the most
+		"goals scored": {
+			results: []result{
+				{"Ada", 1, "Bob", 1}, {"Ada", 0, "Cyd", 0}, {"Ada", 1, "Dan", 1},
+				{"Bob", 3, "Cyd", 3}, {"Bob", 0, "Dan", 0}, {"Cyd", 4, "Dan", 4},
+			},
+			want: []roundrobin.Standing{
+				{Player: "Cyd", Played: 3, Drawn: 3, GoalsFor: 7, GoalsAgainst: 7, Points: 3},
+				{Player: "Dan", Played: 3, Drawn: 3, GoalsFor: 5, GoalsAgainst: 5, Points: 3},
+				{Player: "Bob", Played: 3, Drawn: 3, GoalsFor: 4, GoalsAgainst: 4, Points: 3},
+				{Player: "Ada", Played: 3, Drawn: 3, GoalsFor: 2, GoalsAgainst: 2, Points: 3},
+			},
+		},
+		// nothing is played yet, the players keep the order they were entered in
+		"nothing played": {
+			want: []roundrobin.Standing{{Player: "Ada"}, {Player: "Bob"}, {Player: "Cyd"}, {Player: "Dan"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			l, err := roundrobin.New([]string{"Ada", "Bob", "Cyd", "Dan"})
+			if err != nil {
+				t.Fatalf("failed to schedule league: %v", err)
+			}
+
+			for _, r := range test.results {
+				r.record(t, l)
+			}
+
+			if got := l.Standings(); !slices.Equal(got, test.want) {
+				t.Errorf("standings = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+// result is the score of the fixture between two players, whoever plays at home.
+type result struct {
+	first       string
+	firstScore  int
+	second      string
+	secondScore int
+}
+
+// record records the result in the league.
+func (r result) record(t *testing.T, l *roundrobin.League) {
+	t.Helper()
+
+	for _, f := range l.Fixtures {
+		var err error
+
+		switch {
+		case f.Home == r.first && f.Away == r.second:
+			err = l.Record(f.ID, r.firstScore, r.secondScore)
+		case f.Home == r.second && f.Away == r.first:
+			err = l.Record(f.ID, r.secondScore, r.firstScore)
+		default:
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("failed to record fixture: %v", err)
+		}
+
+		return
+	}
+
+	t.Fatalf("no fixture between %s and %s", r.first, r.second)
+}